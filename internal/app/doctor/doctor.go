@@ -0,0 +1,106 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/errs"
+)
+
+type Status uint8
+
+const (
+	StatusOK Status = iota
+	StatusWarning
+	StatusFailed
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusWarning:
+		return "warn"
+	case StatusFailed:
+		return "fail"
+	default:
+		return "ok"
+	}
+}
+
+// Check - result of one check, Fix tells what to do when it is not ok.
+type Check struct {
+	Name    string
+	Status  Status
+	Details string
+	Fix     string
+}
+
+func (c Check) String() string {
+	line := fmt.Sprintf("%-4s  %s", c.Status, c.Name)
+	if c.Details != "" {
+		line += ": " + strings.ReplaceAll(c.Details, "\n", "\n      ")
+	}
+	if c.Status != StatusOK && c.Fix != "" {
+		line += "\n      fix: " + c.Fix
+	}
+	return line
+}
+
+type Doctor struct {
+	directory  string
+	configPath string
+	project    *configs.Project
+}
+
+func NewDoctor(directory, configPath string) *Doctor {
+	if directory == "" {
+		directory = "."
+	}
+	return &Doctor{directory: directory, configPath: configPath}
+}
+
+// Run checks the tools, the config and the project in the directory. The project checks are skipped when the
+// config can not be read.
+func (d *Doctor) Run() []Check {
+	checks := []Check{d.checkConfig()}
+	checks = append(checks, d.checkTools()...)
+	if d.project == nil {
+		return checks
+	}
+	checks = append(checks, d.checkModule())
+	checks = append(checks, d.checkMigrations()...)
+	checks = append(checks, d.checkWiring()...)
+	return checks
+}
+
+func (d *Doctor) checkConfig() Check {
+	check := Check{Name: "config " + d.configPath}
+	project, err := configs.NewProject(d.configPath)
+	var invalid *errs.Error
+	switch {
+	case errors.As(err, &invalid) && invalid.Params["details"] != "":
+		check.Status = StatusFailed
+		check.Details = invalid.Params["details"]
+		check.Fix = "correct the listed keys of " + filepath.Base(d.configPath)
+	case err != nil:
+		check.Status = StatusFailed
+		check.Details = err.Error()
+		check.Fix = "create the config, see the README example, or pass its path with -c"
+	default:
+		d.project = project
+	}
+	return check
+}
+
+// Failed returns the number of failed checks.
+func Failed(checks []Check) int {
+	failed := 0
+	for _, check := range checks {
+		if check.Status == StatusFailed {
+			failed++
+		}
+	}
+	return failed
+}
@@ -0,0 +1,117 @@
+package doctor
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+var migrationPattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+func (d *Doctor) checkModule() Check {
+	check := Check{Name: "go.mod module"}
+	data, err := os.ReadFile(filepath.Join(d.directory, "go.mod"))
+	if errors.Is(err, os.ErrNotExist) {
+		check.Status = StatusWarning
+		check.Details = "go.mod not found"
+		check.Fix = "run creathor to generate the project"
+		return check
+	}
+	if err != nil {
+		check.Status = StatusFailed
+		check.Details = err.Error()
+		return check
+	}
+	module := modfile.ModulePath(data)
+	check.Details = module
+	if module != d.project.Module {
+		check.Status = StatusFailed
+		check.Details = fmt.Sprintf("%s, the config has %s", module, d.project.Module)
+		check.Fix = fmt.Sprintf(
+			"set module to %s in the config, or rename the module and its imports to %s",
+			module,
+			d.project.Module,
+		)
+	}
+	return check
+}
+
+// migration - SQL migration file named like the ones the generators write, 000002_articles.up.sql.
+type migration struct {
+	number int
+	name   string
+}
+
+// checkMigrations checks that the SQL migrations are numbered 1, 2, 3... without gaps and that a number belongs to a
+// single migration, migrate applies them in that order and the generators number new ones after the last file.
+func (d *Doctor) checkMigrations() []Check {
+	if d.project.Database == "" {
+		return nil
+	}
+	directory := path.Join("internal", "pkg", d.project.Database, "migrations")
+	entries, err := os.ReadDir(filepath.Join(d.directory, directory))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	check := Check{Name: "migrations " + directory}
+	if err != nil {
+		check.Status = StatusFailed
+		check.Details = err.Error()
+		return []Check{check}
+	}
+	var migrations []migration
+	var problems, fixes []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationPattern.FindStringSubmatch(entry.Name())
+		if match == nil {
+			problems = append(problems, entry.Name()+" is not named NNNNNN_name.up.sql or NNNNNN_name.down.sql")
+			fixes = append(fixes, "rename or move "+entry.Name())
+			continue
+		}
+		number, _ := strconv.Atoi(match[1])
+		migrations = append(migrations, migration{number: number, name: match[2]})
+	}
+	slices.SortStableFunc(migrations, func(a, b migration) int {
+		return a.number - b.number
+	})
+	expected := 1
+	names := map[int]string{}
+	for _, m := range migrations {
+		if name, ok := names[m.number]; ok {
+			if name != m.name {
+				problems = append(problems, fmt.Sprintf("%s and %s share number %d", name, m.name, m.number))
+				fixes = append(fixes, fmt.Sprintf("renumber %06d_%s.*.sql after the last migration", m.number, m.name))
+			}
+			continue
+		}
+		if m.number != expected {
+			problems = append(problems, fmt.Sprintf("%s is number %d, expected %d", m.name, m.number, expected))
+			fixes = append(fixes, fmt.Sprintf("rename %06d_%s.*.sql to %06d_%s.*.sql", m.number, m.name, expected, m.name))
+		}
+		names[m.number] = m.name
+		expected = m.number + 1
+	}
+	if len(problems) > 0 {
+		// the up and the down file of a migration give the same problem
+		problems, fixes = slices.Compact(problems), slices.Compact(fixes)
+		check.Status = StatusFailed
+		check.Details = strings.Join(problems, "\n")
+		check.Fix = strings.Join(fixes, "; ")
+		return []Check{check}
+	}
+	if len(names) > 0 {
+		check.Details = fmt.Sprintf("numbered 1 to %d", len(names))
+	}
+	return []Check{check}
+}
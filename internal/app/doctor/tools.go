@@ -0,0 +1,175 @@
+package doctor
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/semver"
+)
+
+var versionPattern = regexp.MustCompile(`\d+\.\d+(\.\d+)?`)
+
+// tool - external program run by creathor or by the tasks of the generated project. A missing required tool fails
+// the check, a missing optional one is a warning.
+type tool struct {
+	name     string
+	args     []string
+	minimum  string
+	exact    bool
+	usage    string
+	install  string
+	required func(project *configs.Project) bool
+}
+
+func always(*configs.Project) bool { return true }
+
+func never(*configs.Project) bool { return false }
+
+func (d *Doctor) tools() []tool {
+	mock := d.requirement("go.uber.org/mock")
+	mockInstall := mock
+	if mockInstall == "" {
+		mockInstall = "latest"
+	}
+	return []tool{
+		{
+			name:     "go",
+			args:     []string{"env", "GOVERSION"},
+			minimum:  "v" + d.goVersion(),
+			usage:    "go mod tidy and building the project",
+			install:  "install it, see https://go.dev/doc/install",
+			required: always,
+		},
+		{
+			name:    "buf",
+			args:    []string{"--version"},
+			minimum: "v1.32.0",
+			usage:   "buf dep update and buf generate of the gRPC code",
+			install: "go install github.com/bufbuild/buf/cmd/buf@latest",
+			required: func(project *configs.Project) bool {
+				return project == nil || project.GRPCEnabled
+			},
+		},
+		{
+			name:    "task",
+			args:    []string{"--version"},
+			minimum: "v3.0.0",
+			usage:   "task docs after generating and the tasks of Taskfile.yaml",
+			install: "go install github.com/go-task/task/v3/cmd/task@latest",
+			required: func(project *configs.Project) bool {
+				return project == nil || project.TaskEnabled
+			},
+		},
+		{
+			name:     "helm",
+			args:     []string{"version", "--short"},
+			minimum:  "v3.0.0",
+			usage:    "helm create of the deployment chart",
+			install:  "install it, see https://helm.sh/docs/intro/install/",
+			required: always,
+		},
+		{
+			name:     "mockgen",
+			args:     []string{"-version"},
+			minimum:  mock,
+			exact:    true,
+			usage:    "go generate of the mocks outside creathor",
+			install:  "go install go.uber.org/mock/mockgen@" + mockInstall,
+			required: never,
+		},
+		{
+			name:     "golangci-lint",
+			args:     []string{"--version"},
+			minimum:  "v2.0.0",
+			usage:    "task clean and the lint job of the CI, the config is in the v2 format",
+			install:  "go install github.com/golangci/golangci-lint/v2/cmd/golangci-lint@latest",
+			required: never,
+		},
+		{
+			name:     "golines",
+			args:     []string{"--version"},
+			usage:    "task clean",
+			install:  "go install github.com/segmentio/golines@latest",
+			required: never,
+		},
+	}
+}
+
+func (d *Doctor) checkTools() []Check {
+	tools := d.tools()
+	checks := make([]Check, 0, len(tools))
+	for _, t := range tools {
+		checks = append(checks, d.checkTool(t))
+	}
+	return checks
+}
+
+func (d *Doctor) checkTool(t tool) Check {
+	check := Check{Name: t.name, Fix: t.install}
+	missing := StatusWarning
+	if t.required(d.project) {
+		missing = StatusFailed
+	}
+	if _, err := exec.LookPath(t.name); err != nil {
+		check.Status = missing
+		check.Details = "not found, used for " + t.usage
+		return check
+	}
+	output, err := exec.Command(t.name, t.args...).CombinedOutput()
+	if err != nil {
+		check.Status = StatusWarning
+		check.Details = fmt.Sprintf("%s %s failed: %s", t.name, strings.Join(t.args, " "), strings.TrimSpace(string(output)))
+		return check
+	}
+	found := versionPattern.FindString(string(output))
+	if found == "" {
+		check.Details = "installed, version unknown"
+		return check
+	}
+	installed := "v" + found
+	check.Details = installed
+	if !semver.IsValid(t.minimum) {
+		return check
+	}
+	switch {
+	case t.exact && semver.Compare(installed, t.minimum) != 0:
+		check.Status = StatusWarning
+		check.Details = fmt.Sprintf("%s, the project uses %s", installed, t.minimum)
+	case !t.exact && semver.Compare(installed, t.minimum) < 0:
+		check.Status = missing
+		check.Details = fmt.Sprintf("%s, %s or newer is needed for %s", installed, t.minimum, t.usage)
+	}
+	return check
+}
+
+// goVersion returns the Go version of the config, or the default one without a config.
+func (d *Doctor) goVersion() string {
+	if d.project == nil || d.project.GoVersion == "" {
+		return "1.24"
+	}
+	return d.project.GoVersion
+}
+
+// requirement returns the version of modulePath required by the go.mod of the project, or an empty string.
+func (d *Doctor) requirement(modulePath string) string {
+	data, err := os.ReadFile(filepath.Join(d.directory, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	file, err := modfile.ParseLax("go.mod", data, nil)
+	if err != nil {
+		return ""
+	}
+	for _, require := range file.Require {
+		if require.Mod.Path == modulePath {
+			return require.Mod.Version
+		}
+	}
+	return ""
+}
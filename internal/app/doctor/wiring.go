@@ -0,0 +1,147 @@
+package doctor
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+)
+
+// wiring - names used by the App of an app, the fields of the struct and the fields of a read by every method.
+type wiring struct {
+	fields  map[string]bool
+	methods map[string]map[string]bool
+}
+
+func readWiring(filePath string) (*wiring, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), filePath, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+	w := &wiring{fields: map[string]bool{}, methods: map[string]map[string]bool{}}
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || typeSpec.Name.Name != "App" {
+					continue
+				}
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+				for _, field := range structType.Fields.List {
+					for _, name := range field.Names {
+						w.fields[name.Name] = true
+					}
+				}
+			}
+		case *ast.FuncDecl:
+			if decl.Recv == nil || decl.Body == nil {
+				continue
+			}
+			w.methods[decl.Name.Name] = selectors(decl.Body, "a")
+		}
+	}
+	return w, nil
+}
+
+// selectors returns the names selected from the identifier receiver in node.
+func selectors(node ast.Node, receiver string) map[string]bool {
+	names := map[string]bool{}
+	ast.Inspect(node, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok && ident.Name == receiver {
+				names[selector.Sel.Name] = true
+			}
+		}
+		return true
+	})
+	return names
+}
+
+// checkWiring checks that every app of the config is provided to fx and that every entity is built in NewApp and
+// registered on the servers the app serves.
+func (d *Doctor) checkWiring() []Check {
+	containers := path.Join("internal", "pkg", "containers", "fx.go")
+	provided := map[string]bool{}
+	if file, err := parser.ParseFile(token.NewFileSet(), filepath.Join(d.directory, containers), nil, parser.SkipObjectResolution); err == nil {
+		ast.Inspect(file, func(node ast.Node) bool {
+			if selector, ok := node.(*ast.SelectorExpr); ok && selector.Sel.Name == "NewApp" {
+				if ident, ok := selector.X.(*ast.Ident); ok {
+					provided[ident.Name] = true
+				}
+			}
+			return true
+		})
+	}
+	checks := make([]Check, 0, len(d.project.Apps))
+	for _, app := range d.project.Apps {
+		appFile := path.Join("internal", "app", app.AppName(), "app.go")
+		check := Check{Name: "wiring " + appFile}
+		w, err := readWiring(filepath.Join(d.directory, appFile))
+		if err != nil {
+			check.Status = StatusFailed
+			check.Details = err.Error()
+			check.Fix = "run creathor to generate the app"
+			checks = append(checks, check)
+			continue
+		}
+		var problems, fixes []string
+		if !provided[app.AppAlias()] {
+			problems = append(problems, fmt.Sprintf("%s.NewApp is not provided in %s", app.AppAlias(), containers))
+			fixes = append(fixes, fmt.Sprintf("%s.NewApp in fx.Provide of FXModule", app.AppAlias()))
+		}
+		for _, entity := range app.Entities {
+			p, f := entityWiring(app, entity, w)
+			problems = append(problems, p...)
+			fixes = append(fixes, f...)
+		}
+		if len(problems) > 0 {
+			check.Status = StatusFailed
+			check.Details = strings.Join(problems, "\n")
+			check.Fix = "run creathor to regenerate the declarations, the ones modified by hand need " +
+				strings.Join(fixes, "; ")
+		} else {
+			names := make([]string, 0, len(app.Entities))
+			for _, entity := range app.Entities {
+				names = append(names, entity.Name)
+			}
+			check.Details = strings.Join(names, ", ")
+		}
+		checks = append(checks, check)
+	}
+	return checks
+}
+
+func entityWiring(app configs.AppConfig, entity configs.EntityConfig, w *wiring) ([]string, []string) {
+	var problems, fixes []string
+	useCase := entity.GetUseCasePrivateVariableName()
+	if !w.fields[useCase] {
+		problems = append(problems, fmt.Sprintf("%s has no %s in App", entity.Name, useCase))
+		fixes = append(fixes, fmt.Sprintf("a %s field built in NewApp", useCase))
+	}
+	registrations := []struct {
+		enabled bool
+		method  string
+		handler string
+	}{
+		{enabled: app.HTTPEnabled, method: "RegisterHTTP", handler: entity.GetHTTPHandlerPrivateVariableName()},
+		{enabled: app.GRPCEnabled, method: "RegisterGRPC", handler: entity.GetGRPCHandlerPrivateVariableName()},
+		{enabled: app.KafkaEnabled, method: "RegisterKafka", handler: entity.GetKafkaHandlerPrivateVariableName()},
+	}
+	for _, registration := range registrations {
+		if !registration.enabled || w.methods[registration.method][registration.handler] {
+			continue
+		}
+		problems = append(problems, fmt.Sprintf("%s is not registered in %s", entity.Name, registration.method))
+		fixes = append(fixes, fmt.Sprintf("a.%s registered in %s", registration.handler, registration.method))
+	}
+	return problems, fixes
+}
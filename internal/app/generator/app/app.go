@@ -54,15 +54,25 @@ func (a App) file() *ast.File {
 	if a.app.GRPCEnabled {
 		decls = append(decls, a.registerGRPC())
 	}
+	if a.app.GatewayEnabled {
+		decls = append(decls, a.registerGateway())
+	}
 	if a.app.KafkaEnabled {
 		decls = append(decls, a.registerKafka())
 	}
+	if a.app.MongoEnabled() {
+		decls = append(decls, a.createIndexes())
+	}
 	return &ast.File{
 		Name:  ast.NewIdent(a.app.AppName()),
 		Decls: decls,
 	}
 }
 
+func (a App) watchEnabled() bool {
+	return a.app.GRPCEnabled && a.app.WatchEnabled
+}
+
 func (a App) imports() *ast.GenDecl {
 	specs := []ast.Spec{
 		&ast.ImportSpec{
@@ -122,9 +132,10 @@ func (a App) imports() *ast.GenDecl {
 				Path: &ast.BasicLit{
 					Kind: token.STRING,
 					Value: fmt.Sprintf(
-						`"%s/internal/app/%s/repositories/postgres/%s"`,
+						`"%s/internal/app/%s/repositories/%s/%s"`,
 						a.app.Module,
 						a.app.AppName(),
+						entity.RepositoryDir(),
 						entity.DirName(),
 					),
 				},
@@ -171,6 +182,40 @@ func (a App) imports() *ast.GenDecl {
 				},
 			)
 		}
+		if entity.CacheEnabled {
+			specs = append(
+				specs,
+				&ast.ImportSpec{
+					Name: ast.NewIdent(fmt.Sprintf("%sCaches", entity.LowerCamelName())),
+					Path: &ast.BasicLit{
+						Kind: token.STRING,
+						Value: fmt.Sprintf(
+							`"%s/internal/app/%s/repositories/cache/%s"`,
+							a.app.Module,
+							a.app.AppName(),
+							entity.DirName(),
+						),
+					},
+				},
+			)
+		}
+		if a.watchEnabled() {
+			specs = append(
+				specs,
+				&ast.ImportSpec{
+					Name: ast.NewIdent(fmt.Sprintf("%sBroadcasts", entity.LowerCamelName())),
+					Path: &ast.BasicLit{
+						Kind: token.STRING,
+						Value: fmt.Sprintf(
+							`"%s/internal/app/%s/repositories/memory/%s"`,
+							a.app.Module,
+							a.app.AppName(),
+							entity.DirName(),
+						),
+					},
+				},
+			)
+		}
 		if a.app.HTTPEnabled {
 			specs = append(
 				specs,
@@ -229,6 +274,41 @@ func (a App) imports() *ast.GenDecl {
 			)
 		}
 	}
+	if a.app.CacheEnabled() {
+		specs = append(specs, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: a.app.ProjectConfig.CacheImportPath(),
+			},
+		})
+	}
+	if a.app.MongoEnabled() {
+		specs = append(specs, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: a.app.ProjectConfig.MongoImportPath(),
+			},
+		})
+	}
+	if a.app.GatewayEnabled || a.app.MongoEnabled() {
+		specs = append(specs, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"context"`,
+			},
+		})
+	}
+	if a.app.GatewayEnabled {
+		specs = append(
+			specs,
+			&ast.ImportSpec{
+				Path: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: `"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"`,
+				},
+			},
+		)
+	}
 
 	return &ast.GenDecl{
 		Tok:   token.IMPORT,
@@ -306,6 +386,30 @@ func (a App) constructor() *ast.FuncDecl {
 			},
 		)
 	}
+	if a.app.CacheEnabled() {
+		args = append(args, &ast.Field{
+			Names: []*ast.Ident{
+				ast.NewIdent("cache"),
+			},
+			Type: &ast.SelectorExpr{
+				X:   ast.NewIdent("cache"),
+				Sel: ast.NewIdent("Cache"),
+			},
+		})
+	}
+	if a.app.MongoEnabled() {
+		args = append(args, &ast.Field{
+			Names: []*ast.Ident{
+				ast.NewIdent("mongoDB"),
+			},
+			Type: &ast.StarExpr{
+				X: &ast.SelectorExpr{
+					X:   ast.NewIdent("mongo"),
+					Sel: ast.NewIdent("Database"),
+				},
+			},
+		})
+	}
 	exprs := []ast.Expr{
 		&ast.KeyValueExpr{
 			Key:   ast.NewIdent("readDB"),
@@ -336,6 +440,17 @@ func (a App) constructor() *ast.FuncDecl {
 		List: []ast.Stmt{},
 	}
 	for _, entity := range a.app.Entities {
+		repositoryArgs := []ast.Expr{
+			ast.NewIdent("readDB"),
+			ast.NewIdent("writeDB"),
+			ast.NewIdent("logger"),
+		}
+		if entity.MongoEnabled() {
+			repositoryArgs = []ast.Expr{
+				ast.NewIdent("mongoDB"),
+				ast.NewIdent("logger"),
+			}
+		}
 		exprs = append(exprs,
 			&ast.KeyValueExpr{
 				Key:   ast.NewIdent(entity.GetRepositoryPrivateVariableName()),
@@ -364,14 +479,38 @@ func (a App) constructor() *ast.FuncDecl {
 							),
 							Sel: ast.NewIdent(entity.GetRepositoryConstructorName()),
 						},
+						Args: repositoryArgs,
+					},
+				},
+			})
+		serviceRepository := entity.GetRepositoryPrivateVariableName()
+		if entity.CacheEnabled {
+			serviceRepository = entity.GetCachedRepositoryPrivateVariableName()
+			body.List = append(body.List, &ast.AssignStmt{
+				Lhs: []ast.Expr{
+					ast.NewIdent(entity.GetCachedRepositoryPrivateVariableName()),
+				},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent(fmt.Sprintf("%sCaches", entity.LowerCamelName())),
+							Sel: ast.NewIdent(entity.CachedRepositoryConstructorName()),
+						},
 						Args: []ast.Expr{
-							ast.NewIdent("readDB"),
-							ast.NewIdent("writeDB"),
+							ast.NewIdent(entity.GetRepositoryPrivateVariableName()),
+							ast.NewIdent("cache"),
 							ast.NewIdent("logger"),
 						},
 					},
 				},
-			},
+			})
+			exprs = append(exprs, &ast.KeyValueExpr{
+				Key:   ast.NewIdent(entity.GetCachedRepositoryPrivateVariableName()),
+				Value: ast.NewIdent(entity.GetCachedRepositoryPrivateVariableName()),
+			})
+		}
+		body.List = append(body.List,
 			&ast.AssignStmt{
 				Lhs: []ast.Expr{
 					ast.NewIdent(entity.GetServicePrivateVariableName()),
@@ -384,7 +523,7 @@ func (a App) constructor() *ast.FuncDecl {
 							Sel: ast.NewIdent(entity.GetServiceConstructorName()),
 						},
 						Args: []ast.Expr{
-							ast.NewIdent(entity.GetRepositoryPrivateVariableName()),
+							ast.NewIdent(serviceRepository),
 							ast.NewIdent("clock"),
 							ast.NewIdent("logger"),
 							ast.NewIdent("uuidGenerator"),
@@ -412,6 +551,28 @@ func (a App) constructor() *ast.FuncDecl {
 				},
 			})
 		}
+		if a.watchEnabled() {
+			body.List = append(body.List, &ast.AssignStmt{
+				Lhs: []ast.Expr{
+					ast.NewIdent(entity.GetEventBroadcasterPrivateVariableName()),
+				},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X: ast.NewIdent(
+								fmt.Sprintf("%sBroadcasts", entity.LowerCamelName()),
+							),
+							Sel: ast.NewIdent(entity.EventBroadcasterConstructorName()),
+						},
+					},
+				},
+			})
+			exprs = append(exprs, &ast.KeyValueExpr{
+				Key:   ast.NewIdent(entity.GetEventBroadcasterPrivateVariableName()),
+				Value: ast.NewIdent(entity.GetEventBroadcasterPrivateVariableName()),
+			})
+		}
 		useCaseArgs := []ast.Expr{
 			ast.NewIdent(entity.GetServicePrivateVariableName()),
 		}
@@ -420,6 +581,11 @@ func (a App) constructor() *ast.FuncDecl {
 				useCaseArgs,
 				ast.NewIdent(entity.GetEventProducerPrivateVariableName()),
 			)
+		} else if a.watchEnabled() {
+			useCaseArgs = append(
+				useCaseArgs,
+				ast.NewIdent(entity.GetEventBroadcasterPrivateVariableName()),
+			)
 		}
 		useCaseArgs = append(useCaseArgs, ast.NewIdent("dtxManager"), ast.NewIdent("logger"))
 		body.List = append(body.List, &ast.AssignStmt{
@@ -465,6 +631,22 @@ func (a App) constructor() *ast.FuncDecl {
 			})
 		}
 		if a.app.KafkaEnabled {
+			kafkaHandlerArgs := []ast.Expr{
+				ast.NewIdent(entity.GetUseCasePrivateVariableName()),
+			}
+			if a.watchEnabled() {
+				kafkaHandlerArgs = append(
+					kafkaHandlerArgs,
+					ast.NewIdent(entity.GetEventBroadcasterPrivateVariableName()),
+				)
+			}
+			if entity.CacheEnabled {
+				kafkaHandlerArgs = append(
+					kafkaHandlerArgs,
+					ast.NewIdent(entity.GetCachedRepositoryPrivateVariableName()),
+				)
+			}
+			kafkaHandlerArgs = append(kafkaHandlerArgs, ast.NewIdent("logger"))
 			body.List = append(body.List, &ast.AssignStmt{
 				Lhs: []ast.Expr{
 					ast.NewIdent(entity.GetKafkaHandlerPrivateVariableName()),
@@ -478,10 +660,7 @@ func (a App) constructor() *ast.FuncDecl {
 							),
 							Sel: ast.NewIdent(entity.KafkaHandlerConstructorName()),
 						},
-						Args: []ast.Expr{
-							ast.NewIdent(entity.GetUseCasePrivateVariableName()),
-							ast.NewIdent("logger"),
-						},
+						Args: kafkaHandlerArgs,
 					},
 				},
 			})
@@ -494,6 +673,16 @@ func (a App) constructor() *ast.FuncDecl {
 			})
 		}
 		if a.app.GRPCEnabled {
+			grpcHandlerArgs := []ast.Expr{
+				ast.NewIdent(entity.GetUseCasePrivateVariableName()),
+			}
+			if a.watchEnabled() {
+				grpcHandlerArgs = append(
+					grpcHandlerArgs,
+					ast.NewIdent(entity.GetEventBroadcasterPrivateVariableName()),
+				)
+			}
+			grpcHandlerArgs = append(grpcHandlerArgs, ast.NewIdent("logger"))
 			body.List = append(body.List, &ast.AssignStmt{
 				Lhs: []ast.Expr{
 					ast.NewIdent(entity.GetGRPCHandlerPrivateVariableName()),
@@ -507,10 +696,7 @@ func (a App) constructor() *ast.FuncDecl {
 							),
 							Sel: ast.NewIdent(entity.GetGRPCHandlerConstructorName()),
 						},
-						Args: []ast.Expr{
-							ast.NewIdent(entity.GetUseCasePrivateVariableName()),
-							ast.NewIdent("logger"),
-						},
+						Args: grpcHandlerArgs,
 					},
 				},
 			})
@@ -650,6 +836,32 @@ func (a App) structure() *ast.GenDecl {
 					},
 				},
 			})
+		if entity.CacheEnabled {
+			structType.Fields.List = append(structType.Fields.List, &ast.Field{
+				Names: []*ast.Ident{
+					ast.NewIdent(entity.GetCachedRepositoryPrivateVariableName()),
+				},
+				Type: &ast.StarExpr{
+					X: &ast.SelectorExpr{
+						X:   ast.NewIdent(fmt.Sprintf("%sCaches", entity.LowerCamelName())),
+						Sel: ast.NewIdent(entity.CachedRepositoryTypeName()),
+					},
+				},
+			})
+		}
+		if a.watchEnabled() {
+			structType.Fields.List = append(structType.Fields.List, &ast.Field{
+				Names: []*ast.Ident{
+					ast.NewIdent(entity.GetEventBroadcasterPrivateVariableName()),
+				},
+				Type: &ast.StarExpr{
+					X: &ast.SelectorExpr{
+						X:   ast.NewIdent(fmt.Sprintf("%sBroadcasts", entity.LowerCamelName())),
+						Sel: ast.NewIdent(entity.EventBroadcasterTypeName()),
+					},
+				},
+			})
+		}
 		if a.app.HTTPEnabled {
 			structType.Fields.List = append(structType.Fields.List, &ast.Field{
 				Names: []*ast.Ident{
@@ -785,6 +997,117 @@ func (a App) registerGRPC() *ast.FuncDecl {
 	}
 }
 
+func (a App) registerGateway() *ast.FuncDecl {
+	stmts := make([]ast.Stmt, 0, len(a.app.Entities)+1)
+	for _, entity := range a.app.Entities {
+		stmts = append(stmts, &ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{
+					ast.NewIdent("err"),
+				},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent(entity.ProtoPackage),
+							Sel: ast.NewIdent(entity.GetGatewayRegisterName()),
+						},
+						Args: []ast.Expr{
+							&ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("context"),
+									Sel: ast.NewIdent("Background"),
+								},
+							},
+							ast.NewIdent("mux"),
+							&ast.SelectorExpr{
+								X:   ast.NewIdent("endpoint"),
+								Sel: ast.NewIdent("Address"),
+							},
+							&ast.SelectorExpr{
+								X:   ast.NewIdent("endpoint"),
+								Sel: ast.NewIdent("Options"),
+							},
+						},
+					},
+				},
+			},
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent("err"),
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							ast.NewIdent("err"),
+						},
+					},
+				},
+			},
+		})
+	}
+	stmts = append(stmts, &ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent("nil"),
+		},
+	})
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{
+						ast.NewIdent("a"),
+					},
+					Type: &ast.StarExpr{
+						X: ast.NewIdent("App"),
+					},
+				},
+			},
+		},
+		Name: ast.NewIdent("RegisterGateway"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							ast.NewIdent("mux"),
+						},
+						Type: &ast.StarExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent("runtime"),
+								Sel: ast.NewIdent("ServeMux"),
+							},
+						},
+					},
+					{
+						Names: []*ast.Ident{
+							ast.NewIdent("endpoint"),
+						},
+						Type: &ast.StarExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent("http"),
+								Sel: ast.NewIdent("GatewayEndpoint"),
+							},
+						},
+					},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: ast.NewIdent("error"),
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: stmts,
+		},
+	}
+}
+
 func (a App) registerHTTP() *ast.FuncDecl {
 	stmts := make([]ast.Stmt, 0, len(a.app.Entities)+1)
 	for _, entity := range a.app.Entities {
@@ -797,12 +1120,8 @@ func (a App) registerHTTP() *ast.FuncDecl {
 					},
 					Args: []ast.Expr{
 						&ast.BasicLit{
-							Kind: token.STRING,
-							Value: fmt.Sprintf(
-								`"/api/v1/%s/%s"`,
-								a.app.AppName(),
-								entity.GetHTTPPath(),
-							),
+							Kind:  token.STRING,
+							Value: fmt.Sprintf(`"%s"`, entity.GetHTTPMountPath()),
 						},
 						&ast.CallExpr{
 							Fun: &ast.SelectorExpr{
@@ -870,139 +1189,160 @@ func (a App) registerHTTP() *ast.FuncDecl {
 }
 
 func (a App) registerKafka() *ast.FuncDecl {
-	stmts := make([]ast.Stmt, 0, len(a.app.Entities)+1)
+	stmts := make([]ast.Stmt, 0, 6*len(a.app.Entities)+1)
 	for _, entity := range a.app.Entities {
-		stmts = append(stmts,
-			&ast.ExprStmt{
-				X: &ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X:   ast.NewIdent("consumer"),
-						Sel: ast.NewIdent("AddHandler"),
+		handler := entity.GetKafkaHandlerPrivateVariableName()
+		topics := map[string]string{
+			"Created": entity.CreatedTopicName(),
+			"Updated": entity.UpdatedTopicName(),
+			"Deleted": entity.DeletedTopicName(),
+		}
+		groups := map[string]string{
+			"Created": entity.KafkaCreatedConsumerGroup(),
+			"Updated": entity.KafkaUpdatedConsumerGroup(),
+			"Deleted": entity.KafkaDeletedConsumerGroup(),
+		}
+		for _, event := range []string{"Created", "Updated", "Deleted"} {
+			stmts = append(stmts, addKafkaHandler("NewHandler", topics[event], groups[event], handler, event))
+		}
+		for _, event := range entity.KafkaBroadcastEvents() {
+			// every instance feeds its own Watch streams and drops its own cache, so the broadcast handlers read the
+			// topic without a consumer group
+			stmts = append(stmts, addKafkaHandler("NewBroadcastHandler", topics[event], "", handler, "Broadcast"+event))
+		}
+	}
+	stmts = append(stmts, &ast.ReturnStmt{
+		Results: []ast.Expr{
+			ast.NewIdent("nil"),
+		},
+	})
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{
+						ast.NewIdent("a"),
 					},
-					Args: []ast.Expr{
-						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X: &ast.Ident{
-									Name: "kafka",
-								},
-								Sel: &ast.Ident{
-									Name: "NewHandler",
-								},
-							},
-							Args: []ast.Expr{
-								&ast.BasicLit{
-									Kind:  token.STRING,
-									Value: fmt.Sprintf(`"%s"`, entity.CreatedTopicName()),
-								},
-								&ast.BasicLit{
-									Kind:  token.STRING,
-									Value: fmt.Sprintf(`"%s"`, entity.KafkaCreatedConsumerGroup()),
-								},
-								&ast.SelectorExpr{
-									X: &ast.SelectorExpr{
-										X: &ast.Ident{
-											Name: "a",
-										},
-										Sel: &ast.Ident{
-											Name: entity.GetKafkaHandlerPrivateVariableName(),
-										},
-									},
-									Sel: &ast.Ident{
-										Name: "Created",
-									},
-								},
+					Type: &ast.StarExpr{
+						X: ast.NewIdent("App"),
+					},
+				},
+			},
+		},
+		Name: ast.NewIdent("RegisterKafka"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							ast.NewIdent("consumer"),
+						},
+						Type: &ast.StarExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent("kafka"),
+								Sel: ast.NewIdent("Consumer"),
 							},
 						},
 					},
 				},
 			},
-			&ast.ExprStmt{
-				X: &ast.CallExpr{
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: ast.NewIdent("error"),
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: stmts,
+		},
+	}
+}
+
+// addKafkaHandler - consumer.AddHandler(kafka.<constructor>("<topic>", "<group>", a.<handler>.<method>)).
+func addKafkaHandler(constructor, topic, group, handler, method string) ast.Stmt {
+	args := []ast.Expr{
+		&ast.BasicLit{
+			Kind:  token.STRING,
+			Value: fmt.Sprintf(`"%s"`, topic),
+		},
+	}
+	if group != "" {
+		args = append(args, &ast.BasicLit{
+			Kind:  token.STRING,
+			Value: fmt.Sprintf(`"%s"`, group),
+		})
+	}
+	args = append(args, &ast.SelectorExpr{
+		X: &ast.SelectorExpr{
+			X:   ast.NewIdent("a"),
+			Sel: ast.NewIdent(handler),
+		},
+		Sel: ast.NewIdent(method),
+	})
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("consumer"),
+				Sel: ast.NewIdent("AddHandler"),
+			},
+			Args: []ast.Expr{
+				&ast.CallExpr{
 					Fun: &ast.SelectorExpr{
-						X:   ast.NewIdent("consumer"),
-						Sel: ast.NewIdent("AddHandler"),
+						X:   ast.NewIdent("kafka"),
+						Sel: ast.NewIdent(constructor),
 					},
-					Args: []ast.Expr{
-						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X: &ast.Ident{
-									Name: "kafka",
-								},
-								Sel: &ast.Ident{
-									Name: "NewHandler",
-								},
-							},
-							Args: []ast.Expr{
-								&ast.BasicLit{
-									Kind:  token.STRING,
-									Value: fmt.Sprintf(`"%s"`, entity.UpdatedTopicName()),
-								},
-								&ast.BasicLit{
-									Kind:  token.STRING,
-									Value: fmt.Sprintf(`"%s"`, entity.KafkaUpdatedConsumerGroup()),
-								},
-								&ast.SelectorExpr{
-									X: &ast.SelectorExpr{
-										X: &ast.Ident{
-											Name: "a",
-										},
-										Sel: &ast.Ident{
-											Name: entity.GetKafkaHandlerPrivateVariableName(),
-										},
-									},
-									Sel: &ast.Ident{
-										Name: "Updated",
-									},
-								},
+					Args: args,
+				},
+			},
+		},
+	}
+}
+
+// createIndexes - CreateIndexes of the MongoDB repositories, called by the migrate command.
+func (a App) createIndexes() *ast.FuncDecl {
+	var stmts []ast.Stmt
+	for _, entity := range a.app.Entities {
+		if !entity.MongoEnabled() {
+			continue
+		}
+		stmts = append(stmts, &ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{
+					ast.NewIdent("err"),
+				},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent("a"),
+								Sel: ast.NewIdent(entity.GetRepositoryPrivateVariableName()),
 							},
+							Sel: ast.NewIdent("CreateIndexes"),
+						},
+						Args: []ast.Expr{
+							ast.NewIdent("ctx"),
 						},
 					},
 				},
 			},
-			&ast.ExprStmt{
-				X: &ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X:   ast.NewIdent("consumer"),
-						Sel: ast.NewIdent("AddHandler"),
-					},
-					Args: []ast.Expr{
-						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X: &ast.Ident{
-									Name: "kafka",
-								},
-								Sel: &ast.Ident{
-									Name: "NewHandler",
-								},
-							},
-							Args: []ast.Expr{
-								&ast.BasicLit{
-									Kind:  token.STRING,
-									Value: fmt.Sprintf(`"%s"`, entity.DeletedTopicName()),
-								},
-								&ast.BasicLit{
-									Kind:  token.STRING,
-									Value: fmt.Sprintf(`"%s"`, entity.KafkaDeletedConsumerGroup()),
-								},
-								&ast.SelectorExpr{
-									X: &ast.SelectorExpr{
-										X: &ast.Ident{
-											Name: "a",
-										},
-										Sel: &ast.Ident{
-											Name: entity.GetKafkaHandlerPrivateVariableName(),
-										},
-									},
-									Sel: &ast.Ident{
-										Name: "Deleted",
-									},
-								},
-							},
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent("err"),
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							ast.NewIdent("err"),
 						},
 					},
 				},
 			},
-		)
+		})
 	}
 	stmts = append(stmts, &ast.ReturnStmt{
 		Results: []ast.Expr{
@@ -1022,19 +1362,17 @@ func (a App) registerKafka() *ast.FuncDecl {
 				},
 			},
 		},
-		Name: ast.NewIdent("RegisterKafka"),
+		Name: ast.NewIdent("CreateIndexes"),
 		Type: &ast.FuncType{
 			Params: &ast.FieldList{
 				List: []*ast.Field{
 					{
 						Names: []*ast.Ident{
-							ast.NewIdent("consumer"),
+							ast.NewIdent("ctx"),
 						},
-						Type: &ast.StarExpr{
-							X: &ast.SelectorExpr{
-								X:   ast.NewIdent("kafka"),
-								Sel: ast.NewIdent("Consumer"),
-							},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("context"),
+							Sel: ast.NewIdent("Context"),
 						},
 					},
 				},
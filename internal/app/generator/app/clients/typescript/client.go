@@ -0,0 +1,36 @@
+package typescript
+
+import (
+	"path"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+const destinationPath = "."
+
+type ClientGenerator struct {
+	domain *configs.EntityConfig
+}
+
+func NewClientGenerator(domain *configs.EntityConfig) *ClientGenerator {
+	return &ClientGenerator{domain: domain}
+}
+
+func (g *ClientGenerator) Sync() error {
+	client := &tmpl.Template{
+		SourcePath: "templates/clients/ts/entity.ts.tmpl",
+		DestinationPath: path.Join(
+			destinationPath,
+			"clients",
+			"ts",
+			g.domain.AppConfig.AppName(),
+			g.domain.TypeScriptFileName(),
+		),
+		Name: "typescript client",
+	}
+	if err := client.RenderToFile(g.domain); err != nil {
+		return err
+	}
+	return nil
+}
@@ -11,7 +11,6 @@ import (
 	"path"
 
 	"github.com/iancoleman/strcase"
-	"golang.org/x/exp/maps"
 
 	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
 
@@ -99,7 +98,7 @@ func (m *Mock) values() []*ast.KeyValueExpr {
 						kvs,
 						&ast.KeyValueExpr{
 							Key:   name,
-							Value: fake.Ordering(field.Type, maps.Keys(m.domain.OrderingConsts())),
+							Value: fake.Ordering(field.Type, m.domain.OrderingNames()),
 						},
 					)
 				default:
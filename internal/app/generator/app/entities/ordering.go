@@ -50,7 +50,9 @@ func (r Ordering) Sync() error {
 	if _, ok := astfile.FindMethod(file, r.entityConfig.OrderingTypeName(), "String"); !ok {
 		file.Decls = append(file.Decls, r.stringerFunc())
 	}
-	for name, value := range r.entityConfig.OrderingConsts() {
+	consts := r.entityConfig.OrderingConsts()
+	for _, name := range r.entityConfig.OrderingNames() {
+		value := consts[name]
 		if !astfile.ConstExists(file, name) {
 			file.Decls = append(file.Decls, &ast.GenDecl{
 				Tok: token.CONST,
@@ -137,7 +139,7 @@ func (r Ordering) typeDecl() *ast.GenDecl {
 
 func (r Ordering) validateFunc() *ast.FuncDecl {
 	validateIn := make([]ast.Expr, 0, len(r.entityConfig.GetMainModel().Params))
-	for k := range r.entityConfig.OrderingConsts() {
+	for _, k := range r.entityConfig.OrderingNames() {
 		validateIn = append(
 			validateIn,
 			&ast.CallExpr{
@@ -2,11 +2,15 @@ package app
 
 import (
 	"github.com/mikalai-mitsin/creathor/internal/app/generator"
+	"github.com/mikalai-mitsin/creathor/internal/app/generator/app/clients/typescript"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/app/entities"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/app/handlers/grpc"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/app/handlers/http"
 	handlersKafka "github.com/mikalai-mitsin/creathor/internal/app/generator/app/handlers/kafka"
+	"github.com/mikalai-mitsin/creathor/internal/app/generator/app/repositories/cache"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/app/repositories/kafka"
+	"github.com/mikalai-mitsin/creathor/internal/app/generator/app/repositories/memory"
+	"github.com/mikalai-mitsin/creathor/internal/app/generator/app/repositories/mongo"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/app/repositories/postgres"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/app/services"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/app/usecases"
@@ -32,11 +36,20 @@ func (g *Generator) Sync() error {
 			services.NewInterfacesGenerator(&entity),
 			services.NewServiceGenerator(&entity),
 			services.NewTestGenerator(&entity),
-
-			postgres.NewInterfacesGenerator(&entity),
-			postgres.NewRepositoryGenerator(&entity),
-			postgres.NewTestGenerator(&entity),
 		)
+		if entity.MongoEnabled() {
+			domainGenerators = append(domainGenerators, mongo.NewRepositoryGenerator(&entity))
+		} else {
+			domainGenerators = append(
+				domainGenerators,
+				postgres.NewInterfacesGenerator(&entity),
+				postgres.NewRepositoryGenerator(&entity),
+				postgres.NewTestGenerator(&entity),
+			)
+		}
+		if entity.CacheEnabled {
+			domainGenerators = append(domainGenerators, cache.NewRepositoryGenerator(&entity))
+		}
 		if g.domain.KafkaEnabled {
 			domainGenerators = append(
 				domainGenerators,
@@ -54,6 +67,12 @@ func (g *Generator) Sync() error {
 				http.NewHandlerGenerator(&entity),
 				http.NewInterfacesGenerator(&entity),
 			)
+			if g.domain.TypeScriptEnabled {
+				domainGenerators = append(
+					domainGenerators,
+					typescript.NewClientGenerator(&entity),
+				)
+			}
 		}
 		if g.domain.GRPCEnabled {
 			domainGenerators = append(
@@ -63,6 +82,12 @@ func (g *Generator) Sync() error {
 				grpc.NewHandlerGenerator(&entity),
 				grpc.NewTestGenerator(&entity),
 			)
+			if g.domain.WatchEnabled {
+				domainGenerators = append(
+					domainGenerators,
+					memory.NewBroadcasterGenerator(&entity),
+				)
+			}
 		}
 		for _, baseEntity := range entity.Entities {
 			domainGenerators = append(domainGenerators, entities.NewModel(baseEntity, &entity))
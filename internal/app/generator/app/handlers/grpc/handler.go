@@ -9,6 +9,7 @@ import (
 	"go/token"
 	"os"
 	"path"
+	"strconv"
 	"strings"
 
 	"github.com/mikalai-mitsin/creathor/internal/pkg/astfile"
@@ -62,12 +63,24 @@ func (h HandlerGenerator) file() *ast.File {
 				Value: h.domain.AppConfig.ProjectConfig.UUIDImportPath(),
 			},
 		},
+		&ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: h.domain.AppConfig.ProjectConfig.ErrsImportPath(),
+			},
+		},
 		&ast.ImportSpec{
 			Path: &ast.BasicLit{
 				Kind:  token.STRING,
 				Value: `"google.golang.org/protobuf/types/known/emptypb"`,
 			},
 		},
+		&ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"google.golang.org/protobuf/types/known/fieldmaskpb"`,
+			},
+		},
 		&ast.ImportSpec{
 			Path: &ast.BasicLit{
 				Kind:  token.STRING,
@@ -81,16 +94,21 @@ func (h HandlerGenerator) file() *ast.File {
 			},
 		},
 	}
-	for _, param := range h.domain.GetUpdateModel().Params {
-		if param.IsSlice() {
-			importSpec = append(importSpec, &ast.ImportSpec{
-				Path: &ast.BasicLit{
-					Kind:  token.STRING,
-					Value: `"google.golang.org/protobuf/types/known/structpb"`,
-				},
-			})
-			break
-		}
+	if h.watchEnabled() {
+		importSpec = append(importSpec, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: h.domain.AppConfig.ProjectConfig.BroadcastImportPath(),
+			},
+		})
+	}
+	if h.watchEnabled() && h.domain.SearchEnabled() {
+		importSpec = append(importSpec, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"strings"`,
+			},
+		})
 	}
 	return &ast.File{
 		Name: ast.NewIdent("handlers"),
@@ -313,243 +331,184 @@ func (h HandlerGenerator) syncEncodeCreate() error {
 	return nil
 }
 
-func (h HandlerGenerator) updateStmts() []*ast.IfStmt {
-	var stmts []*ast.IfStmt
-	for _, param := range h.domain.GetUpdateModel().Params {
-		if param.GetName() == "ID" {
-			continue
+func (h HandlerGenerator) updateMaskValue(param *configs.Param) ([]ast.Stmt, ast.Expr) {
+	getter := &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("input"),
+			Sel: ast.NewIdent(param.GRPCGetter()),
+		},
+	}
+	switch {
+	case param.Type == "time.Time":
+		return nil, &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   getter,
+				Sel: ast.NewIdent("AsTime"),
+			},
 		}
-		var body []ast.Stmt
-		if param.Type == "*time.Time" || param.Type == "time.Time" {
-			body = []ast.Stmt{
-				&ast.AssignStmt{
-					Lhs: []ast.Expr{
-						&ast.SelectorExpr{
-							X:   ast.NewIdent("update"),
-							Sel: ast.NewIdent(param.GetName()),
-						},
-					},
-					Tok: token.ASSIGN,
-					Rhs: []ast.Expr{
-						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X:   ast.NewIdent("pointer"),
-								Sel: ast.NewIdent("Of"),
-							},
-							Args: []ast.Expr{
-								&ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X: &ast.CallExpr{
-											Fun: &ast.SelectorExpr{
-												X:   ast.NewIdent("input"),
-												Sel: ast.NewIdent(param.GRPCGetter()),
-											},
-										},
-										Sel: ast.NewIdent("AsTime"),
-									},
-								},
-							},
-						},
-					},
+	case param.IsSlice() && param.Type != param.GRPCType():
+		var item ast.Expr = ast.NewIdent("item")
+		if strings.HasPrefix(strings.ToLower(param.SliceType()), "uuid") {
+			item = &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("uuid"),
+					Sel: ast.NewIdent("MustParse"),
 				},
+				Args: []ast.Expr{item},
 			}
-		} else if param.IsSlice() {
-			value := &ast.CallExpr{
-				Fun: ast.NewIdent(param.SliceType()),
-				Args: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X:   ast.NewIdent("item"),
-							Sel: ast.NewIdent(param.GrpcGetFromListValueAs()),
-						},
-					},
-				},
+		} else {
+			item = &ast.CallExpr{
+				Fun:  ast.NewIdent(param.SliceType()),
+				Args: []ast.Expr{item},
 			}
-			body = []ast.Stmt{
-				&ast.DeclStmt{
-					Decl: &ast.GenDecl{
-						Tok: token.VAR,
-						Specs: []ast.Spec{
-							&ast.ValueSpec{
-								Names: []*ast.Ident{
-									ast.NewIdent("params"),
-								},
-								Type: &ast.ArrayType{
-									Elt: ast.NewIdent(param.SliceType()),
-								},
-							},
-						},
-					},
+		}
+		stmts := []ast.Stmt{
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{
+					ast.NewIdent("params"),
 				},
-				&ast.RangeStmt{
-					Key:   ast.NewIdent("_"),
-					Value: ast.NewIdent("item"),
-					Tok:   token.DEFINE,
-					X: &ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X: &ast.CallExpr{
-								Fun: &ast.SelectorExpr{
-									X:   ast.NewIdent("input"),
-									Sel: ast.NewIdent(param.GRPCGetter()),
-								},
-							},
-							Sel: ast.NewIdent("GetValues"),
-						},
-					},
-					Body: &ast.BlockStmt{
-						List: []ast.Stmt{
-							&ast.AssignStmt{
-								Lhs: []ast.Expr{
-									ast.NewIdent("params"),
-								},
-								Tok: token.ASSIGN,
-								Rhs: []ast.Expr{
-									&ast.CallExpr{
-										Fun: ast.NewIdent("append"),
-										Args: []ast.Expr{
-											ast.NewIdent("params"),
-											value,
-										},
-									},
-								},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun: ast.NewIdent("make"),
+						Args: []ast.Expr{
+							&ast.ArrayType{
+								Elt: ast.NewIdent(param.SliceType()),
 							},
-						},
-					},
-				},
-				&ast.AssignStmt{
-					Lhs: []ast.Expr{
-						&ast.SelectorExpr{
-							X:   ast.NewIdent("update"),
-							Sel: ast.NewIdent(param.GetName()),
-						},
-					},
-					Tok: token.ASSIGN,
-					Rhs: []ast.Expr{
-						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X:   ast.NewIdent("pointer"),
-								Sel: ast.NewIdent("Of"),
+							&ast.BasicLit{
+								Kind:  token.INT,
+								Value: "0",
 							},
-							Args: []ast.Expr{
-								ast.NewIdent("params"),
+							&ast.CallExpr{
+								Fun:  ast.NewIdent("len"),
+								Args: []ast.Expr{getter},
 							},
 						},
 					},
 				},
-			}
-		} else {
-			var value ast.Expr
-			value = &ast.CallExpr{
-				Fun: &ast.SelectorExpr{
-					X: &ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X:   ast.NewIdent("input"),
-							Sel: ast.NewIdent(param.GRPCGetter()),
-						},
-					},
-					Sel: ast.NewIdent("GetValue"),
-				},
-			}
-			if !param.IsID() && strings.TrimPrefix(param.Type, "*") != param.GRPCType() {
-				value = &ast.CallExpr{
-					Fun:  ast.NewIdent(strings.TrimPrefix(param.Type, "*")),
-					Args: []ast.Expr{value},
-				}
-			}
-			if param.IsID() {
-				value = &ast.CallExpr{
-					Fun:  ast.NewIdent("uuid.MustParse"),
-					Args: []ast.Expr{value},
-				}
-			}
-			body = []ast.Stmt{
-				&ast.AssignStmt{
-					Lhs: []ast.Expr{
-						&ast.SelectorExpr{
-							X:   ast.NewIdent("update"),
-							Sel: ast.NewIdent(param.GetName()),
-						},
-					},
-					Tok: token.ASSIGN,
-					Rhs: []ast.Expr{
-						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X:   ast.NewIdent("pointer"),
-								Sel: ast.NewIdent("Of"),
+			},
+			&ast.RangeStmt{
+				Key:   ast.NewIdent("_"),
+				Value: ast.NewIdent("item"),
+				Tok:   token.DEFINE,
+				X:     getter,
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.AssignStmt{
+							Lhs: []ast.Expr{
+								ast.NewIdent("params"),
 							},
-							Args: []ast.Expr{
-								value,
+							Tok: token.ASSIGN,
+							Rhs: []ast.Expr{
+								&ast.CallExpr{
+									Fun: ast.NewIdent("append"),
+									Args: []ast.Expr{
+										ast.NewIdent("params"),
+										item,
+									},
+								},
 							},
 						},
 					},
 				},
-			}
-		}
-		stmts = append(stmts, &ast.IfStmt{
-			Cond: &ast.BinaryExpr{
-				X: &ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X:   ast.NewIdent("input"),
-						Sel: ast.NewIdent(param.GRPCGetter()),
-					},
-				},
-				Op: token.NEQ,
-				Y:  ast.NewIdent("nil"),
 			},
-			Body: &ast.BlockStmt{
-				List: body,
+		}
+		return stmts, ast.NewIdent("params")
+	case param.IsSlice(), param.Type == param.GRPCType():
+		return nil, getter
+	case param.IsID():
+		return nil, &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("uuid"),
+				Sel: ast.NewIdent("MustParse"),
 			},
-		})
+			Args: []ast.Expr{getter},
+		}
+	default:
+		return nil, &ast.CallExpr{
+			Fun:  ast.NewIdent(param.Type),
+			Args: []ast.Expr{getter},
+		}
 	}
-	return stmts
 }
 
-func (h HandlerGenerator) encodeUpdate() *ast.FuncDecl {
-	body := []ast.Stmt{
-		&ast.AssignStmt{
+func (h HandlerGenerator) updateMaskCases() []ast.Stmt {
+	var cases []ast.Stmt
+	for _, param := range h.domain.Params {
+		stmts, value := h.updateMaskValue(param)
+		stmts = append(stmts, &ast.AssignStmt{
 			Lhs: []ast.Expr{
-				ast.NewIdent("update"),
+				&ast.SelectorExpr{
+					X:   ast.NewIdent("update"),
+					Sel: ast.NewIdent(param.GetName()),
+				},
 			},
-			Tok: token.DEFINE,
+			Tok: token.ASSIGN,
 			Rhs: []ast.Expr{
-				&ast.CompositeLit{
-					Type: &ast.SelectorExpr{
-						X:   ast.NewIdent("entities"),
-						Sel: ast.NewIdent(h.domain.GetUpdateModel().Name),
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("pointer"),
+						Sel: ast.NewIdent("Of"),
 					},
-					Elts: []ast.Expr{
-						&ast.KeyValueExpr{
-							Key: ast.NewIdent("ID"),
-							Value: &ast.CallExpr{
+					Args: []ast.Expr{value},
+				},
+			},
+		})
+		cases = append(cases, &ast.CaseClause{
+			List: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: strconv.Quote(param.Tag()),
+				},
+			},
+			Body: stmts,
+		})
+	}
+	cases = append(cases, &ast.CaseClause{
+		Body: []ast.Stmt{
+			&ast.ReturnStmt{
+				Results: []ast.Expr{
+					&ast.CompositeLit{
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("entities"),
+							Sel: ast.NewIdent(h.domain.GetUpdateModel().Name),
+						},
+					},
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X: &ast.CallExpr{
 								Fun: &ast.SelectorExpr{
-									X:   ast.NewIdent("uuid"),
-									Sel: ast.NewIdent("MustParse"),
+									X:   ast.NewIdent("errs"),
+									Sel: ast.NewIdent("NewInvalidFormError"),
 								},
-								Args: []ast.Expr{
-									&ast.CallExpr{
-										Fun: &ast.SelectorExpr{
-											X:   ast.NewIdent("input"),
-											Sel: ast.NewIdent("GetId"),
-										},
-									},
+							},
+							Sel: ast.NewIdent("WithParam"),
+						},
+						Args: []ast.Expr{
+							&ast.BasicLit{
+								Kind:  token.STRING,
+								Value: `"update_mask"`,
+							},
+							&ast.BinaryExpr{
+								X: &ast.BasicLit{
+									Kind:  token.STRING,
+									Value: `"unknown field: "`,
 								},
+								Op: token.ADD,
+								Y:  ast.NewIdent("path"),
 							},
 						},
 					},
 				},
 			},
 		},
-	}
-	for _, stmt := range h.updateStmts() {
-		body = append(body, stmt)
-	}
-	body = append(body, &ast.ReturnStmt{
-		Results: []ast.Expr{
-			ast.NewIdent("update"),
-		},
 	})
+	return cases
+}
+
+func (h HandlerGenerator) encodeUpdate() *ast.FuncDecl {
 	return &ast.FuncDecl{
-		Name: ast.NewIdent(fmt.Sprintf("encode%s", h.domain.GetUpdateModel().Name)),
+		Name: ast.NewIdent(h.domain.GetGRPCUpdateDTOEncodeName()),
 		Type: &ast.FuncType{
 			Params: &ast.FieldList{
 				List: []*ast.Field{
@@ -574,11 +533,80 @@ func (h HandlerGenerator) encodeUpdate() *ast.FuncDecl {
 							Sel: ast.NewIdent(h.domain.GetUpdateModel().Name),
 						},
 					},
+					{
+						Type: ast.NewIdent("error"),
+					},
 				},
 			},
 		},
 		Body: &ast.BlockStmt{
-			List: body,
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("update"),
+					},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.CompositeLit{
+							Type: &ast.SelectorExpr{
+								X:   ast.NewIdent("entities"),
+								Sel: ast.NewIdent(h.domain.GetUpdateModel().Name),
+							},
+							Elts: []ast.Expr{
+								&ast.KeyValueExpr{
+									Key: ast.NewIdent("ID"),
+									Value: &ast.CallExpr{
+										Fun: &ast.SelectorExpr{
+											X:   ast.NewIdent("uuid"),
+											Sel: ast.NewIdent("MustParse"),
+										},
+										Args: []ast.Expr{
+											&ast.CallExpr{
+												Fun: &ast.SelectorExpr{
+													X:   ast.NewIdent("input"),
+													Sel: ast.NewIdent("GetId"),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				&ast.RangeStmt{
+					Key:   ast.NewIdent("_"),
+					Value: ast.NewIdent("path"),
+					Tok:   token.DEFINE,
+					X: &ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X: &ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("input"),
+									Sel: ast.NewIdent("GetUpdateMask"),
+								},
+							},
+							Sel: ast.NewIdent("GetPaths"),
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.SwitchStmt{
+								Tag: ast.NewIdent("path"),
+								Body: &ast.BlockStmt{
+									List: h.updateMaskCases(),
+								},
+							},
+						},
+					},
+				},
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("update"),
+						ast.NewIdent("nil"),
+					},
+				},
+			},
 		},
 	}
 }
@@ -1256,6 +1284,89 @@ func (h HandlerGenerator) syncDecodeList() error {
 	return nil
 }
 
+func (h HandlerGenerator) decodeUpdateValue(param *configs.Param) ([]ast.Stmt, ast.Expr) {
+	value := &ast.StarExpr{
+		X: &ast.SelectorExpr{
+			X:   ast.NewIdent("update"),
+			Sel: ast.NewIdent(param.GetName()),
+		},
+	}
+	switch {
+	case param.Type == "time.Time":
+		return nil, &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("timestamppb"),
+				Sel: ast.NewIdent("New"),
+			},
+			Args: []ast.Expr{value},
+		}
+	case param.IsSlice() && param.Type != param.GRPCType():
+		var item ast.Expr = &ast.CallExpr{
+			Fun:  ast.NewIdent(param.GRPCSliceType()),
+			Args: []ast.Expr{ast.NewIdent("item")},
+		}
+		if strings.HasPrefix(strings.ToLower(param.SliceType()), "uuid") {
+			item = &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("item"),
+					Sel: ast.NewIdent("String"),
+				},
+			}
+		}
+		stmts := []ast.Stmt{
+			&ast.RangeStmt{
+				Key:   ast.NewIdent("_"),
+				Value: ast.NewIdent("item"),
+				Tok:   token.DEFINE,
+				X:     value,
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.AssignStmt{
+							Lhs: []ast.Expr{
+								&ast.SelectorExpr{
+									X:   ast.NewIdent("result"),
+									Sel: ast.NewIdent(param.GRPCParam()),
+								},
+							},
+							Tok: token.ASSIGN,
+							Rhs: []ast.Expr{
+								&ast.CallExpr{
+									Fun: ast.NewIdent("append"),
+									Args: []ast.Expr{
+										&ast.SelectorExpr{
+											X:   ast.NewIdent("result"),
+											Sel: ast.NewIdent(param.GRPCParam()),
+										},
+										item,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+		return stmts, nil
+	case param.IsSlice(), param.Type == param.GRPCType():
+		return nil, value
+	case param.IsID():
+		return nil, &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X: &ast.SelectorExpr{
+					X:   ast.NewIdent("update"),
+					Sel: ast.NewIdent(param.GetName()),
+				},
+				Sel: ast.NewIdent("String"),
+			},
+		}
+	default:
+		return nil, &ast.CallExpr{
+			Fun:  ast.NewIdent(param.GRPCType()),
+			Args: []ast.Expr{value},
+		}
+	}
+}
+
 func (h HandlerGenerator) decodeUpdate() *ast.FuncDecl {
 	stmts := []ast.Stmt{
 		&ast.AssignStmt{
@@ -1271,16 +1382,74 @@ func (h HandlerGenerator) decodeUpdate() *ast.FuncDecl {
 							X:   ast.NewIdent(h.domain.ProtoPackage),
 							Sel: ast.NewIdent(h.domain.GetUpdateModel().Name),
 						},
-						Elts: h.decodeUpdateParams(),
+						Elts: []ast.Expr{
+							&ast.KeyValueExpr{
+								Key: ast.NewIdent("Id"),
+								Value: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X:   ast.NewIdent("update"),
+											Sel: ast.NewIdent("ID"),
+										},
+										Sel: ast.NewIdent("String"),
+									},
+								},
+							},
+							&ast.KeyValueExpr{
+								Key: ast.NewIdent("UpdateMask"),
+								Value: &ast.UnaryExpr{
+									Op: token.AND,
+									X: &ast.CompositeLit{
+										Type: &ast.SelectorExpr{
+											X:   ast.NewIdent("fieldmaskpb"),
+											Sel: ast.NewIdent("FieldMask"),
+										},
+									},
+								},
+							},
+						},
 					},
 				},
 			},
 		},
 	}
-	for _, param := range h.domain.GetUpdateModel().Params {
-		if !param.IsSlice() {
-			continue
+	for _, param := range h.domain.Params {
+		body, value := h.decodeUpdateValue(param)
+		if value != nil {
+			body = append(body, &ast.AssignStmt{
+				Lhs: []ast.Expr{
+					&ast.SelectorExpr{
+						X:   ast.NewIdent("result"),
+						Sel: ast.NewIdent(param.GRPCParam()),
+					},
+				},
+				Tok: token.ASSIGN,
+				Rhs: []ast.Expr{value},
+			})
 		}
+		paths := &ast.SelectorExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent("result"),
+				Sel: ast.NewIdent("UpdateMask"),
+			},
+			Sel: ast.NewIdent("Paths"),
+		}
+		body = append(body, &ast.AssignStmt{
+			Lhs: []ast.Expr{paths},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: ast.NewIdent("append"),
+					Args: []ast.Expr{
+						paths,
+						&ast.BasicLit{
+							Kind:  token.STRING,
+							Value: strconv.Quote(param.Tag()),
+						},
+					},
+				},
+			},
+		})
 		stmts = append(stmts, &ast.IfStmt{
 			Cond: &ast.BinaryExpr{
 				X: &ast.SelectorExpr{
@@ -1291,67 +1460,7 @@ func (h HandlerGenerator) decodeUpdate() *ast.FuncDecl {
 				Y:  ast.NewIdent("nil"),
 			},
 			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.AssignStmt{
-						Lhs: []ast.Expr{
-							ast.NewIdent("params"),
-							ast.NewIdent("err"),
-						},
-						Tok: token.DEFINE,
-						Rhs: []ast.Expr{
-							&ast.CallExpr{
-								Fun: &ast.SelectorExpr{
-									X:   ast.NewIdent("structpb"),
-									Sel: ast.NewIdent("NewList"),
-								},
-								Args: []ast.Expr{
-									&ast.CallExpr{
-										Fun: &ast.SelectorExpr{
-											X:   ast.NewIdent("pointer"),
-											Sel: ast.NewIdent("ToAnySlice"),
-										},
-										Args: []ast.Expr{
-											&ast.StarExpr{
-												X: &ast.SelectorExpr{
-													X:   ast.NewIdent("update"),
-													Sel: ast.NewIdent(param.GetName()),
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-					&ast.IfStmt{
-						Cond: &ast.BinaryExpr{
-							X:  ast.NewIdent("err"),
-							Op: token.NEQ,
-							Y:  ast.NewIdent("nil"),
-						},
-						Body: &ast.BlockStmt{
-							List: []ast.Stmt{
-								&ast.ReturnStmt{
-									Results: []ast.Expr{
-										ast.NewIdent("nil"),
-									},
-								},
-							},
-						},
-					},
-					&ast.AssignStmt{
-						Lhs: []ast.Expr{
-							&ast.SelectorExpr{
-								X:   ast.NewIdent("result"),
-								Sel: ast.NewIdent(param.GRPCParam()),
-							},
-						},
-						Tok: token.ASSIGN,
-						Rhs: []ast.Expr{
-							ast.NewIdent("params"),
-						},
-					},
-				},
+				List: body,
 			},
 		})
 	}
@@ -1395,53 +1504,6 @@ func (h HandlerGenerator) decodeUpdate() *ast.FuncDecl {
 	}
 }
 
-func (h HandlerGenerator) decodeUpdateParams() []ast.Expr {
-	var exprs []ast.Expr
-	for _, param := range h.domain.GetUpdateModel().Params {
-		var value ast.Expr
-		if param.IsSlice() {
-			value = ast.NewIdent("nil")
-		} else {
-			var v ast.Expr
-			v = &ast.SelectorExpr{
-				X:   ast.NewIdent("update"),
-				Sel: ast.NewIdent(param.GetName()),
-			}
-			if strings.HasPrefix(param.Type, "*") {
-				v = &ast.StarExpr{
-					X: v,
-				}
-			}
-			if param.GetGRPCWrapperArgumentType() != strings.TrimPrefix(param.Type, "*") {
-				v = &ast.CallExpr{
-					Fun:  ast.NewIdent(param.GetGRPCWrapperArgumentType()),
-					Args: []ast.Expr{v},
-				}
-			}
-			if param.IsID() {
-				v = &ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X: &ast.SelectorExpr{
-							X:   ast.NewIdent("update"),
-							Sel: ast.NewIdent(param.GetName()),
-						},
-						Sel: ast.NewIdent("String"),
-					},
-				}
-			}
-			value = &ast.CallExpr{
-				Fun:  ast.NewIdent(param.GetGRPCWrapper()),
-				Args: []ast.Expr{v},
-			}
-		}
-		exprs = append(exprs, &ast.KeyValueExpr{
-			Key:   ast.NewIdent(param.GRPCParam()),
-			Value: value,
-		})
-	}
-	return exprs
-}
-
 func (h HandlerGenerator) syncDecodeUpdate() error {
 	fileset := token.NewFileSet()
 	file, err := parser.ParseFile(fileset, h.filename(), nil, parser.ParseComments)
@@ -1466,35 +1528,44 @@ func (h HandlerGenerator) syncDecodeUpdate() error {
 }
 
 func (h HandlerGenerator) structure() *ast.TypeSpec {
+	fields := []*ast.Field{
+		{
+			Type: &ast.SelectorExpr{
+				X: ast.NewIdent(h.domain.ProtoPackage),
+				Sel: &ast.Ident{
+					Name: fmt.Sprintf(
+						"Unimplemented%sServiceServer",
+						h.domain.GetMainModel().Name,
+					),
+				},
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent(h.domain.GetUseCasePrivateVariableName()),
+			},
+			Type: ast.NewIdent(h.domain.GetUseCaseInterfaceName()),
+		},
+	}
+	if h.watchEnabled() {
+		fields = append(fields, &ast.Field{
+			Names: []*ast.Ident{
+				ast.NewIdent(h.domain.GetWatcherPrivateVariableName()),
+			},
+			Type: ast.NewIdent(h.domain.WatcherInterfaceName()),
+		})
+	}
+	fields = append(fields, &ast.Field{
+		Names: []*ast.Ident{
+			ast.NewIdent("logger"),
+		},
+		Type: ast.NewIdent("logger"),
+	})
 	return &ast.TypeSpec{
 		Name: ast.NewIdent(h.domain.GetGRPCHandlerTypeName()),
 		Type: &ast.StructType{
 			Fields: &ast.FieldList{
-				List: []*ast.Field{
-					{
-						Type: &ast.SelectorExpr{
-							X: ast.NewIdent(h.domain.ProtoPackage),
-							Sel: &ast.Ident{
-								Name: fmt.Sprintf(
-									"Unimplemented%sServiceServer",
-									h.domain.GetMainModel().Name,
-								),
-							},
-						},
-					},
-					{
-						Names: []*ast.Ident{
-							ast.NewIdent(h.domain.GetUseCasePrivateVariableName()),
-						},
-						Type: ast.NewIdent(h.domain.GetUseCaseInterfaceName()),
-					},
-					{
-						Names: []*ast.Ident{
-							ast.NewIdent("logger"),
-						},
-						Type: ast.NewIdent("logger"),
-					},
-				},
+				List: fields,
 			},
 		},
 	}
@@ -1529,24 +1600,51 @@ func (h HandlerGenerator) syncStruct() error {
 }
 
 func (h HandlerGenerator) constructor() *ast.FuncDecl {
+	params := []*ast.Field{
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent(h.domain.GetUseCasePrivateVariableName()),
+			},
+			Type: ast.NewIdent(h.domain.GetUseCaseInterfaceName()),
+		},
+	}
+	elts := []ast.Expr{
+		&ast.KeyValueExpr{
+			Key: ast.NewIdent(
+				h.domain.GetUseCasePrivateVariableName(),
+			),
+			Value: ast.NewIdent(
+				h.domain.GetUseCasePrivateVariableName(),
+			),
+		},
+	}
+	if h.watchEnabled() {
+		params = append(params, &ast.Field{
+			Names: []*ast.Ident{
+				ast.NewIdent(h.domain.GetWatcherPrivateVariableName()),
+			},
+			Type: ast.NewIdent(h.domain.WatcherInterfaceName()),
+		})
+		elts = append(elts, &ast.KeyValueExpr{
+			Key:   ast.NewIdent(h.domain.GetWatcherPrivateVariableName()),
+			Value: ast.NewIdent(h.domain.GetWatcherPrivateVariableName()),
+		})
+	}
+	params = append(params, &ast.Field{
+		Names: []*ast.Ident{
+			ast.NewIdent("logger"),
+		},
+		Type: ast.NewIdent("logger"),
+	})
+	elts = append(elts, &ast.KeyValueExpr{
+		Key:   ast.NewIdent("logger"),
+		Value: ast.NewIdent("logger"),
+	})
 	return &ast.FuncDecl{
 		Name: ast.NewIdent(fmt.Sprintf("New%s", h.domain.GetGRPCHandlerTypeName())),
 		Type: &ast.FuncType{
 			Params: &ast.FieldList{
-				List: []*ast.Field{
-					{
-						Names: []*ast.Ident{
-							ast.NewIdent(h.domain.GetUseCasePrivateVariableName()),
-						},
-						Type: ast.NewIdent(h.domain.GetUseCaseInterfaceName()),
-					},
-					{
-						Names: []*ast.Ident{
-							ast.NewIdent("logger"),
-						},
-						Type: ast.NewIdent("logger"),
-					},
-				},
+				List: params,
 			},
 			Results: &ast.FieldList{
 				List: []*ast.Field{
@@ -1571,20 +1669,7 @@ func (h HandlerGenerator) constructor() *ast.FuncDecl {
 										h.domain.GetMainModel().Name,
 									),
 								},
-								Elts: []ast.Expr{
-									&ast.KeyValueExpr{
-										Key: ast.NewIdent(
-											h.domain.GetUseCasePrivateVariableName(),
-										),
-										Value: ast.NewIdent(
-											h.domain.GetUseCasePrivateVariableName(),
-										),
-									},
-									&ast.KeyValueExpr{
-										Key:   ast.NewIdent("logger"),
-										Value: ast.NewIdent("logger"),
-									},
-								},
+								Elts: elts,
 							},
 						},
 					},
@@ -2067,12 +2152,7 @@ func (h HandlerGenerator) syncListMethod() error {
 func (h HandlerGenerator) update() *ast.FuncDecl {
 	args := []ast.Expr{
 		ast.NewIdent("ctx"),
-		&ast.CallExpr{
-			Fun: ast.NewIdent(fmt.Sprintf("encode%s", h.domain.GetUpdateModel().Name)),
-			Args: []ast.Expr{
-				ast.NewIdent("input"),
-			},
-		},
+		ast.NewIdent("update"),
 	}
 	return &ast.FuncDecl{
 		Recv: &ast.FieldList{
@@ -2131,6 +2211,38 @@ func (h HandlerGenerator) update() *ast.FuncDecl {
 		},
 		Body: &ast.BlockStmt{
 			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("update"),
+						ast.NewIdent("err"),
+					},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: ast.NewIdent(h.domain.GetGRPCUpdateDTOEncodeName()),
+							Args: []ast.Expr{
+								ast.NewIdent("input"),
+							},
+						},
+					},
+				},
+				&ast.IfStmt{
+					Cond: &ast.BinaryExpr{
+						X:  ast.NewIdent("err"),
+						Op: token.NEQ,
+						Y:  ast.NewIdent("nil"),
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									ast.NewIdent("nil"),
+									ast.NewIdent("err"),
+								},
+							},
+						},
+					},
+				},
 				&ast.AssignStmt{
 					Lhs: []ast.Expr{
 						ast.NewIdent("item"),
@@ -2408,5 +2520,16 @@ func (h HandlerGenerator) Sync() error {
 	if err := h.syncDecodeUpdate(); err != nil {
 		return err
 	}
+	if h.watchEnabled() {
+		if err := h.syncWatchMethod(); err != nil {
+			return err
+		}
+		if err := h.syncDecodeEvent(); err != nil {
+			return err
+		}
+		if err := h.syncMatchEvent(); err != nil {
+			return err
+		}
+	}
 	return nil
 }
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 
+	"github.com/mikalai-mitsin/creathor/internal/pkg/astfile"
 	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
 )
 
@@ -60,6 +61,10 @@ func (i InterfacesGenerator) Sync() error {
 	if !loggerExists {
 		file.Decls = append(file.Decls, i.loggerInterface())
 	}
+	if i.domain.GRPCEnabled && i.domain.WatchEnabled &&
+		!astfile.TypeExists(file, i.domain.WatcherInterfaceName()) {
+		file.Decls = append(file.Decls, i.watcherInterface())
+	}
 	buff := &bytes.Buffer{}
 	if err := printer.Fprint(buff, fileset, file); err != nil {
 		return err
@@ -80,7 +85,7 @@ func (i InterfacesGenerator) file() *ast.File {
 }
 
 func (i InterfacesGenerator) imports() *ast.GenDecl {
-	return &ast.GenDecl{
+	imports := &ast.GenDecl{
 		Tok: token.IMPORT,
 		Doc: &ast.CommentGroup{
 			List: []*ast.Comment{
@@ -121,6 +126,15 @@ func (i InterfacesGenerator) imports() *ast.GenDecl {
 			},
 		},
 	}
+	if i.domain.GRPCEnabled && i.domain.WatchEnabled {
+		imports.Specs = append(imports.Specs, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: i.domain.AppConfig.ProjectConfig.BroadcastImportPath(),
+			},
+		})
+	}
+	return imports
 }
 
 func (i InterfacesGenerator) usecaseInterface() *ast.GenDecl {
@@ -336,3 +350,54 @@ func (i InterfacesGenerator) loggerInterface() *ast.GenDecl {
 		},
 	}
 }
+
+func (i InterfacesGenerator) watcherInterface() *ast.GenDecl {
+	return &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: ast.NewIdent(i.domain.WatcherInterfaceName()),
+				Type: &ast.InterfaceType{
+					Methods: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{ast.NewIdent("Watch")},
+								Type: &ast.FuncType{
+									Params: &ast.FieldList{
+										List: []*ast.Field{
+											{
+												Type: &ast.SelectorExpr{
+													X:   ast.NewIdent("context"),
+													Sel: ast.NewIdent("Context"),
+												},
+											},
+										},
+									},
+									Results: &ast.FieldList{
+										List: []*ast.Field{
+											{
+												Type: &ast.ChanType{
+													Dir: ast.RECV,
+													Value: &ast.IndexExpr{
+														X: &ast.SelectorExpr{
+															X:   ast.NewIdent("broadcast"),
+															Sel: ast.NewIdent("Event"),
+														},
+														Index: &ast.SelectorExpr{
+															X:   ast.NewIdent("entities"),
+															Sel: ast.NewIdent(i.domain.GetMainModel().Name),
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
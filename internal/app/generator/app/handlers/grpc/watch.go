@@ -0,0 +1,555 @@
+package grpc
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/astfile"
+)
+
+func (h HandlerGenerator) watchEnabled() bool {
+	return h.domain.GRPCEnabled && h.domain.WatchEnabled
+}
+
+func (h HandlerGenerator) eventType() ast.Expr {
+	return &ast.IndexExpr{
+		X: &ast.SelectorExpr{
+			X:   ast.NewIdent("broadcast"),
+			Sel: ast.NewIdent("Event"),
+		},
+		Index: &ast.SelectorExpr{
+			X:   ast.NewIdent("entities"),
+			Sel: ast.NewIdent(h.domain.GetMainModel().Name),
+		},
+	}
+}
+
+func (h HandlerGenerator) watch() *ast.FuncDecl {
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{
+						ast.NewIdent("s"),
+					},
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(h.domain.GetGRPCHandlerTypeName()),
+					},
+				},
+			},
+		},
+		Name: ast.NewIdent("Watch"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							ast.NewIdent("input"),
+						},
+						Type: &ast.StarExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent(h.domain.ProtoPackage),
+								Sel: ast.NewIdent(h.domain.GetFilterModel().Name),
+							},
+						},
+					},
+					{
+						Names: []*ast.Ident{
+							ast.NewIdent("stream"),
+						},
+						Type: &ast.SelectorExpr{
+							X: ast.NewIdent(h.domain.ProtoPackage),
+							Sel: ast.NewIdent(
+								fmt.Sprintf("%sService_WatchServer", h.domain.GetMainModel().Name),
+							),
+						},
+					},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: ast.NewIdent("error"),
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("filter"),
+					},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: ast.NewIdent(h.domain.GetGRPCFilterDTOEncodeName()),
+							Args: []ast.Expr{
+								ast.NewIdent("input"),
+							},
+						},
+					},
+				},
+				&ast.RangeStmt{
+					Key: ast.NewIdent("event"),
+					Tok: token.DEFINE,
+					X: &ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent("s"),
+								Sel: ast.NewIdent(h.domain.GetWatcherPrivateVariableName()),
+							},
+							Sel: ast.NewIdent("Watch"),
+						},
+						Args: []ast.Expr{
+							&ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("stream"),
+									Sel: ast.NewIdent("Context"),
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.IfStmt{
+								Cond: &ast.UnaryExpr{
+									Op: token.NOT,
+									X: &ast.CallExpr{
+										Fun: ast.NewIdent(h.domain.GetGRPCEventMatchName()),
+										Args: []ast.Expr{
+											ast.NewIdent("filter"),
+											ast.NewIdent("event"),
+										},
+									},
+								},
+								Body: &ast.BlockStmt{
+									List: []ast.Stmt{
+										&ast.BranchStmt{
+											Tok: token.CONTINUE,
+										},
+									},
+								},
+							},
+							&ast.IfStmt{
+								Init: &ast.AssignStmt{
+									Lhs: []ast.Expr{
+										ast.NewIdent("err"),
+									},
+									Tok: token.DEFINE,
+									Rhs: []ast.Expr{
+										&ast.CallExpr{
+											Fun: &ast.SelectorExpr{
+												X:   ast.NewIdent("stream"),
+												Sel: ast.NewIdent("Send"),
+											},
+											Args: []ast.Expr{
+												&ast.CallExpr{
+													Fun: ast.NewIdent(
+														h.domain.GetGRPCEventDecodeName(),
+													),
+													Args: []ast.Expr{
+														ast.NewIdent("event"),
+													},
+												},
+											},
+										},
+									},
+								},
+								Cond: &ast.BinaryExpr{
+									X:  ast.NewIdent("err"),
+									Op: token.NEQ,
+									Y:  ast.NewIdent("nil"),
+								},
+								Body: &ast.BlockStmt{
+									List: []ast.Stmt{
+										&ast.ReturnStmt{
+											Results: []ast.Expr{
+												ast.NewIdent("err"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("nil"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (h HandlerGenerator) syncWatchMethod() error {
+	fileset := token.NewFileSet()
+	file, err := parser.ParseFile(fileset, h.filename(), nil, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	method, methodExist := astfile.FindFunc(file, "Watch")
+	if method == nil {
+		method = h.watch()
+	}
+	if !methodExist {
+		file.Decls = append(file.Decls, method)
+	}
+	buff := &bytes.Buffer{}
+	if err := printer.Fprint(buff, fileset, file); err != nil {
+		return err
+	}
+	if err := os.WriteFile(h.filename(), buff.Bytes(), 0777); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (h HandlerGenerator) decodeEventCase(eventType, protoType string, withItem bool) *ast.CaseClause {
+	body := []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				&ast.SelectorExpr{
+					X:   ast.NewIdent("response"),
+					Sel: ast.NewIdent("Type"),
+				},
+			},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				&ast.SelectorExpr{
+					X: ast.NewIdent(h.domain.ProtoPackage),
+					Sel: ast.NewIdent(
+						fmt.Sprintf("%s_%s", h.domain.EventTypeName(), protoType),
+					),
+				},
+			},
+		},
+	}
+	if withItem {
+		body = append(body, &ast.AssignStmt{
+			Lhs: []ast.Expr{
+				&ast.SelectorExpr{
+					X:   ast.NewIdent("response"),
+					Sel: ast.NewIdent("Item"),
+				},
+			},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: ast.NewIdent(h.domain.GetGRPCMainDecodeName()),
+					Args: []ast.Expr{
+						&ast.SelectorExpr{
+							X:   ast.NewIdent("event"),
+							Sel: ast.NewIdent("Item"),
+						},
+					},
+				},
+			},
+		})
+	}
+	return &ast.CaseClause{
+		List: []ast.Expr{
+			&ast.SelectorExpr{
+				X:   ast.NewIdent("broadcast"),
+				Sel: ast.NewIdent(eventType),
+			},
+		},
+		Body: body,
+	}
+}
+
+func (h HandlerGenerator) decodeEvent() *ast.FuncDecl {
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(h.domain.GetGRPCEventDecodeName()),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							ast.NewIdent("event"),
+						},
+						Type: h.eventType(),
+					},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: &ast.StarExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent(h.domain.ProtoPackage),
+								Sel: ast.NewIdent(h.domain.EventTypeName()),
+							},
+						},
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("response"),
+					},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.UnaryExpr{
+							Op: token.AND,
+							X: &ast.CompositeLit{
+								Type: &ast.SelectorExpr{
+									X:   ast.NewIdent(h.domain.ProtoPackage),
+									Sel: ast.NewIdent(h.domain.EventTypeName()),
+								},
+								Elts: []ast.Expr{
+									&ast.KeyValueExpr{
+										Key: ast.NewIdent("Id"),
+										Value: &ast.CallExpr{
+											Fun: &ast.SelectorExpr{
+												X: &ast.SelectorExpr{
+													X:   ast.NewIdent("event"),
+													Sel: ast.NewIdent("ID"),
+												},
+												Sel: ast.NewIdent("String"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				&ast.SwitchStmt{
+					Tag: &ast.SelectorExpr{
+						X:   ast.NewIdent("event"),
+						Sel: ast.NewIdent("Type"),
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							h.decodeEventCase("EventTypeCreated", "TYPE_CREATED", true),
+							h.decodeEventCase("EventTypeUpdated", "TYPE_UPDATED", true),
+							h.decodeEventCase("EventTypeDeleted", "TYPE_DELETED", false),
+						},
+					},
+				},
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("response"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (h HandlerGenerator) syncDecodeEvent() error {
+	fileset := token.NewFileSet()
+	file, err := parser.ParseFile(fileset, h.filename(), nil, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	method, methodExist := astfile.FindFunc(file, h.domain.GetGRPCEventDecodeName())
+	if method == nil {
+		method = h.decodeEvent()
+	}
+	if !methodExist {
+		file.Decls = append(file.Decls, method)
+	}
+	buff := &bytes.Buffer{}
+	if err := printer.Fprint(buff, fileset, file); err != nil {
+		return err
+	}
+	if err := os.WriteFile(h.filename(), buff.Bytes(), 0777); err != nil {
+		return err
+	}
+	return nil
+}
+
+// matchEvent - whether the event concerns an item the Watch filter lists. The search of the filter is matched as a
+// case-insensitive substring of the search params, deleted events carry no item and always match.
+func (h HandlerGenerator) matchEvent() *ast.FuncDecl {
+	var matches ast.Expr
+	for _, param := range h.domain.GetMainModel().Params {
+		if !param.Search {
+			continue
+		}
+		var value ast.Expr = &ast.SelectorExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent("event"),
+				Sel: ast.NewIdent("Item"),
+			},
+			Sel: ast.NewIdent(param.GetName()),
+		}
+		if param.IsSlice() {
+			value = &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("strings"),
+					Sel: ast.NewIdent("Join"),
+				},
+				Args: []ast.Expr{
+					value,
+					&ast.BasicLit{
+						Kind:  token.STRING,
+						Value: `" "`,
+					},
+				},
+			}
+		}
+		var match ast.Expr = &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("strings"),
+				Sel: ast.NewIdent("Contains"),
+			},
+			Args: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X:   ast.NewIdent("strings"),
+						Sel: ast.NewIdent("ToLower"),
+					},
+					Args: []ast.Expr{
+						value,
+					},
+				},
+				ast.NewIdent("search"),
+			},
+		}
+		if matches != nil {
+			match = &ast.BinaryExpr{
+				X:  matches,
+				Op: token.LOR,
+				Y:  match,
+			}
+		}
+		matches = match
+	}
+	stmts := []ast.Stmt{
+		&ast.ReturnStmt{
+			Results: []ast.Expr{
+				ast.NewIdent("true"),
+			},
+		},
+	}
+	if matches != nil {
+		stmts = []ast.Stmt{
+			&ast.IfStmt{
+				Cond: &ast.BinaryExpr{
+					X: &ast.BinaryExpr{
+						X: &ast.SelectorExpr{
+							X:   ast.NewIdent("filter"),
+							Sel: ast.NewIdent("Search"),
+						},
+						Op: token.EQL,
+						Y:  ast.NewIdent("nil"),
+					},
+					Op: token.LOR,
+					Y: &ast.BinaryExpr{
+						X: &ast.SelectorExpr{
+							X:   ast.NewIdent("event"),
+							Sel: ast.NewIdent("Type"),
+						},
+						Op: token.EQL,
+						Y: &ast.SelectorExpr{
+							X:   ast.NewIdent("broadcast"),
+							Sel: ast.NewIdent("EventTypeDeleted"),
+						},
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ReturnStmt{
+							Results: []ast.Expr{
+								ast.NewIdent("true"),
+							},
+						},
+					},
+				},
+			},
+			&ast.AssignStmt{
+				Lhs: []ast.Expr{
+					ast.NewIdent("search"),
+				},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("strings"),
+							Sel: ast.NewIdent("ToLower"),
+						},
+						Args: []ast.Expr{
+							&ast.StarExpr{
+								X: &ast.SelectorExpr{
+									X:   ast.NewIdent("filter"),
+									Sel: ast.NewIdent("Search"),
+								},
+							},
+						},
+					},
+				},
+			},
+			&ast.ReturnStmt{
+				Results: []ast.Expr{
+					matches,
+				},
+			},
+		}
+	}
+	return &ast.FuncDecl{
+		Name: ast.NewIdent(h.domain.GetGRPCEventMatchName()),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							ast.NewIdent("filter"),
+						},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("entities"),
+							Sel: ast.NewIdent(h.domain.GetFilterModel().Name),
+						},
+					},
+					{
+						Names: []*ast.Ident{
+							ast.NewIdent("event"),
+						},
+						Type: h.eventType(),
+					},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: ast.NewIdent("bool"),
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: stmts,
+		},
+	}
+}
+
+func (h HandlerGenerator) syncMatchEvent() error {
+	fileset := token.NewFileSet()
+	file, err := parser.ParseFile(fileset, h.filename(), nil, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	if _, methodExist := astfile.FindFunc(file, h.domain.GetGRPCEventMatchName()); !methodExist {
+		file.Decls = append(file.Decls, h.matchEvent())
+	}
+	buff := &bytes.Buffer{}
+	if err := printer.Fprint(buff, fileset, file); err != nil {
+		return err
+	}
+	if err := os.WriteFile(h.filename(), buff.Bytes(), 0777); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package kafka
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// invalidateStmt drops the cached entity on updated and deleted events, so every instance serves the change and not
+// only the one that made it.
+func (h *HandlerGenerator) invalidateStmt() ast.Stmt {
+	return &ast.IfStmt{
+		Init: &ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("err"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X: &ast.SelectorExpr{
+							X:   ast.NewIdent("h"),
+							Sel: ast.NewIdent(h.domain.CacheInterfaceName()),
+						},
+						Sel: ast.NewIdent("Invalidate"),
+					},
+					Args: []ast.Expr{
+						ast.NewIdent("ctx"),
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("uuid"),
+								Sel: ast.NewIdent("MustParse"),
+							},
+							Args: []ast.Expr{
+								&ast.CallExpr{
+									Fun: ast.NewIdent("string"),
+									Args: []ast.Expr{
+										&ast.SelectorExpr{
+											X:   ast.NewIdent("msg"),
+											Sel: ast.NewIdent("Key"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("err"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func hasImport(decl *ast.GenDecl, path string) bool {
+	for _, spec := range decl.Specs {
+		if importSpec, ok := spec.(*ast.ImportSpec); ok && importSpec.Path.Value == path {
+			return true
+		}
+	}
+	return false
+}
@@ -54,8 +54,52 @@ func (h *HandlerGenerator) filename() string {
 	)
 }
 
+func (h *HandlerGenerator) fields() []*ast.Field {
+	fields := []*ast.Field{
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent(h.domain.GetUseCasePrivateVariableName()),
+			},
+			Type: ast.NewIdent(h.domain.GetUseCaseInterfaceName()),
+		},
+	}
+	if h.watchEnabled() {
+		fields = append(fields, &ast.Field{
+			Names: []*ast.Ident{
+				ast.NewIdent(h.domain.GetEventBroadcasterPrivateVariableName()),
+			},
+			Type: ast.NewIdent(h.domain.EventBroadcasterInterfaceName()),
+		})
+	}
+	if h.domain.CacheEnabled {
+		fields = append(fields, &ast.Field{
+			Names: []*ast.Ident{
+				ast.NewIdent(h.domain.CacheInterfaceName()),
+			},
+			Type: ast.NewIdent(h.domain.CacheInterfaceName()),
+		})
+	}
+	return append(fields, &ast.Field{
+		Names: []*ast.Ident{
+			ast.NewIdent("logger"),
+		},
+		Type: ast.NewIdent("logger"),
+	})
+}
+
+func (h *HandlerGenerator) constructorElts() []ast.Expr {
+	var elts []ast.Expr
+	for _, field := range h.fields() {
+		elts = append(elts, &ast.KeyValueExpr{
+			Key:   ast.NewIdent(field.Names[0].Name),
+			Value: ast.NewIdent(field.Names[0].Name),
+		})
+	}
+	return elts
+}
+
 func (h *HandlerGenerator) file() *ast.File {
-	return &ast.File{
+	file := &ast.File{
 		Package: 1,
 		Name: &ast.Ident{
 			Name: "handlers",
@@ -87,29 +131,7 @@ func (h *HandlerGenerator) file() *ast.File {
 						},
 						Type: &ast.StructType{
 							Fields: &ast.FieldList{
-								List: []*ast.Field{
-
-									{
-										Names: []*ast.Ident{
-											{
-												Name: h.domain.GetUseCasePrivateVariableName(),
-											},
-										},
-										Type: &ast.Ident{
-											Name: h.domain.GetUseCaseInterfaceName(),
-										},
-									},
-									{
-										Names: []*ast.Ident{
-											{
-												Name: "logger",
-											},
-										},
-										Type: &ast.Ident{
-											Name: "logger",
-										},
-									},
-								},
+								List: h.fields(),
 							},
 						},
 					},
@@ -121,28 +143,7 @@ func (h *HandlerGenerator) file() *ast.File {
 				},
 				Type: &ast.FuncType{
 					Params: &ast.FieldList{
-						List: []*ast.Field{
-							{
-								Names: []*ast.Ident{
-									{
-										Name: h.domain.GetUseCasePrivateVariableName(),
-									},
-								},
-								Type: &ast.Ident{
-									Name: h.domain.GetUseCaseInterfaceName(),
-								},
-							},
-							{
-								Names: []*ast.Ident{
-									{
-										Name: "logger",
-									},
-								},
-								Type: &ast.Ident{
-									Name: "logger",
-								},
-							},
-						},
+						List: h.fields(),
 					},
 					Results: &ast.FieldList{
 						List: []*ast.Field{
@@ -166,24 +167,7 @@ func (h *HandlerGenerator) file() *ast.File {
 										Type: &ast.Ident{
 											Name: h.domain.KafkaHandlerTypeName(),
 										},
-										Elts: []ast.Expr{
-											&ast.KeyValueExpr{
-												Key: &ast.Ident{
-													Name: h.domain.GetUseCasePrivateVariableName(),
-												},
-												Value: &ast.Ident{
-													Name: h.domain.GetUseCasePrivateVariableName(),
-												},
-											},
-											&ast.KeyValueExpr{
-												Key: &ast.Ident{
-													Name: "logger",
-												},
-												Value: &ast.Ident{
-													Name: "logger",
-												},
-											},
-										},
+										Elts: h.constructorElts(),
 									},
 								},
 							},
@@ -976,4 +960,8 @@ func (h *HandlerGenerator) file() *ast.File {
 			},
 		},
 	}
+	if len(h.domain.KafkaBroadcastEvents()) > 0 {
+		h.addBroadcasts(file)
+	}
+	return file
 }
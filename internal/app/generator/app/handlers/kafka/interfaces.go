@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 
+	"github.com/mikalai-mitsin/creathor/internal/pkg/astfile"
 	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
 )
 
@@ -60,6 +61,13 @@ func (i InterfacesGenerator) Sync() error {
 	if !loggerExists {
 		file.Decls = append(file.Decls, i.loggerInterface())
 	}
+	if i.domain.GRPCEnabled && i.domain.WatchEnabled &&
+		!astfile.TypeExists(file, i.domain.EventBroadcasterInterfaceName()) {
+		file.Decls = append(file.Decls, i.eventBroadcasterInterface())
+	}
+	if i.domain.CacheEnabled && !astfile.TypeExists(file, i.domain.CacheInterfaceName()) {
+		file.Decls = append(file.Decls, i.cacheInterface())
+	}
 	buff := &bytes.Buffer{}
 	if err := printer.Fprint(buff, fileset, file); err != nil {
 		return err
@@ -80,7 +88,7 @@ func (i InterfacesGenerator) file() *ast.File {
 }
 
 func (i InterfacesGenerator) imports() *ast.GenDecl {
-	return &ast.GenDecl{
+	imports := &ast.GenDecl{
 		Tok: token.IMPORT,
 		Doc: &ast.CommentGroup{
 			List: []*ast.Comment{
@@ -121,6 +129,15 @@ func (i InterfacesGenerator) imports() *ast.GenDecl {
 			},
 		},
 	}
+	if i.domain.GRPCEnabled && i.domain.WatchEnabled {
+		imports.Specs = append(imports.Specs, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: i.domain.AppConfig.ProjectConfig.DTXImportPath(),
+			},
+		})
+	}
+	return imports
 }
 
 func (i InterfacesGenerator) usecaseInterface() *ast.GenDecl {
@@ -336,3 +353,213 @@ func (i InterfacesGenerator) loggerInterface() *ast.GenDecl {
 		},
 	}
 }
+
+func (i InterfacesGenerator) eventBroadcasterInterface() *ast.GenDecl {
+	return &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: &ast.Ident{
+					Name: i.domain.EventBroadcasterInterfaceName(),
+				},
+				Type: &ast.InterfaceType{
+					Methods: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "Created",
+									},
+								},
+								Type: &ast.FuncType{
+									Params: &ast.FieldList{
+										List: []*ast.Field{
+											{
+												Type: &ast.SelectorExpr{
+													X: &ast.Ident{
+														Name: "context",
+													},
+													Sel: &ast.Ident{
+														Name: "Context",
+													},
+												},
+											},
+											{
+												Type: &ast.SelectorExpr{
+													X:   ast.NewIdent("dtx"),
+													Sel: ast.NewIdent("TX"),
+												},
+											},
+											{
+												Type: &ast.SelectorExpr{
+													X: &ast.Ident{
+														Name: "entities",
+													},
+													Sel: &ast.Ident{
+														Name: i.domain.GetMainModel().Name,
+													},
+												},
+											},
+										},
+									},
+									Results: &ast.FieldList{
+										List: []*ast.Field{
+											{
+												Type: &ast.Ident{
+													Name: "error",
+												},
+											},
+										},
+									},
+								},
+							},
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "Updated",
+									},
+								},
+								Type: &ast.FuncType{
+									Params: &ast.FieldList{
+										List: []*ast.Field{
+											{
+												Type: &ast.SelectorExpr{
+													X: &ast.Ident{
+														Name: "context",
+													},
+													Sel: &ast.Ident{
+														Name: "Context",
+													},
+												},
+											},
+											{
+												Type: &ast.SelectorExpr{
+													X:   ast.NewIdent("dtx"),
+													Sel: ast.NewIdent("TX"),
+												},
+											},
+											{
+												Type: &ast.SelectorExpr{
+													X: &ast.Ident{
+														Name: "entities",
+													},
+													Sel: &ast.Ident{
+														Name: i.domain.GetMainModel().Name,
+													},
+												},
+											},
+										},
+									},
+									Results: &ast.FieldList{
+										List: []*ast.Field{
+											{
+												Type: &ast.Ident{
+													Name: "error",
+												},
+											},
+										},
+									},
+								},
+							},
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "Deleted",
+									},
+								},
+								Type: &ast.FuncType{
+									Params: &ast.FieldList{
+										List: []*ast.Field{
+											{
+												Type: &ast.SelectorExpr{
+													X: &ast.Ident{
+														Name: "context",
+													},
+													Sel: &ast.Ident{
+														Name: "Context",
+													},
+												},
+											},
+											{
+												Type: &ast.SelectorExpr{
+													X:   ast.NewIdent("dtx"),
+													Sel: ast.NewIdent("TX"),
+												},
+											},
+											{
+												Type: &ast.SelectorExpr{
+													X: &ast.Ident{
+														Name: "uuid",
+													},
+													Sel: &ast.Ident{
+														Name: "UUID",
+													},
+												},
+											},
+										},
+									},
+									Results: &ast.FieldList{
+										List: []*ast.Field{
+											{
+												Type: &ast.Ident{
+													Name: "error",
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (i InterfacesGenerator) cacheInterface() *ast.GenDecl {
+	return &ast.GenDecl{
+		Tok: token.TYPE,
+		Specs: []ast.Spec{
+			&ast.TypeSpec{
+				Name: ast.NewIdent(i.domain.CacheInterfaceName()),
+				Type: &ast.InterfaceType{
+					Methods: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("Invalidate"),
+								},
+								Type: &ast.FuncType{
+									Params: &ast.FieldList{
+										List: []*ast.Field{
+											{
+												Type: &ast.SelectorExpr{
+													X:   ast.NewIdent("context"),
+													Sel: ast.NewIdent("Context"),
+												},
+											},
+											{
+												Type: &ast.SelectorExpr{
+													X:   ast.NewIdent("uuid"),
+													Sel: ast.NewIdent("UUID"),
+												},
+											},
+										},
+									},
+									Results: &ast.FieldList{
+										List: []*ast.Field{
+											{
+												Type: ast.NewIdent("error"),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
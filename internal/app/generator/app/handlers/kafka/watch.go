@@ -0,0 +1,240 @@
+package kafka
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+func (h *HandlerGenerator) watchEnabled() bool {
+	return h.domain.GRPCEnabled && h.domain.WatchEnabled
+}
+
+// addBroadcasts adds the handlers of the events every instance consumes, without a consumer group: they
+// republish the events to the in-process broadcaster feeding the gRPC Watch streams and drop the cached entity. The
+// Created, Updated and Deleted handlers share one group and see every event on one instance only.
+func (h *HandlerGenerator) addBroadcasts(file *ast.File) {
+	imports := []string{h.domain.AppConfig.ProjectConfig.UUIDImportPath()}
+	if h.watchEnabled() {
+		imports = append(imports,
+			`"encoding/json"`,
+			h.domain.EntitiesImportPath(),
+			h.domain.AppConfig.ProjectConfig.DTXImportPath(),
+		)
+	}
+	for _, decl := range file.Decls {
+		if d, ok := decl.(*ast.GenDecl); ok && d.Tok == token.IMPORT {
+			for _, importPath := range imports {
+				if !hasImport(d, importPath) {
+					d.Specs = append(d.Specs, &ast.ImportSpec{
+						Path: &ast.BasicLit{
+							Kind:  token.STRING,
+							Value: importPath,
+						},
+					})
+				}
+			}
+		}
+	}
+	for _, event := range h.domain.KafkaBroadcastEvents() {
+		var stmts []ast.Stmt
+		if h.domain.CacheEnabled && event != "Created" {
+			stmts = append(stmts, h.invalidateStmt())
+		}
+		if h.watchEnabled() {
+			if event == "Deleted" {
+				stmts = append(stmts, h.broadcastDeletedStmts()...)
+			} else {
+				stmts = append(stmts, h.broadcastItemStmts(event)...)
+			}
+		}
+		file.Decls = append(file.Decls, h.broadcastMethod("Broadcast"+event, stmts))
+	}
+}
+
+func (h *HandlerGenerator) broadcastMethod(name string, stmts []ast.Stmt) *ast.FuncDecl {
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{
+						ast.NewIdent("h"),
+					},
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(h.domain.KafkaHandlerTypeName()),
+					},
+				},
+			},
+		},
+		Name: ast.NewIdent(name),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							ast.NewIdent("ctx"),
+						},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("context"),
+							Sel: ast.NewIdent("Context"),
+						},
+					},
+					{
+						Names: []*ast.Ident{
+							ast.NewIdent("msg"),
+						},
+						Type: &ast.StarExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent("sarama"),
+								Sel: ast.NewIdent("ConsumerMessage"),
+							},
+						},
+					},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: ast.NewIdent("error"),
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: append(stmts, &ast.ReturnStmt{
+				Results: []ast.Expr{
+					ast.NewIdent("nil"),
+				},
+			}),
+		},
+	}
+}
+
+func (h *HandlerGenerator) broadcastCall(method string, arg ast.Expr) ast.Stmt {
+	return &ast.IfStmt{
+		Init: &ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent("err"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X: &ast.SelectorExpr{
+							X:   ast.NewIdent("h"),
+							Sel: ast.NewIdent(h.domain.GetEventBroadcasterPrivateVariableName()),
+						},
+						Sel: ast.NewIdent(method),
+					},
+					Args: []ast.Expr{
+						ast.NewIdent("ctx"),
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("dtx"),
+								Sel: ast.NewIdent("NewTX"),
+							},
+						},
+						arg,
+					},
+				},
+			},
+		},
+		Cond: &ast.BinaryExpr{
+			X:  ast.NewIdent("err"),
+			Op: token.NEQ,
+			Y:  ast.NewIdent("nil"),
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("err"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (h *HandlerGenerator) broadcastItemStmts(method string) []ast.Stmt {
+	return []ast.Stmt{
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{
+							ast.NewIdent(h.domain.GetMainModel().Variable),
+						},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("entities"),
+							Sel: ast.NewIdent(h.domain.GetMainModel().Name),
+						},
+					},
+				},
+			},
+		},
+		&ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{
+					ast.NewIdent("err"),
+				},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("json"),
+							Sel: ast.NewIdent("Unmarshal"),
+						},
+						Args: []ast.Expr{
+							&ast.SelectorExpr{
+								X:   ast.NewIdent("msg"),
+								Sel: ast.NewIdent("Value"),
+							},
+							&ast.UnaryExpr{
+								Op: token.AND,
+								X:  ast.NewIdent(h.domain.GetMainModel().Variable),
+							},
+						},
+					},
+				},
+			},
+			Cond: &ast.BinaryExpr{
+				X:  ast.NewIdent("err"),
+				Op: token.NEQ,
+				Y:  ast.NewIdent("nil"),
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							ast.NewIdent("err"),
+						},
+					},
+				},
+			},
+		},
+		h.broadcastCall(method, ast.NewIdent(h.domain.GetMainModel().Variable)),
+	}
+}
+
+func (h *HandlerGenerator) broadcastDeletedStmts() []ast.Stmt {
+	return []ast.Stmt{
+		h.broadcastCall("Deleted", &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("uuid"),
+				Sel: ast.NewIdent("MustParse"),
+			},
+			Args: []ast.Expr{
+				&ast.CallExpr{
+					Fun: ast.NewIdent("string"),
+					Args: []ast.Expr{
+						&ast.SelectorExpr{
+							X:   ast.NewIdent("msg"),
+							Sel: ast.NewIdent("Value"),
+						},
+					},
+				},
+			},
+		}),
+	}
+}
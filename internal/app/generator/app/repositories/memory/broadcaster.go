@@ -0,0 +1,48 @@
+package memory
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+type BroadcasterGenerator struct {
+	domain *configs.EntityConfig
+}
+
+func NewBroadcasterGenerator(domain *configs.EntityConfig) *BroadcasterGenerator {
+	return &BroadcasterGenerator{domain: domain}
+}
+
+func (r *BroadcasterGenerator) Sync() error {
+	err := os.MkdirAll(path.Dir(r.filename()), 0777)
+	if err != nil {
+		return err
+	}
+	broadcaster := tmpl.Template{
+		SourcePath:      "templates/internal/domain/repositories/memory/event.go.tmpl",
+		DestinationPath: r.filename(),
+		Name:            "broadcaster",
+	}
+	if err := broadcaster.RenderToFile(r.domain); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (r *BroadcasterGenerator) filename() string {
+	return filepath.Join(
+		".",
+		"internal",
+		"app",
+		r.domain.AppConfig.AppName(),
+		"repositories",
+		"memory",
+		r.domain.DirName(),
+		r.domain.FileName(),
+	)
+}
@@ -0,0 +1,58 @@
+package mongo
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+type RepositoryGenerator struct {
+	domain *configs.EntityConfig
+}
+
+func NewRepositoryGenerator(domain *configs.EntityConfig) *RepositoryGenerator {
+	return &RepositoryGenerator{domain: domain}
+}
+
+func (r *RepositoryGenerator) Sync() error {
+	files := []*tmpl.Template{
+		{
+			SourcePath:      "templates/internal/domain/repositories/mongo/crud.go.tmpl",
+			DestinationPath: r.filename(r.domain.FileName()),
+			Name:            "mongo repository",
+		},
+		{
+			SourcePath: "templates/internal/domain/repositories/mongo/interfaces.go.tmpl",
+			DestinationPath: r.filename(
+				fmt.Sprintf("%s_interfaces.go", r.domain.SnakeName()),
+			),
+			Name: "mongo repository interfaces",
+		},
+		{
+			SourcePath:      "templates/internal/domain/repositories/mongo/crud_test.go.tmpl",
+			DestinationPath: r.filename(r.domain.TestFileName()),
+			Name:            "mongo repository tests",
+		},
+	}
+	for _, file := range files {
+		if err := file.RenderToFile(r.domain); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *RepositoryGenerator) filename(name string) string {
+	return filepath.Join(
+		".",
+		"internal",
+		"app",
+		r.domain.AppConfig.AppName(),
+		"repositories",
+		"mongo",
+		r.domain.DirName(),
+		name,
+	)
+}
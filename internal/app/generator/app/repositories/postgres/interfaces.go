@@ -30,7 +30,7 @@ func (r InterfacesGenerator) Sync() error {
 		"app",
 		r.domain.AppConfig.AppName(),
 		"repositories",
-		"postgres",
+		r.domain.Project().Database,
 		r.domain.DirName(),
 		fmt.Sprintf("%s_interfaces.go", r.domain.SnakeName()),
 	)
@@ -10,6 +10,7 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 
 	"github.com/mikalai-mitsin/creathor/internal/pkg/astfile"
 	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
@@ -35,13 +36,29 @@ func (r RepositoryGenerator) getDTOListName() string {
 	return fmt.Sprintf("%sListDTO", strcase.ToCamel(r.domain.GetMainModel().Name))
 }
 
+// dtoType returns the DTO field type of the param for the project database.
+func (r RepositoryGenerator) dtoType(param *configs.Param) string {
+	if r.domain.Project().Database == "postgres" {
+		return param.PostgresDTOType()
+	}
+	return param.SQLDTOType(r.domain.Project().Database)
+}
+
+// dtoSliceType returns the element type of the DTO field of a slice param.
+func (r RepositoryGenerator) dtoSliceType(param *configs.Param) string {
+	if r.domain.Project().Database == "postgres" {
+		return param.PostgresDTOSliceType()
+	}
+	return param.SliceType()
+}
+
 func (r RepositoryGenerator) filename() string {
 	return filepath.Join(
 		"internal",
 		"app",
 		r.domain.AppConfig.AppName(),
 		"repositories",
-		"postgres",
+		r.domain.Project().Database,
 		r.domain.DirName(),
 		r.domain.FileName(),
 	)
@@ -58,6 +75,9 @@ func (r RepositoryGenerator) Sync() error {
 	if err := r.syncConstructor(); err != nil {
 		return err
 	}
+	if err := r.syncReaderMethod(); err != nil {
+		return err
+	}
 	if err := r.syncOrderByMap(); err != nil {
 		return err
 	}
@@ -162,7 +182,7 @@ func (r RepositoryGenerator) dtoStruct() *ast.TypeSpec {
 				st.Fields.List = append(st.Fields.List, &ast.Field{
 					Doc:   nil,
 					Names: []*ast.Ident{ast.NewIdent(param.GetName())},
-					Type:  ast.NewIdent(param.PostgresDTOType()),
+					Type:  ast.NewIdent(r.dtoType(param)),
 					Tag: &ast.BasicLit{
 						Kind:  token.STRING,
 						Value: fmt.Sprintf("`db:\"%s\"`", param.Tag()),
@@ -191,7 +211,7 @@ func (r RepositoryGenerator) syncDTOStruct() error {
 		astfile.SetTypeParam(
 			structure,
 			param.GetName(),
-			param.PostgresDTOType(),
+			r.dtoType(param),
 			fmt.Sprintf("`db:\"%s\"`", param.Tag()),
 		)
 	}
@@ -223,17 +243,17 @@ func (r RepositoryGenerator) dtoConstructor() *ast.FuncDecl {
 		}
 		if param.IsSlice() {
 			elt.Value = &ast.CompositeLit{
-				Type: ast.NewIdent(param.PostgresDTOType()),
+				Type: ast.NewIdent(r.dtoType(param)),
 			}
 		} else {
-			if param.PostgresDTOType() == param.Type {
+			if r.dtoType(param) == param.Type {
 				elt.Value = &ast.SelectorExpr{
 					X:   ast.NewIdent("entity"),
 					Sel: ast.NewIdent(param.GetName()),
 				}
 			} else {
 				elt.Value = &ast.CallExpr{
-					Fun: ast.NewIdent(param.PostgresDTOType()),
+					Fun: ast.NewIdent(r.dtoType(param)),
 					Args: []ast.Expr{
 						&ast.SelectorExpr{
 							X:   ast.NewIdent("entity"),
@@ -288,11 +308,11 @@ func (r RepositoryGenerator) dtoConstructor() *ast.FuncDecl {
 			continue
 		}
 		var valueToAppend ast.Expr
-		if param.SliceType() == param.PostgresDTOSliceType() {
+		if param.SliceType() == r.dtoSliceType(param) {
 			valueToAppend = ast.NewIdent("param")
 		} else {
 			valueToAppend = &ast.CallExpr{
-				Fun: ast.NewIdent(param.PostgresDTOSliceType()),
+				Fun: ast.NewIdent(r.dtoSliceType(param)),
 				Args: []ast.Expr{
 					ast.NewIdent("param"),
 				},
@@ -387,17 +407,17 @@ func (r RepositoryGenerator) syncDTOConstructor() error {
 					}
 					if param.IsSlice() {
 						elt.Value = &ast.CompositeLit{
-							Type: ast.NewIdent(param.PostgresDTOType()),
+							Type: ast.NewIdent(r.dtoType(param)),
 						}
 					} else {
-						if param.PostgresDTOType() == param.Type {
+						if r.dtoType(param) == param.Type {
 							elt.Value = &ast.SelectorExpr{
 								X:   ast.NewIdent("entity"),
 								Sel: ast.NewIdent(param.GetName()),
 							}
 						} else {
 							elt.Value = &ast.CallExpr{
-								Fun: ast.NewIdent(param.PostgresDTOType()),
+								Fun: ast.NewIdent(r.dtoType(param)),
 								Args: []ast.Expr{
 									&ast.SelectorExpr{
 										X:   ast.NewIdent("entity"),
@@ -447,7 +467,7 @@ func (r RepositoryGenerator) dtoToModel() *ast.FuncDecl {
 				},
 			}
 		} else {
-			if param.PostgresDTOType() == param.Type {
+			if r.dtoType(param) == param.Type {
 				par.Value = &ast.SelectorExpr{
 					X:   ast.NewIdent("dto"),
 					Sel: ast.NewIdent(param.GetName()),
@@ -514,7 +534,7 @@ func (r RepositoryGenerator) dtoToModel() *ast.FuncDecl {
 			continue
 		}
 		var valueToAppend ast.Expr
-		if param.SliceType() == param.PostgresDTOSliceType() {
+		if param.SliceType() == r.dtoSliceType(param) {
 			valueToAppend = ast.NewIdent("param")
 		} else {
 			valueToAppend = &ast.CallExpr{
@@ -689,6 +709,12 @@ func (r RepositoryGenerator) file() *ast.File {
 				Value: r.domain.AppConfig.ProjectConfig.UUIDImportPath(),
 			},
 		},
+		&ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: r.domain.AppConfig.ProjectConfig.TracingImportPath(),
+			},
+		},
 		&ast.ImportSpec{
 			Name: ast.NewIdent("sq"),
 			Path: &ast.BasicLit{
@@ -696,18 +722,25 @@ func (r RepositoryGenerator) file() *ast.File {
 				Value: `"github.com/Masterminds/squirrel"`,
 			},
 		},
-		&ast.ImportSpec{
+	}
+	project := r.domain.Project()
+	if project.Database == "postgres" {
+		specs = append(specs, &ast.ImportSpec{
 			Path: &ast.BasicLit{
 				Kind:  token.STRING,
 				Value: `"github.com/lib/pq"`,
 			},
-		},
+		})
 	}
-	if r.domain.SearchEnabled() {
+	hasSlices := slices.ContainsFunc(
+		r.domain.GetMainModel().Params,
+		func(param *configs.Param) bool { return param.IsSlice() },
+	)
+	if r.domain.SearchEnabled() || (project.Database != "postgres" && hasSlices) {
 		specs = append(specs, &ast.ImportSpec{
 			Path: &ast.BasicLit{
 				Kind:  token.STRING,
-				Value: r.domain.AppConfig.ProjectConfig.PostgresImportPath(),
+				Value: project.DatabaseImportPath(),
 			},
 		})
 	}
@@ -722,6 +755,40 @@ func (r RepositoryGenerator) file() *ast.File {
 	}
 }
 
+// traceStatements records every built query on the method span right after it is rendered.
+func (r RepositoryGenerator) traceStatements(method *ast.FuncDecl) {
+	ast.Inspect(method.Body, func(node ast.Node) bool {
+		block, ok := node.(*ast.BlockStmt)
+		if !ok {
+			return true
+		}
+		stmts := make([]ast.Stmt, 0, len(block.List))
+		for _, stmt := range block.List {
+			stmts = append(stmts, stmt)
+			assign, ok := stmt.(*ast.AssignStmt)
+			if !ok || len(assign.Lhs) == 0 {
+				continue
+			}
+			if ident, ok := assign.Lhs[0].(*ast.Ident); ok && ident.Name == "query" {
+				stmts = append(stmts, &ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("tracing"),
+							Sel: ast.NewIdent("Statement"),
+						},
+						Args: []ast.Expr{
+							ast.NewIdent("ctx"),
+							ast.NewIdent("query"),
+						},
+					},
+				})
+			}
+		}
+		block.List = stmts
+		return true
+	})
+}
+
 func (r RepositoryGenerator) syncStruct() error {
 	fileset := token.NewFileSet()
 	file, err := parser.ParseFile(fileset, r.filename(), nil, parser.ParseComments)
@@ -859,6 +926,117 @@ func (r RepositoryGenerator) syncConstructor() error {
 	return nil
 }
 
+// reader returns the r.reader(ctx) call the read methods run their queries on.
+func (r RepositoryGenerator) reader() *ast.CallExpr {
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("r"),
+			Sel: ast.NewIdent("reader"),
+		},
+		Args: []ast.Expr{
+			ast.NewIdent("ctx"),
+		},
+	}
+}
+
+// astReaderMethod reads through the transaction carried by ctx, so reads inside a usecase see its own writes.
+func (r RepositoryGenerator) astReaderMethod() *ast.FuncDecl {
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{ast.NewIdent("r")},
+					Type: &ast.StarExpr{
+						X: ast.NewIdent(r.domain.GetRepositoryTypeName()),
+					},
+				},
+			},
+		},
+		Name: ast.NewIdent("reader"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{ast.NewIdent("ctx")},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("context"),
+							Sel: ast.NewIdent("Context"),
+						},
+					},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: ast.NewIdent("database"),
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.IfStmt{
+					Init: &ast.AssignStmt{
+						Lhs: []ast.Expr{
+							ast.NewIdent("tx"),
+							ast.NewIdent("ok"),
+						},
+						Tok: token.DEFINE,
+						Rhs: []ast.Expr{
+							&ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("dtx"),
+									Sel: ast.NewIdent("FromContext"),
+								},
+								Args: []ast.Expr{
+									ast.NewIdent("ctx"),
+								},
+							},
+						},
+					},
+					Cond: ast.NewIdent("ok"),
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									ast.NewIdent("tx"),
+								},
+							},
+						},
+					},
+				},
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.SelectorExpr{
+							X:   ast.NewIdent("r"),
+							Sel: ast.NewIdent("readDB"),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (r RepositoryGenerator) syncReaderMethod() error {
+	fileset := token.NewFileSet()
+	file, err := parser.ParseFile(fileset, r.filename(), nil, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	if _, methodExist := astfile.FindFunc(file, "reader"); !methodExist {
+		file.Decls = append(file.Decls, r.astReaderMethod())
+	}
+	buff := &bytes.Buffer{}
+	if err := printer.Fprint(buff, fileset, file); err != nil {
+		return err
+	}
+	if err := os.WriteFile(r.filename(), buff.Bytes(), 0777); err != nil {
+		return err
+	}
+	return nil
+}
+
 func (r RepositoryGenerator) astCreateMethod() *ast.FuncDecl {
 	var columns []ast.Expr
 	var values []ast.Expr
@@ -982,11 +1160,8 @@ func (r RepositoryGenerator) astCreateMethod() *ast.FuncDecl {
 											},
 											Args: []ast.Expr{
 												&ast.BasicLit{
-													Kind: token.STRING,
-													Value: fmt.Sprintf(
-														`"public.%s"`,
-														r.domain.TableName(),
-													),
+													Kind:  token.STRING,
+													Value: fmt.Sprintf(`"%s"`, r.domain.QualifiedTableName()),
 												},
 											},
 										},
@@ -1017,8 +1192,10 @@ func (r RepositoryGenerator) astCreateMethod() *ast.FuncDecl {
 									},
 									Args: []ast.Expr{
 										&ast.SelectorExpr{
-											X:   ast.NewIdent("sq"),
-											Sel: ast.NewIdent("Dollar"),
+											X: ast.NewIdent("sq"),
+											Sel: ast.NewIdent(
+												r.domain.Project().PlaceholderFormat(),
+											),
 										},
 									},
 								},
@@ -1038,12 +1215,7 @@ func (r RepositoryGenerator) astCreateMethod() *ast.FuncDecl {
 						Rhs: []ast.Expr{
 							&ast.CallExpr{
 								Fun: &ast.SelectorExpr{
-									X: &ast.CallExpr{
-										Fun: &ast.SelectorExpr{
-											X:   ast.NewIdent("tx"),
-											Sel: ast.NewIdent("GetSQLTx"),
-										},
-									},
+									X:   ast.NewIdent("tx"),
 									Sel: ast.NewIdent("ExecContext"),
 								},
 								Args: []ast.Expr{
@@ -1071,7 +1243,7 @@ func (r RepositoryGenerator) astCreateMethod() *ast.FuncDecl {
 									&ast.CallExpr{
 										Fun: &ast.SelectorExpr{
 											X:   ast.NewIdent("errs"),
-											Sel: ast.NewIdent("FromPostgresError"),
+											Sel: ast.NewIdent(r.domain.Project().DatabaseErrorFunc()),
 										},
 										Args: []ast.Expr{
 											ast.NewIdent("err"),
@@ -1107,6 +1279,8 @@ func (r RepositoryGenerator) syncCreateMethod() error {
 	method, methodExist := astfile.FindFunc(file, "Create")
 	if method == nil {
 		method = r.astCreateMethod()
+		astfile.TraceFunc(method, r.domain.SpanName("repository", "Create"))
+		r.traceStatements(method)
 	}
 	for _, param := range r.domain.GetMainModel().Params {
 		param := param
@@ -1178,6 +1352,51 @@ func (r RepositoryGenerator) search() ast.Stmt {
 			})
 		}
 	}
+	database := r.domain.Project().Database
+	search := &ast.CompositeLit{
+		Type: &ast.SelectorExpr{
+			X:   ast.NewIdent(database),
+			Sel: ast.NewIdent("Search"),
+		},
+	}
+	if database == "postgres" {
+		search.Elts = append(search.Elts, &ast.KeyValueExpr{
+			Key: ast.NewIdent("Lang"),
+			Value: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"english"`,
+			},
+		})
+	}
+	if database == "sqlite" {
+		search.Elts = append(search.Elts, &ast.KeyValueExpr{
+			Key: ast.NewIdent("Table"),
+			Value: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: fmt.Sprintf(`"%s"`, r.domain.TableName()),
+			},
+		})
+	}
+	search.Elts = append(search.Elts, &ast.KeyValueExpr{
+		Key: ast.NewIdent("Query"),
+		Value: &ast.StarExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent("filter"),
+				Sel: ast.NewIdent("Search"),
+			},
+		},
+	})
+	if database != "sqlite" {
+		search.Elts = append(search.Elts, &ast.KeyValueExpr{
+			Key: ast.NewIdent("Fields"),
+			Value: &ast.CompositeLit{
+				Type: &ast.ArrayType{
+					Elt: ast.NewIdent("string"),
+				},
+				Elts: columns,
+			},
+		})
+	}
 	stmt := &ast.IfStmt{
 		Cond: &ast.BinaryExpr{
 			X: &ast.SelectorExpr{
@@ -1201,39 +1420,7 @@ func (r RepositoryGenerator) search() ast.Stmt {
 								Sel: ast.NewIdent("Where"),
 							},
 							Args: []ast.Expr{
-								&ast.CompositeLit{
-									Type: &ast.SelectorExpr{
-										X:   ast.NewIdent("postgres"),
-										Sel: ast.NewIdent("Search"),
-									},
-									Elts: []ast.Expr{
-										&ast.KeyValueExpr{
-											Key: ast.NewIdent("Lang"),
-											Value: &ast.BasicLit{
-												Kind:  token.STRING,
-												Value: `"english"`,
-											},
-										},
-										&ast.KeyValueExpr{
-											Key: ast.NewIdent("Query"),
-											Value: &ast.StarExpr{
-												X: &ast.SelectorExpr{
-													X:   ast.NewIdent("filter"),
-													Sel: ast.NewIdent("Search"),
-												},
-											},
-										},
-										&ast.KeyValueExpr{
-											Key: ast.NewIdent("Fields"),
-											Value: &ast.CompositeLit{
-												Type: &ast.ArrayType{
-													Elt: ast.NewIdent("string"),
-												},
-												Elts: columns,
-											},
-										},
-									},
-								},
+								search,
 							},
 						},
 					},
@@ -1430,7 +1617,7 @@ func (r RepositoryGenerator) listMethod() *ast.FuncDecl {
 									Args: []ast.Expr{
 										&ast.BasicLit{
 											Kind:  token.STRING,
-											Value: fmt.Sprintf(`"public.%s"`, tableName),
+											Value: fmt.Sprintf(`"%s"`, r.domain.QualifiedTableName()),
 										},
 									},
 								},
@@ -1605,8 +1792,10 @@ func (r RepositoryGenerator) listMethod() *ast.FuncDecl {
 									},
 									Args: []ast.Expr{
 										&ast.SelectorExpr{
-											X:   ast.NewIdent("sq"),
-											Sel: ast.NewIdent("Dollar"),
+											X: ast.NewIdent("sq"),
+											Sel: ast.NewIdent(
+												r.domain.Project().PlaceholderFormat(),
+											),
 										},
 									},
 								},
@@ -1624,10 +1813,7 @@ func (r RepositoryGenerator) listMethod() *ast.FuncDecl {
 						Rhs: []ast.Expr{
 							&ast.CallExpr{
 								Fun: &ast.SelectorExpr{
-									X: &ast.SelectorExpr{
-										X:   ast.NewIdent("r"),
-										Sel: ast.NewIdent("readDB"),
-									},
+									X:   r.reader(),
 									Sel: ast.NewIdent("SelectContext"),
 								},
 								Args: []ast.Expr{
@@ -1659,7 +1845,7 @@ func (r RepositoryGenerator) listMethod() *ast.FuncDecl {
 									&ast.CallExpr{
 										Fun: &ast.SelectorExpr{
 											X:   ast.NewIdent("errs"),
-											Sel: ast.NewIdent("FromPostgresError"),
+											Sel: ast.NewIdent(r.domain.Project().DatabaseErrorFunc()),
 										},
 										Args: []ast.Expr{
 											ast.NewIdent("err"),
@@ -1701,6 +1887,8 @@ func (r RepositoryGenerator) syncListMethod() error {
 	method, methodExist := astfile.FindFunc(file, "List")
 	if method == nil {
 		method = r.listMethod()
+		astfile.TraceFunc(method, r.domain.SpanName("repository", "List"))
+		r.traceStatements(method)
 	}
 	for _, param := range r.domain.GetMainModel().Params {
 		param := param
@@ -1864,7 +2052,7 @@ func (r RepositoryGenerator) astCountMethod() *ast.FuncDecl {
 							Args: []ast.Expr{
 								&ast.BasicLit{
 									Kind:  token.STRING,
-									Value: fmt.Sprintf(`"public.%s"`, r.domain.TableName()),
+									Value: fmt.Sprintf(`"%s"`, r.domain.QualifiedTableName()),
 								},
 							},
 						},
@@ -1887,8 +2075,10 @@ func (r RepositoryGenerator) astCountMethod() *ast.FuncDecl {
 									},
 									Args: []ast.Expr{
 										&ast.SelectorExpr{
-											X:   ast.NewIdent("sq"),
-											Sel: ast.NewIdent("Dollar"),
+											X: ast.NewIdent("sq"),
+											Sel: ast.NewIdent(
+												r.domain.Project().PlaceholderFormat(),
+											),
 										},
 									},
 								},
@@ -1923,14 +2113,7 @@ func (r RepositoryGenerator) astCountMethod() *ast.FuncDecl {
 						Rhs: []ast.Expr{
 							&ast.CallExpr{
 								Fun: &ast.SelectorExpr{
-									X: &ast.SelectorExpr{
-										X: &ast.Ident{
-											Name: "r",
-										},
-										Sel: &ast.Ident{
-											Name: "readDB",
-										},
-									},
+									X: r.reader(),
 									Sel: &ast.Ident{
 										Name: "GetContext",
 									},
@@ -1981,7 +2164,7 @@ func (r RepositoryGenerator) astCountMethod() *ast.FuncDecl {
 												Name: "errs",
 											},
 											Sel: &ast.Ident{
-												Name: "FromPostgresError",
+												Name: r.domain.Project().DatabaseErrorFunc(),
 											},
 										},
 										Args: []ast.Expr{
@@ -2026,6 +2209,8 @@ func (r RepositoryGenerator) syncCountMethod() error {
 	method, methodExist := astfile.FindFunc(file, "Count")
 	if method == nil {
 		method = r.astCountMethod()
+		astfile.TraceFunc(method, r.domain.SpanName("repository", "Count"))
+		r.traceStatements(method)
 	}
 	if !methodExist {
 		file.Decls = append(file.Decls, method)
@@ -2170,7 +2355,7 @@ func (r RepositoryGenerator) getMethod() *ast.FuncDecl {
 											Args: []ast.Expr{
 												&ast.BasicLit{
 													Kind:  token.STRING,
-													Value: fmt.Sprintf(`"public.%s"`, tableName),
+													Value: fmt.Sprintf(`"%s"`, r.domain.QualifiedTableName()),
 												},
 											},
 										},
@@ -2221,8 +2406,10 @@ func (r RepositoryGenerator) getMethod() *ast.FuncDecl {
 									},
 									Args: []ast.Expr{
 										&ast.SelectorExpr{
-											X:   ast.NewIdent("sq"),
-											Sel: ast.NewIdent("Dollar"),
+											X: ast.NewIdent("sq"),
+											Sel: ast.NewIdent(
+												r.domain.Project().PlaceholderFormat(),
+											),
 										},
 									},
 								},
@@ -2240,10 +2427,7 @@ func (r RepositoryGenerator) getMethod() *ast.FuncDecl {
 						Rhs: []ast.Expr{
 							&ast.CallExpr{
 								Fun: &ast.SelectorExpr{
-									X: &ast.SelectorExpr{
-										X:   ast.NewIdent("r"),
-										Sel: ast.NewIdent("readDB"),
-									},
+									X:   r.reader(),
 									Sel: ast.NewIdent("GetContext"),
 								},
 								Args: []ast.Expr{
@@ -2274,7 +2458,7 @@ func (r RepositoryGenerator) getMethod() *ast.FuncDecl {
 											X: &ast.CallExpr{
 												Fun: &ast.SelectorExpr{
 													X:   ast.NewIdent("errs"),
-													Sel: ast.NewIdent("FromPostgresError"),
+													Sel: ast.NewIdent(r.domain.Project().DatabaseErrorFunc()),
 												},
 												Args: []ast.Expr{
 													ast.NewIdent("err"),
@@ -2336,6 +2520,8 @@ func (r RepositoryGenerator) syncGetMethod() error {
 	method, methodExist := astfile.FindFunc(file, "Get")
 	if method == nil {
 		method = r.getMethod()
+		astfile.TraceFunc(method, r.domain.SpanName("repository", "Get"))
+		r.traceStatements(method)
 	}
 	for _, param := range r.domain.GetMainModel().Params {
 		param := param
@@ -2373,7 +2559,6 @@ func (r RepositoryGenerator) syncGetMethod() error {
 }
 
 func (r RepositoryGenerator) updateMethod() *ast.FuncDecl {
-	tableName := r.domain.TableName()
 	updateBlock := &ast.BlockStmt{
 		List: []ast.Stmt{},
 	}
@@ -2517,7 +2702,7 @@ func (r RepositoryGenerator) updateMethod() *ast.FuncDecl {
 									Args: []ast.Expr{
 										&ast.BasicLit{
 											Kind:  token.STRING,
-											Value: fmt.Sprintf(`"public.%s"`, tableName),
+											Value: fmt.Sprintf(`"%s"`, r.domain.QualifiedTableName()),
 										},
 									},
 								},
@@ -2563,8 +2748,10 @@ func (r RepositoryGenerator) updateMethod() *ast.FuncDecl {
 									},
 									Args: []ast.Expr{
 										&ast.SelectorExpr{
-											X:   ast.NewIdent("sq"),
-											Sel: ast.NewIdent("Dollar"),
+											X: ast.NewIdent("sq"),
+											Sel: ast.NewIdent(
+												r.domain.Project().PlaceholderFormat(),
+											),
 										},
 									},
 								},
@@ -2582,12 +2769,7 @@ func (r RepositoryGenerator) updateMethod() *ast.FuncDecl {
 					Rhs: []ast.Expr{
 						&ast.CallExpr{
 							Fun: &ast.SelectorExpr{
-								X: &ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X:   ast.NewIdent("tx"),
-										Sel: ast.NewIdent("GetSQLTx"),
-									},
-								},
+								X:   ast.NewIdent("tx"),
 								Sel: ast.NewIdent("ExecContext"),
 							},
 							Args: []ast.Expr{
@@ -2618,7 +2800,7 @@ func (r RepositoryGenerator) updateMethod() *ast.FuncDecl {
 											X: &ast.CallExpr{
 												Fun: &ast.SelectorExpr{
 													X:   ast.NewIdent("errs"),
-													Sel: ast.NewIdent("FromPostgresError"),
+													Sel: ast.NewIdent(r.domain.Project().DatabaseErrorFunc()),
 												},
 												Args: []ast.Expr{
 													ast.NewIdent("err"),
@@ -2688,7 +2870,7 @@ func (r RepositoryGenerator) updateMethod() *ast.FuncDecl {
 											X: &ast.CallExpr{
 												Fun: &ast.SelectorExpr{
 													X:   ast.NewIdent("errs"),
-													Sel: ast.NewIdent("FromPostgresError"),
+													Sel: ast.NewIdent(r.domain.Project().DatabaseErrorFunc()),
 												},
 												Args: []ast.Expr{
 													ast.NewIdent("err"),
@@ -2802,6 +2984,8 @@ func (r RepositoryGenerator) syncUpdateMethod() error {
 	method, methodExist := astfile.FindFunc(file, "Update")
 	if method == nil {
 		method = r.updateMethod()
+		astfile.TraceFunc(method, r.domain.SpanName("repository", "Update"))
+		r.traceStatements(method)
 	}
 	for _, param := range r.domain.GetMainModel().Params {
 		param := param
@@ -2973,11 +3157,8 @@ func (r RepositoryGenerator) astDeleteMethod() *ast.FuncDecl {
 									},
 									Args: []ast.Expr{
 										&ast.BasicLit{
-											Kind: token.STRING,
-											Value: fmt.Sprintf(
-												`"public.%s"`,
-												r.domain.TableName(),
-											),
+											Kind:  token.STRING,
+											Value: fmt.Sprintf(`"%s"`, r.domain.QualifiedTableName()),
 										},
 									},
 								},
@@ -3019,8 +3200,10 @@ func (r RepositoryGenerator) astDeleteMethod() *ast.FuncDecl {
 									},
 									Args: []ast.Expr{
 										&ast.SelectorExpr{
-											X:   ast.NewIdent("sq"),
-											Sel: ast.NewIdent("Dollar"),
+											X: ast.NewIdent("sq"),
+											Sel: ast.NewIdent(
+												r.domain.Project().PlaceholderFormat(),
+											),
 										},
 									},
 								},
@@ -3038,12 +3221,7 @@ func (r RepositoryGenerator) astDeleteMethod() *ast.FuncDecl {
 					Rhs: []ast.Expr{
 						&ast.CallExpr{
 							Fun: &ast.SelectorExpr{
-								X: &ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X:   ast.NewIdent("tx"),
-										Sel: ast.NewIdent("GetSQLTx"),
-									},
-								},
+								X:   ast.NewIdent("tx"),
 								Sel: ast.NewIdent("ExecContext"),
 							},
 							Args: []ast.Expr{
@@ -3074,7 +3252,7 @@ func (r RepositoryGenerator) astDeleteMethod() *ast.FuncDecl {
 											X: &ast.CallExpr{
 												Fun: &ast.SelectorExpr{
 													X:   ast.NewIdent("errs"),
-													Sel: ast.NewIdent("FromPostgresError"),
+													Sel: ast.NewIdent(r.domain.Project().DatabaseErrorFunc()),
 												},
 												Args: []ast.Expr{
 													ast.NewIdent("err"),
@@ -3145,7 +3323,7 @@ func (r RepositoryGenerator) astDeleteMethod() *ast.FuncDecl {
 											X: &ast.CallExpr{
 												Fun: &ast.SelectorExpr{
 													X:   ast.NewIdent("errs"),
-													Sel: ast.NewIdent("FromPostgresError"),
+													Sel: ast.NewIdent(r.domain.Project().DatabaseErrorFunc()),
 												},
 												Args: []ast.Expr{
 													ast.NewIdent("err"),
@@ -3257,6 +3435,8 @@ func (r RepositoryGenerator) syncDeleteMethod() error {
 	method, methodExist := astfile.FindFunc(file, "Delete")
 	if method == nil {
 		method = r.astDeleteMethod()
+		astfile.TraceFunc(method, r.domain.SpanName("repository", "Delete"))
+		r.traceStatements(method)
 	}
 	if !methodExist {
 		file.Decls = append(file.Decls, method)
@@ -3403,7 +3583,9 @@ func (r RepositoryGenerator) astDTOToEntities() *ast.FuncDecl {
 
 func (r RepositoryGenerator) astOrderByMap() *ast.GenDecl {
 	var values []ast.Expr
-	for cnt, column := range r.domain.OrderingMap() {
+	columns := r.domain.OrderingMap()
+	for _, cnt := range r.domain.OrderingNames() {
+		column := columns[cnt]
 		values = append(values, &ast.KeyValueExpr{
 			Key: &ast.SelectorExpr{
 				X: &ast.Ident{
@@ -3680,7 +3862,7 @@ func (r RepositoryGenerator) syncMigrations() error {
 		destinationPath,
 		"internal",
 		"pkg",
-		"postgres",
+		r.domain.Project().Database,
 		"migrations",
 	))
 	if err != nil {
@@ -3698,24 +3880,30 @@ func (r RepositoryGenerator) syncMigrations() error {
 
 	files := []*tmpl.Template{
 		{
-			SourcePath: "templates/internal/pkg/postgres/migrations/crud.up.sql.tmpl",
+			SourcePath: fmt.Sprintf(
+				"templates/internal/pkg/%s/migrations/crud.up.sql.tmpl",
+				r.domain.Project().Database,
+			),
 			DestinationPath: path.Join(
 				destinationPath,
 				"internal",
 				"pkg",
-				"postgres",
+				r.domain.Project().Database,
 				"migrations",
 				r.domain.MigrationUpFileName(),
 			),
 			Name: "migration up",
 		},
 		{
-			SourcePath: "templates/internal/pkg/postgres/migrations/crud.down.sql.tmpl",
+			SourcePath: fmt.Sprintf(
+				"templates/internal/pkg/%s/migrations/crud.down.sql.tmpl",
+				r.domain.Project().Database,
+			),
 			DestinationPath: path.Join(
 				destinationPath,
 				"internal",
 				"pkg",
-				"postgres",
+				r.domain.Project().Database,
 				"migrations",
 				r.domain.MigrationDownFileName(),
 			),
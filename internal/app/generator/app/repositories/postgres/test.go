@@ -26,7 +26,7 @@ func (g *TestGenerator) Sync() error {
 			"app",
 			g.domain.AppConfig.AppName(),
 			"repositories",
-			"postgres",
+			g.domain.Project().Database,
 			g.domain.DirName(),
 			g.domain.TestFileName(),
 		),
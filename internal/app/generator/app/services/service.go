@@ -88,6 +88,12 @@ func (u ServiceGenerator) file() *ast.File {
 							Value: u.domain.AppConfig.ProjectConfig.UUIDImportPath(),
 						},
 					},
+					&ast.ImportSpec{
+						Path: &ast.BasicLit{
+							Kind:  token.STRING,
+							Value: u.domain.AppConfig.ProjectConfig.TracingImportPath(),
+						},
+					},
 				},
 			},
 		},
@@ -470,6 +476,7 @@ func (u ServiceGenerator) syncCreateMethod() error {
 	method, methodExist := astfile.FindFunc(file, "Create")
 	if method == nil {
 		method = u.create()
+		astfile.TraceFunc(method, u.domain.SpanName("service", "Create"))
 	}
 	if !methodExist {
 		file.Decls = append(file.Decls, method)
@@ -679,6 +686,7 @@ func (u ServiceGenerator) syncListMethod() error {
 	method, methodExist := astfile.FindFunc(file, "List")
 	if method == nil {
 		method = u.list()
+		astfile.TraceFunc(method, u.domain.SpanName("service", "List"))
 	}
 	if !methodExist {
 		file.Decls = append(file.Decls, method)
@@ -804,6 +812,7 @@ func (u ServiceGenerator) syncGetMethod() error {
 	method, methodExist := astfile.FindFunc(file, "Get")
 	if method == nil {
 		method = u.get()
+		astfile.TraceFunc(method, u.domain.SpanName("service", "Get"))
 	}
 	if !methodExist {
 		file.Decls = append(file.Decls, method)
@@ -1093,6 +1102,7 @@ func (u ServiceGenerator) syncUpdateMethod() error {
 	method, methodExist := astfile.FindFunc(file, "Update")
 	if method == nil {
 		method = u.update()
+		astfile.TraceFunc(method, u.domain.SpanName("service", "Update"))
 	}
 	for _, param := range u.domain.GetUpdateModel().Params {
 		param := param
@@ -1274,6 +1284,7 @@ func (u ServiceGenerator) syncDeleteMethod() error {
 	method, methodExist := astfile.FindFunc(file, "Delete")
 	if method == nil {
 		method = u.delete()
+		astfile.TraceFunc(method, u.domain.SpanName("service", "Delete"))
 	}
 	if !methodExist {
 		file.Decls = append(file.Decls, method)
@@ -36,7 +36,7 @@ func (i InterfacesGenerator) Sync() error {
 	if err != nil {
 		return err
 	}
-	file, err := parser.ParseFile(fileset, filename, nil, parser.AllErrors)
+	file, err := parser.ParseFile(fileset, filename, nil, parser.ParseComments)
 	if err != nil {
 		file = i.file()
 	}
@@ -44,7 +44,7 @@ func (i InterfacesGenerator) Sync() error {
 		file.Decls = append(file.Decls, i.appServiceInterface())
 	}
 	if !astfile.TypeExists(file, i.domain.EventProducerInterfaceName()) &&
-		i.domain.AppConfig.ProjectConfig.KafkaEnabled {
+		i.domain.EventProducerEnabled() {
 		file.Decls = append(file.Decls, i.appEventProducerInterface())
 	}
 	if !astfile.TypeExists(file, "logger") {
@@ -95,6 +95,12 @@ func (i InterfacesGenerator) imports() *ast.GenDecl {
 					Value: `"context"`,
 				},
 			},
+			&ast.ImportSpec{
+				Path: &ast.BasicLit{
+					Kind:  token.STRING,
+					Value: `"database/sql"`,
+				},
+			},
 			&ast.ImportSpec{
 				Path: &ast.BasicLit{
 					Kind:  token.STRING,
@@ -524,34 +530,66 @@ func (i InterfacesGenerator) dtxManagerInterface() *ast.GenDecl {
 		Tok: token.TYPE,
 		Specs: []ast.Spec{
 			&ast.TypeSpec{
-				Name: &ast.Ident{
-					Name: "dtxManager",
-				},
+				Name: ast.NewIdent("dtxManager"),
 				Type: &ast.InterfaceType{
 					Methods: &ast.FieldList{
 						List: []*ast.Field{
 							{
-								Names: []*ast.Ident{
-									{
-										Name: "NewTx",
-									},
-								},
+								Names: []*ast.Ident{ast.NewIdent("WithTx")},
 								Type: &ast.FuncType{
-									Params: &ast.FieldList{},
-									Results: &ast.FieldList{
+									Params: &ast.FieldList{
 										List: []*ast.Field{
 											{
+												Names: []*ast.Ident{ast.NewIdent("ctx")},
 												Type: &ast.SelectorExpr{
-													X: &ast.Ident{
-														Name: "dtx",
+													X:   ast.NewIdent("context"),
+													Sel: ast.NewIdent("Context"),
+												},
+											},
+											{
+												Names: []*ast.Ident{ast.NewIdent("opts")},
+												Type: &ast.StarExpr{
+													X: &ast.SelectorExpr{
+														X:   ast.NewIdent("sql"),
+														Sel: ast.NewIdent("TxOptions"),
 													},
-													Sel: &ast.Ident{
-														Name: "TX",
+												},
+											},
+											{
+												Names: []*ast.Ident{ast.NewIdent("fn")},
+												Type: &ast.FuncType{
+													Params: &ast.FieldList{
+														List: []*ast.Field{
+															{
+																Names: []*ast.Ident{ast.NewIdent("ctx")},
+																Type: &ast.SelectorExpr{
+																	X:   ast.NewIdent("context"),
+																	Sel: ast.NewIdent("Context"),
+																},
+															},
+															{
+																Names: []*ast.Ident{ast.NewIdent("tx")},
+																Type: &ast.SelectorExpr{
+																	X:   ast.NewIdent("dtx"),
+																	Sel: ast.NewIdent("TX"),
+																},
+															},
+														},
+													},
+													Results: &ast.FieldList{
+														List: []*ast.Field{
+															{Type: ast.NewIdent("error")},
+														},
 													},
 												},
 											},
 										},
 									},
+									Results: &ast.FieldList{
+										List: []*ast.Field{
+											{Type: ast.NewIdent("error")},
+										},
+									},
 								},
 							},
 						},
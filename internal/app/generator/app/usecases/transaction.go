@@ -0,0 +1,237 @@
+package usecases
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// createBody runs the service and the event producer in one transaction and returns the created entity.
+func (i UseCaseGenerator) createBody() []ast.Stmt {
+	return i.writeBody("Create", "create", "created", "Created")
+}
+
+// updateBody runs the service and the event producer in one transaction and returns the updated entity.
+func (i UseCaseGenerator) updateBody() []ast.Stmt {
+	return i.writeBody("Update", "update", "updated", "Updated")
+}
+
+func (i UseCaseGenerator) writeBody(method, argument, result, event string) []ast.Stmt {
+	variable := i.domain.GetOneVariableName()
+	empty := &ast.CompositeLit{
+		Type: &ast.SelectorExpr{
+			X:   ast.NewIdent("entities"),
+			Sel: ast.NewIdent(i.domain.GetMainModel().Name),
+		},
+	}
+	stmts := []ast.Stmt{
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{
+				ast.NewIdent(result),
+				ast.NewIdent("err"),
+			},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				i.serviceCall(method, ast.NewIdent(argument)),
+			},
+		},
+		returnOnError(),
+	}
+	if i.domain.EventProducerEnabled() {
+		stmts = append(stmts, i.eventStmt(event, ast.NewIdent(result)))
+	}
+	stmts = append(stmts,
+		&ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent(variable)},
+			Tok: token.ASSIGN,
+			Rhs: []ast.Expr{ast.NewIdent(result)},
+		},
+		&ast.ReturnStmt{
+			Results: []ast.Expr{ast.NewIdent("nil")},
+		},
+	)
+	return []ast.Stmt{
+		&ast.DeclStmt{
+			Decl: &ast.GenDecl{
+				Tok: token.VAR,
+				Specs: []ast.Spec{
+					&ast.ValueSpec{
+						Names: []*ast.Ident{ast.NewIdent(variable)},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("entities"),
+							Sel: ast.NewIdent(i.domain.GetMainModel().Name),
+						},
+					},
+				},
+			},
+		},
+		i.withTx(stmts, empty),
+		&ast.ReturnStmt{
+			Results: []ast.Expr{
+				ast.NewIdent(variable),
+				ast.NewIdent("nil"),
+			},
+		},
+	}
+}
+
+// deleteBody runs the service and the event producer in one transaction.
+func (i UseCaseGenerator) deleteBody() []ast.Stmt {
+	stmts := []ast.Stmt{
+		&ast.IfStmt{
+			Init: &ast.AssignStmt{
+				Lhs: []ast.Expr{ast.NewIdent("err")},
+				Tok: token.DEFINE,
+				Rhs: []ast.Expr{i.serviceCall("Delete", ast.NewIdent("id"))},
+			},
+			Cond: errNotNil(),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{Results: []ast.Expr{ast.NewIdent("err")}},
+				},
+			},
+		},
+	}
+	if i.domain.EventProducerEnabled() {
+		stmts = append(stmts, i.eventStmt("Deleted", ast.NewIdent("id")))
+	}
+	stmts = append(stmts, &ast.ReturnStmt{
+		Results: []ast.Expr{ast.NewIdent("nil")},
+	})
+	return []ast.Stmt{
+		i.withTx(stmts),
+		&ast.ReturnStmt{
+			Results: []ast.Expr{ast.NewIdent("nil")},
+		},
+	}
+}
+
+// withTx wraps stmts into u.dtxManager.WithTx, the transaction is committed when they return nil and rolled back
+// otherwise. failure is returned next to the error.
+func (i UseCaseGenerator) withTx(stmts []ast.Stmt, failure ...ast.Expr) *ast.IfStmt {
+	return &ast.IfStmt{
+		Init: &ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X: &ast.SelectorExpr{
+							X:   ast.NewIdent("u"),
+							Sel: ast.NewIdent("dtxManager"),
+						},
+						Sel: ast.NewIdent("WithTx"),
+					},
+					Args: []ast.Expr{
+						ast.NewIdent("ctx"),
+						ast.NewIdent("nil"),
+						&ast.FuncLit{
+							Type: &ast.FuncType{
+								Params: &ast.FieldList{
+									List: []*ast.Field{
+										{
+											Names: []*ast.Ident{ast.NewIdent("ctx")},
+											Type: &ast.SelectorExpr{
+												X:   ast.NewIdent("context"),
+												Sel: ast.NewIdent("Context"),
+											},
+										},
+										{
+											Names: []*ast.Ident{ast.NewIdent("tx")},
+											Type: &ast.SelectorExpr{
+												X:   ast.NewIdent("dtx"),
+												Sel: ast.NewIdent("TX"),
+											},
+										},
+									},
+								},
+								Results: &ast.FieldList{
+									List: []*ast.Field{
+										{Type: ast.NewIdent("error")},
+									},
+								},
+							},
+							Body: &ast.BlockStmt{
+								List: stmts,
+							},
+						},
+					},
+				},
+			},
+		},
+		Cond: errNotNil(),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: append(failure, ast.NewIdent("err")),
+				},
+			},
+		},
+	}
+}
+
+func (i UseCaseGenerator) serviceCall(method string, argument ast.Expr) *ast.CallExpr {
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent("u"),
+				Sel: ast.NewIdent(i.domain.GetServicePrivateVariableName()),
+			},
+			Sel: ast.NewIdent(method),
+		},
+		Args: []ast.Expr{
+			ast.NewIdent("ctx"),
+			ast.NewIdent("tx"),
+			argument,
+		},
+	}
+}
+
+func (i UseCaseGenerator) eventStmt(event string, argument ast.Expr) ast.Stmt {
+	return &ast.IfStmt{
+		Init: &ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{
+				&ast.CallExpr{
+					Fun: &ast.SelectorExpr{
+						X: &ast.SelectorExpr{
+							X:   ast.NewIdent("u"),
+							Sel: ast.NewIdent(i.domain.GetEventProducerPrivateVariableName()),
+						},
+						Sel: ast.NewIdent(event),
+					},
+					Args: []ast.Expr{
+						ast.NewIdent("ctx"),
+						ast.NewIdent("tx"),
+						argument,
+					},
+				},
+			},
+		},
+		Cond: errNotNil(),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{Results: []ast.Expr{ast.NewIdent("err")}},
+			},
+		},
+	}
+}
+
+func returnOnError() ast.Stmt {
+	return &ast.IfStmt{
+		Cond: errNotNil(),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{Results: []ast.Expr{ast.NewIdent("err")}},
+			},
+		},
+	}
+}
+
+func errNotNil() ast.Expr {
+	return &ast.BinaryExpr{
+		X:  ast.NewIdent("err"),
+		Op: token.NEQ,
+		Y:  ast.NewIdent("nil"),
+	}
+}
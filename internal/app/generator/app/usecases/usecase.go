@@ -70,7 +70,7 @@ func (i UseCaseGenerator) structure() *ast.TypeSpec {
 			Type:  ast.NewIdent(i.domain.GetServiceInterfaceName()),
 		},
 	}
-	if i.domain.AppConfig.ProjectConfig.KafkaEnabled {
+	if i.domain.EventProducerEnabled() {
 		fields = append(fields, &ast.Field{
 			Names: []*ast.Ident{ast.NewIdent(i.domain.GetEventProducerPrivateVariableName())},
 			Type:  ast.NewIdent(i.domain.EventProducerInterfaceName()),
@@ -128,7 +128,7 @@ func (i UseCaseGenerator) constructor() *ast.FuncDecl {
 			Type:  ast.NewIdent(i.domain.GetServiceInterfaceName()),
 		},
 	}
-	if i.domain.AppConfig.ProjectConfig.KafkaEnabled {
+	if i.domain.EventProducerEnabled() {
 		fields = append(fields, &ast.Field{
 			Names: []*ast.Ident{ast.NewIdent(i.domain.GetEventProducerPrivateVariableName())},
 			Type:  ast.NewIdent(i.domain.EventProducerInterfaceName()),
@@ -147,7 +147,7 @@ func (i UseCaseGenerator) constructor() *ast.FuncDecl {
 			Value: ast.NewIdent(i.domain.GetServicePrivateVariableName()),
 		},
 	}
-	if i.domain.AppConfig.ProjectConfig.KafkaEnabled {
+	if i.domain.EventProducerEnabled() {
 		exprs = append(exprs, &ast.KeyValueExpr{
 			Key:   ast.NewIdent(i.domain.GetEventProducerPrivateVariableName()),
 			Value: ast.NewIdent(i.domain.GetEventProducerPrivateVariableName()),
@@ -219,346 +219,7 @@ func (i UseCaseGenerator) syncConstructor() error {
 }
 
 func (i UseCaseGenerator) createMethod() *ast.FuncDecl {
-	var body []ast.Stmt
-	body = append(body,
-		&ast.AssignStmt{
-			Lhs: []ast.Expr{
-				&ast.Ident{
-					Name: "logger",
-				},
-			},
-			Tok: token.DEFINE,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X: &ast.SelectorExpr{
-							X: &ast.Ident{
-								Name: "u",
-							},
-							Sel: &ast.Ident{
-								Name: "logger",
-							},
-						},
-						Sel: &ast.Ident{
-							Name: "WithContext",
-						},
-					},
-					Args: []ast.Expr{
-						&ast.Ident{
-							Name: "ctx",
-						},
-					},
-				},
-			},
-		},
-		&ast.AssignStmt{
-			Lhs: []ast.Expr{
-				&ast.Ident{
-					Name: "tx",
-				},
-			},
-			Tok: token.DEFINE,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X: &ast.SelectorExpr{
-							X: &ast.Ident{
-								Name: "u",
-							},
-							Sel: &ast.Ident{
-								Name: "dtxManager",
-							},
-						},
-						Sel: &ast.Ident{
-							Name: "NewTx",
-						},
-					},
-				},
-			},
-		},
-		&ast.DeferStmt{
-			Call: &ast.CallExpr{
-				Fun: &ast.FuncLit{
-					Type: &ast.FuncType{
-						Params: &ast.FieldList{
-							List: []*ast.Field{
-								{
-									Names: []*ast.Ident{
-										{
-											Name: "tx",
-										},
-									},
-									Type: &ast.SelectorExpr{
-										X: &ast.Ident{
-											Name: "dtx",
-										},
-										Sel: &ast.Ident{
-											Name: "TX",
-										},
-									},
-								},
-							},
-						},
-					},
-					Body: &ast.BlockStmt{
-						List: []ast.Stmt{
-							&ast.IfStmt{
-								Init: &ast.AssignStmt{
-									Lhs: []ast.Expr{
-										&ast.Ident{
-											Name: "err",
-										},
-									},
-									Tok: token.DEFINE,
-									Rhs: []ast.Expr{
-										&ast.CallExpr{
-											Fun: &ast.SelectorExpr{
-												X: &ast.Ident{
-													Name: "tx",
-												},
-												Sel: &ast.Ident{
-													Name: "Rollback",
-												},
-											},
-										},
-									},
-								},
-								Cond: &ast.BinaryExpr{
-									X: &ast.Ident{
-										Name: "err",
-									},
-									Op: token.NEQ,
-									Y: &ast.Ident{
-										Name: "nil",
-									},
-								},
-								Body: &ast.BlockStmt{
-									List: []ast.Stmt{
-										&ast.ExprStmt{
-											X: &ast.CallExpr{
-												Fun: &ast.SelectorExpr{
-													X: &ast.Ident{
-														Name: "logger",
-													},
-													Sel: &ast.Ident{
-														Name: "Error",
-													},
-												},
-												Args: []ast.Expr{
-													&ast.BasicLit{
-														Kind:  token.STRING,
-														Value: "\"cant rollback transaction\"",
-													},
-													&ast.CallExpr{
-														Fun: &ast.SelectorExpr{
-															X: &ast.Ident{
-																Name: "log",
-															},
-															Sel: &ast.Ident{
-																Name: "Error",
-															},
-														},
-														Args: []ast.Expr{
-															&ast.Ident{
-																Name: "err",
-															},
-														},
-													},
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-				Args: []ast.Expr{
-					&ast.Ident{
-						Name: "tx",
-					},
-				},
-			},
-		},
-		&ast.AssignStmt{
-			Lhs: []ast.Expr{
-				ast.NewIdent(i.domain.GetOneVariableName()),
-				ast.NewIdent("err"),
-			},
-			Tok: token.DEFINE,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X: &ast.SelectorExpr{
-							X:   ast.NewIdent("u"),
-							Sel: ast.NewIdent(i.domain.GetServicePrivateVariableName()),
-						},
-						Sel: ast.NewIdent("Create"),
-					},
-					Args: []ast.Expr{
-						ast.NewIdent("ctx"),
-						ast.NewIdent("tx"),
-						ast.NewIdent("create"),
-					},
-				},
-			},
-		},
-		// Check error
-		&ast.IfStmt{
-			Init: nil,
-			Cond: &ast.BinaryExpr{
-				X:  ast.NewIdent("err"),
-				Op: token.NEQ,
-				Y:  ast.NewIdent("nil"),
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: []ast.Expr{
-							&ast.CompositeLit{
-								Type: &ast.SelectorExpr{
-									X:   ast.NewIdent("entities"),
-									Sel: ast.NewIdent(i.domain.GetMainModel().Name),
-								},
-							},
-							ast.NewIdent("err"),
-						},
-					},
-				},
-			},
-			Else: nil,
-		},
-	)
-	if i.domain.AppConfig.ProjectConfig.KafkaEnabled {
-		body = append(body, &ast.IfStmt{
-			Init: &ast.AssignStmt{
-				Lhs: []ast.Expr{
-					&ast.Ident{
-						Name: "err",
-					},
-				},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X: &ast.SelectorExpr{
-								X: &ast.Ident{
-									Name: "u",
-								},
-								Sel: &ast.Ident{
-									Name: i.domain.GetEventProducerPrivateVariableName(),
-								},
-							},
-							Sel: &ast.Ident{
-								Name: "Created",
-							},
-						},
-						Args: []ast.Expr{
-							&ast.Ident{
-								Name: "ctx",
-							},
-							ast.NewIdent("tx"),
-							&ast.Ident{
-								Name: i.domain.GetOneVariableName(),
-							},
-						},
-					},
-				},
-			},
-			Cond: &ast.BinaryExpr{
-				X: &ast.Ident{
-					Name: "err",
-				},
-				Op: token.NEQ,
-				Y: &ast.Ident{
-					Name: "nil",
-				},
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: []ast.Expr{
-							&ast.CompositeLit{
-								Type: &ast.SelectorExpr{
-									X: &ast.Ident{
-										Name: "entities",
-									},
-									Sel: &ast.Ident{
-										Name: i.domain.GetMainModel().Name,
-									},
-								},
-							},
-							&ast.Ident{
-								Name: "err",
-							},
-						},
-					},
-				},
-			},
-		})
-	}
-	body = append(body,
-		// Commit transaction
-		&ast.IfStmt{
-			Init: &ast.AssignStmt{
-				Lhs: []ast.Expr{
-					&ast.Ident{
-						Name: "err",
-					},
-				},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X: &ast.Ident{
-								Name: "tx",
-							},
-							Sel: &ast.Ident{
-								Name: "Commit",
-							},
-						},
-					},
-				},
-			},
-			Cond: &ast.BinaryExpr{
-				X: &ast.Ident{
-					Name: "err",
-				},
-				Op: token.NEQ,
-				Y: &ast.Ident{
-					Name: "nil",
-				},
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: []ast.Expr{
-							&ast.CompositeLit{
-								Type: &ast.SelectorExpr{
-									X: &ast.Ident{
-										Name: "entities",
-									},
-									Sel: &ast.Ident{
-										Name: i.domain.GetMainModel().Name,
-									},
-								},
-							},
-							&ast.Ident{
-								Name: "err",
-							},
-						},
-					},
-				},
-			},
-		},
-		// Return created model and nil error
-		&ast.ReturnStmt{
-			Results: []ast.Expr{
-				ast.NewIdent(i.domain.GetOneVariableName()),
-				ast.NewIdent("nil"),
-			},
-		},
-	)
+	body := i.createBody()
 	return &ast.FuncDecl{
 		Recv: &ast.FieldList{
 			List: []*ast.Field{
@@ -621,6 +282,7 @@ func (i UseCaseGenerator) syncCreateMethod() error {
 	method, methodExist := astfile.FindFunc(file, "Create")
 	if method == nil {
 		method = i.createMethod()
+		astfile.TraceFunc(method, i.domain.SpanName("usecase", "Create"))
 	}
 	if !methodExist {
 		file.Decls = append(file.Decls, method)
@@ -760,6 +422,7 @@ func (i UseCaseGenerator) syncListMethod() error {
 	method, methodExist := astfile.FindFunc(file, "List")
 	if method == nil {
 		method = i.astListMethod()
+		astfile.TraceFunc(method, i.domain.SpanName("usecase", "List"))
 	}
 	if !methodExist {
 		file.Decls = append(file.Decls, method)
@@ -899,6 +562,7 @@ func (i UseCaseGenerator) syncGetMethod() error {
 	method, methodExist := astfile.FindFunc(file, "Get")
 	if method == nil {
 		method = i.astGetMethod()
+		astfile.TraceFunc(method, i.domain.SpanName("usecase", "Get"))
 	}
 	if !methodExist {
 		file.Decls = append(file.Decls, method)
@@ -914,350 +578,7 @@ func (i UseCaseGenerator) syncGetMethod() error {
 }
 
 func (i UseCaseGenerator) updateMethod() *ast.FuncDecl {
-	var body []ast.Stmt
-	body = append(body,
-		// Setup logger
-		&ast.AssignStmt{
-			Lhs: []ast.Expr{
-				&ast.Ident{
-					Name: "logger",
-				},
-			},
-			Tok: token.DEFINE,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X: &ast.SelectorExpr{
-							X: &ast.Ident{
-								Name: "u",
-							},
-							Sel: &ast.Ident{
-								Name: "logger",
-							},
-						},
-						Sel: &ast.Ident{
-							Name: "WithContext",
-						},
-					},
-					Args: []ast.Expr{
-						&ast.Ident{
-							Name: "ctx",
-						},
-					},
-				},
-			},
-		},
-		// Begin transaction
-		&ast.AssignStmt{
-			Lhs: []ast.Expr{
-				&ast.Ident{
-					Name: "tx",
-				},
-			},
-			Tok: token.DEFINE,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X: &ast.SelectorExpr{
-							X: &ast.Ident{
-								Name: "u",
-							},
-							Sel: &ast.Ident{
-								Name: "dtxManager",
-							},
-						},
-						Sel: &ast.Ident{
-							Name: "NewTx",
-						},
-					},
-				},
-			},
-		},
-		// Defer rollback transaction
-		&ast.DeferStmt{
-			Call: &ast.CallExpr{
-				Fun: &ast.FuncLit{
-					Type: &ast.FuncType{
-						Params: &ast.FieldList{
-							List: []*ast.Field{
-								{
-									Names: []*ast.Ident{
-										{
-											Name: "tx",
-										},
-									},
-									Type: &ast.SelectorExpr{
-										X: &ast.Ident{
-											Name: "dtx",
-										},
-										Sel: &ast.Ident{
-											Name: "TX",
-										},
-									},
-								},
-							},
-						},
-					},
-					Body: &ast.BlockStmt{
-						List: []ast.Stmt{
-							&ast.IfStmt{
-								Init: &ast.AssignStmt{
-									Lhs: []ast.Expr{
-										&ast.Ident{
-											Name: "err",
-										},
-									},
-									Tok: token.DEFINE,
-									Rhs: []ast.Expr{
-										&ast.CallExpr{
-											Fun: &ast.SelectorExpr{
-												X: &ast.Ident{
-													Name: "tx",
-												},
-												Sel: &ast.Ident{
-													Name: "Rollback",
-												},
-											},
-										},
-									},
-								},
-								Cond: &ast.BinaryExpr{
-									X: &ast.Ident{
-										Name: "err",
-									},
-									Op: token.NEQ,
-									Y: &ast.Ident{
-										Name: "nil",
-									},
-								},
-								Body: &ast.BlockStmt{
-									List: []ast.Stmt{
-										&ast.ExprStmt{
-											X: &ast.CallExpr{
-												Fun: &ast.SelectorExpr{
-													X: &ast.Ident{
-														Name: "logger",
-													},
-													Sel: &ast.Ident{
-														Name: "Error",
-													},
-												},
-												Args: []ast.Expr{
-													&ast.BasicLit{
-														Kind:  token.STRING,
-														Value: "\"cant rollback transaction\"",
-													},
-													&ast.CallExpr{
-														Fun: &ast.SelectorExpr{
-															X: &ast.Ident{
-																Name: "log",
-															},
-															Sel: &ast.Ident{
-																Name: "Error",
-															},
-														},
-														Args: []ast.Expr{
-															&ast.Ident{
-																Name: "err",
-															},
-														},
-													},
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-				Args: []ast.Expr{
-					&ast.Ident{
-						Name: "tx",
-					},
-				},
-			},
-		},
-		// Try to update model at use case
-		&ast.AssignStmt{
-			Lhs: []ast.Expr{
-				ast.NewIdent(i.domain.GetOneVariableName()),
-				ast.NewIdent("err"),
-			},
-			Tok: token.DEFINE,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X: &ast.SelectorExpr{
-							X:   ast.NewIdent("u"),
-							Sel: ast.NewIdent(i.domain.GetServicePrivateVariableName()),
-						},
-						Sel: ast.NewIdent("Update"),
-					},
-					Args: []ast.Expr{
-						ast.NewIdent("ctx"),
-						ast.NewIdent("tx"),
-						ast.NewIdent("update"),
-					},
-				},
-			},
-		},
-		// Check error
-		&ast.IfStmt{
-			Init: nil,
-			Cond: &ast.BinaryExpr{
-				X:  ast.NewIdent("err"),
-				Op: token.NEQ,
-				Y:  ast.NewIdent("nil"),
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: []ast.Expr{
-							&ast.CompositeLit{
-								Type: &ast.SelectorExpr{
-									X:   ast.NewIdent("entities"),
-									Sel: ast.NewIdent(i.domain.GetMainModel().Name),
-								},
-							},
-							ast.NewIdent("err"),
-						},
-					},
-				},
-			},
-			Else: nil,
-		},
-	)
-	if i.domain.AppConfig.ProjectConfig.KafkaEnabled {
-		body = append(body, &ast.IfStmt{
-			Init: &ast.AssignStmt{
-				Lhs: []ast.Expr{
-					&ast.Ident{
-						Name: "err",
-					},
-				},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X: &ast.SelectorExpr{
-								X: &ast.Ident{
-									Name: "u",
-								},
-								Sel: &ast.Ident{
-									Name: i.domain.GetEventProducerPrivateVariableName(),
-								},
-							},
-							Sel: &ast.Ident{
-								Name: "Updated",
-							},
-						},
-						Args: []ast.Expr{
-							&ast.Ident{
-								Name: "ctx",
-							},
-							ast.NewIdent("tx"),
-							&ast.Ident{
-								Name: i.domain.GetOneVariableName(),
-							},
-						},
-					},
-				},
-			},
-			Cond: &ast.BinaryExpr{
-				X: &ast.Ident{
-					Name: "err",
-				},
-				Op: token.NEQ,
-				Y: &ast.Ident{
-					Name: "nil",
-				},
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: []ast.Expr{
-							&ast.CompositeLit{
-								Type: &ast.SelectorExpr{
-									X: &ast.Ident{
-										Name: "entities",
-									},
-									Sel: &ast.Ident{
-										Name: i.domain.GetMainModel().Name,
-									},
-								},
-							},
-							&ast.Ident{
-								Name: "err",
-							},
-						},
-					},
-				},
-			},
-		})
-	}
-	body = append(body,
-		// Commit transaction
-		&ast.IfStmt{
-			Init: &ast.AssignStmt{
-				Lhs: []ast.Expr{
-					&ast.Ident{
-						Name: "err",
-					},
-				},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X: &ast.Ident{
-								Name: "tx",
-							},
-							Sel: &ast.Ident{
-								Name: "Commit",
-							},
-						},
-					},
-				},
-			},
-			Cond: &ast.BinaryExpr{
-				X: &ast.Ident{
-					Name: "err",
-				},
-				Op: token.NEQ,
-				Y: &ast.Ident{
-					Name: "nil",
-				},
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: []ast.Expr{
-							&ast.CompositeLit{
-								Type: &ast.SelectorExpr{
-									X: &ast.Ident{
-										Name: "entities",
-									},
-									Sel: &ast.Ident{
-										Name: i.domain.GetMainModel().Name,
-									},
-								},
-							},
-							&ast.Ident{
-								Name: "err",
-							},
-						},
-					},
-				},
-			},
-		},
-		// Return created model and nil error
-		&ast.ReturnStmt{
-			Results: []ast.Expr{
-				ast.NewIdent(i.domain.GetOneVariableName()),
-				ast.NewIdent("nil"),
-			},
-		},
-	)
+	body := i.updateBody()
 	return &ast.FuncDecl{
 		Recv: &ast.FieldList{
 			List: []*ast.Field{
@@ -1320,6 +641,7 @@ func (i UseCaseGenerator) syncUpdateMethod() error {
 	method, methodExist := astfile.FindFunc(file, "Update")
 	if method == nil {
 		method = i.updateMethod()
+		astfile.TraceFunc(method, i.domain.SpanName("usecase", "Update"))
 	}
 	if !methodExist {
 		file.Decls = append(file.Decls, method)
@@ -1335,319 +657,7 @@ func (i UseCaseGenerator) syncUpdateMethod() error {
 }
 
 func (i UseCaseGenerator) deleteMethod() *ast.FuncDecl {
-	var body []ast.Stmt
-	body = append(body,
-		// Setup logger
-		&ast.AssignStmt{
-			Lhs: []ast.Expr{
-				&ast.Ident{
-					Name: "logger",
-				},
-			},
-			Tok: token.DEFINE,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X: &ast.SelectorExpr{
-							X: &ast.Ident{
-								Name: "u",
-							},
-							Sel: &ast.Ident{
-								Name: "logger",
-							},
-						},
-						Sel: &ast.Ident{
-							Name: "WithContext",
-						},
-					},
-					Args: []ast.Expr{
-						&ast.Ident{
-							Name: "ctx",
-						},
-					},
-				},
-			},
-		},
-		// Begin transaction
-		&ast.AssignStmt{
-			Lhs: []ast.Expr{
-				&ast.Ident{
-					Name: "tx",
-				},
-			},
-			Tok: token.DEFINE,
-			Rhs: []ast.Expr{
-				&ast.CallExpr{
-					Fun: &ast.SelectorExpr{
-						X: &ast.SelectorExpr{
-							X: &ast.Ident{
-								Name: "u",
-							},
-							Sel: &ast.Ident{
-								Name: "dtxManager",
-							},
-						},
-						Sel: &ast.Ident{
-							Name: "NewTx",
-						},
-					},
-				},
-			},
-		},
-		// Defer rollback transaction
-		&ast.DeferStmt{
-			Call: &ast.CallExpr{
-				Fun: &ast.FuncLit{
-					Type: &ast.FuncType{
-						Params: &ast.FieldList{
-							List: []*ast.Field{
-								{
-									Names: []*ast.Ident{
-										{
-											Name: "tx",
-										},
-									},
-									Type: &ast.SelectorExpr{
-										X: &ast.Ident{
-											Name: "dtx",
-										},
-										Sel: &ast.Ident{
-											Name: "TX",
-										},
-									},
-								},
-							},
-						},
-					},
-					Body: &ast.BlockStmt{
-						List: []ast.Stmt{
-							&ast.IfStmt{
-								Init: &ast.AssignStmt{
-									Lhs: []ast.Expr{
-										&ast.Ident{
-											Name: "err",
-										},
-									},
-									Tok: token.DEFINE,
-									Rhs: []ast.Expr{
-										&ast.CallExpr{
-											Fun: &ast.SelectorExpr{
-												X: &ast.Ident{
-													Name: "tx",
-												},
-												Sel: &ast.Ident{
-													Name: "Rollback",
-												},
-											},
-										},
-									},
-								},
-								Cond: &ast.BinaryExpr{
-									X: &ast.Ident{
-										Name: "err",
-									},
-									Op: token.NEQ,
-									Y: &ast.Ident{
-										Name: "nil",
-									},
-								},
-								Body: &ast.BlockStmt{
-									List: []ast.Stmt{
-										&ast.ExprStmt{
-											X: &ast.CallExpr{
-												Fun: &ast.SelectorExpr{
-													X: &ast.Ident{
-														Name: "logger",
-													},
-													Sel: &ast.Ident{
-														Name: "Error",
-													},
-												},
-												Args: []ast.Expr{
-													&ast.BasicLit{
-														Kind:  token.STRING,
-														Value: "\"cant rollback transaction\"",
-													},
-													&ast.CallExpr{
-														Fun: &ast.SelectorExpr{
-															X: &ast.Ident{
-																Name: "log",
-															},
-															Sel: &ast.Ident{
-																Name: "Error",
-															},
-														},
-														Args: []ast.Expr{
-															&ast.Ident{
-																Name: "err",
-															},
-														},
-													},
-												},
-											},
-										},
-									},
-								},
-							},
-						},
-					},
-				},
-				Args: []ast.Expr{
-					&ast.Ident{
-						Name: "tx",
-					},
-				},
-			},
-		},
-		// Try to delete model at use case
-		&ast.IfStmt{
-			Init: &ast.AssignStmt{
-				Lhs: []ast.Expr{
-					ast.NewIdent("err"),
-				},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X: &ast.SelectorExpr{
-								X:   ast.NewIdent("u"),
-								Sel: ast.NewIdent(i.domain.GetServicePrivateVariableName()),
-							},
-							Sel: ast.NewIdent("Delete"),
-						},
-						Args: []ast.Expr{
-							ast.NewIdent("ctx"),
-							ast.NewIdent("tx"),
-							ast.NewIdent("id"),
-						},
-					},
-				},
-			},
-			Cond: &ast.BinaryExpr{
-				X:  ast.NewIdent("err"),
-				Op: token.NEQ,
-				Y:  ast.NewIdent("nil"),
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: []ast.Expr{
-							ast.NewIdent("err"),
-						},
-					},
-				},
-			},
-		},
-	)
-	if i.domain.AppConfig.ProjectConfig.KafkaEnabled {
-		body = append(body, &ast.IfStmt{
-			Init: &ast.AssignStmt{
-				Lhs: []ast.Expr{
-					&ast.Ident{
-						Name: "err",
-					},
-				},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X: &ast.SelectorExpr{
-								X: &ast.Ident{
-									Name: "u",
-								},
-								Sel: &ast.Ident{
-									Name: i.domain.GetEventProducerPrivateVariableName(),
-								},
-							},
-							Sel: &ast.Ident{
-								Name: "Deleted",
-							},
-						},
-						Args: []ast.Expr{
-							&ast.Ident{
-								Name: "ctx",
-							},
-							ast.NewIdent("tx"),
-							&ast.Ident{
-								Name: "id",
-							},
-						},
-					},
-				},
-			},
-			Cond: &ast.BinaryExpr{
-				X: &ast.Ident{
-					Name: "err",
-				},
-				Op: token.NEQ,
-				Y: &ast.Ident{
-					Name: "nil",
-				},
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: []ast.Expr{
-							&ast.Ident{
-								Name: "err",
-							},
-						},
-					},
-				},
-			},
-		})
-	}
-	body = append(body,
-		// Commit transaction
-		&ast.IfStmt{
-			Init: &ast.AssignStmt{
-				Lhs: []ast.Expr{
-					&ast.Ident{
-						Name: "err",
-					},
-				},
-				Tok: token.DEFINE,
-				Rhs: []ast.Expr{
-					&ast.CallExpr{
-						Fun: &ast.SelectorExpr{
-							X: &ast.Ident{
-								Name: "tx",
-							},
-							Sel: &ast.Ident{
-								Name: "Commit",
-							},
-						},
-					},
-				},
-			},
-			Cond: &ast.BinaryExpr{
-				X: &ast.Ident{
-					Name: "err",
-				},
-				Op: token.NEQ,
-				Y: &ast.Ident{
-					Name: "nil",
-				},
-			},
-			Body: &ast.BlockStmt{
-				List: []ast.Stmt{
-					&ast.ReturnStmt{
-						Results: []ast.Expr{
-							&ast.Ident{
-								Name: "err",
-							},
-						},
-					},
-				},
-			},
-		},
-		// Return created model and nil error
-		&ast.ReturnStmt{
-			Results: []ast.Expr{
-				ast.NewIdent("nil"),
-			},
-		},
-	)
+	body := i.deleteBody()
 	return &ast.FuncDecl{
 		Recv: &ast.FieldList{
 			List: []*ast.Field{
@@ -1704,6 +714,7 @@ func (i UseCaseGenerator) syncDeleteMethod() error {
 	method, methodExist := astfile.FindFunc(file, "Delete")
 	if method == nil {
 		method = i.deleteMethod()
+		astfile.TraceFunc(method, i.domain.SpanName("usecase", "Delete"))
 	}
 	if !methodExist {
 		file.Decls = append(file.Decls, method)
@@ -1739,6 +750,12 @@ func (i UseCaseGenerator) file() *ast.File {
 				Value: i.domain.AppConfig.ProjectConfig.UUIDImportPath(),
 			},
 		},
+		&ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: i.domain.AppConfig.ProjectConfig.TracingImportPath(),
+			},
+		},
 	}
 	return &ast.File{
 		Name: ast.NewIdent("usecases"),
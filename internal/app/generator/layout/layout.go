@@ -25,6 +25,9 @@ func (g *Generator) Sync() error {
 	if g.project.GRPCEnabled {
 		generators = append(generators, NewBufGenerator(g.project))
 	}
+	if g.project.HTTPEnabled && g.project.TypeScriptEnabled {
+		generators = append(generators, NewTypeScriptGenerator(g.project))
+	}
 	for _, g := range generators {
 		if err := g.Sync(); err != nil {
 			return err
@@ -0,0 +1,32 @@
+package layout
+
+import (
+	"path"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+type TypeScriptGenerator struct {
+	project *configs.Project
+}
+
+func NewTypeScriptGenerator(project *configs.Project) *TypeScriptGenerator {
+	return &TypeScriptGenerator{project: project}
+}
+
+func (g *TypeScriptGenerator) Sync() error {
+	files := []*tmpl.Template{
+		{
+			SourcePath:      "templates/clients/ts/http.ts.tmpl",
+			DestinationPath: path.Join(destinationPath, "clients", "ts", "http.ts"),
+			Name:            "typescript http client",
+		},
+	}
+	for _, file := range files {
+		if err := file.RenderToFile(g.project); err != nil {
+			return err
+		}
+	}
+	return nil
+}
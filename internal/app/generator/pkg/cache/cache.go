@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"path"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+var destinationPath = "."
+
+type Generator struct {
+	project *configs.Project
+}
+
+func NewGenerator(project *configs.Project) *Generator {
+	return &Generator{project: project}
+}
+
+func (g *Generator) Sync() error {
+	files := []*tmpl.Template{
+		{
+			SourcePath:      "templates/internal/pkg/cache/cache.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "cache", "cache.go"),
+			Name:            "cache",
+		},
+		{
+			SourcePath:      "templates/internal/pkg/cache/lru.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "cache", "lru.go"),
+			Name:            "lru cache",
+		},
+		{
+			SourcePath:      "templates/internal/pkg/cache/redis.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "cache", "redis.go"),
+			Name:            "redis cache",
+		},
+		{
+			SourcePath: "templates/internal/pkg/cache/cache_test.go.tmpl",
+			DestinationPath: path.Join(
+				destinationPath,
+				"internal",
+				"pkg",
+				"cache",
+				"cache_test.go",
+			),
+			Name: "cache tests",
+		},
+	}
+	for _, file := range files {
+		if err := file.RenderToFile(g.project); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -52,7 +52,7 @@ func (f Generator) file() *ast.File {
 		&ast.ImportSpec{
 			Path: &ast.BasicLit{
 				Kind:  token.STRING,
-				Value: f.project.PostgresImportPath(),
+				Value: f.project.DatabaseImportPath(),
 			},
 		},
 
@@ -99,6 +99,18 @@ func (f Generator) file() *ast.File {
 				Value: f.project.ConfigsImportPath(),
 			},
 		},
+		&ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: f.project.HealthImportPath(),
+			},
+		},
+		&ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"github.com/jmoiron/sqlx"`,
+			},
+		},
 	}
 	if f.project.GRPCEnabled {
 		imports = append(imports, &ast.ImportSpec{
@@ -124,6 +136,14 @@ func (f Generator) file() *ast.File {
 			},
 		})
 	}
+	if f.project.GatewayEnabled {
+		imports = append(imports, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"`,
+			},
+		})
+	}
 	if f.project.UptraceEnabled {
 		imports = append(imports, &ast.ImportSpec{
 			Path: &ast.BasicLit{
@@ -132,6 +152,46 @@ func (f Generator) file() *ast.File {
 			},
 		})
 	}
+	if f.project.OTLPEnabled() {
+		imports = append(imports, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: f.project.TracingImportPath(),
+			},
+		})
+	}
+	if f.project.PrometheusEnabled() {
+		imports = append(imports, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: f.project.MetricsImportPath(),
+			},
+		})
+	}
+	if f.project.CacheEnabled() {
+		imports = append(imports, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: f.project.CacheImportPath(),
+			},
+		})
+	}
+	if f.project.MongoEnabled() {
+		imports = append(imports, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: f.project.MongoImportPath(),
+			},
+		})
+	}
+	if f.project.RateLimitEnabled {
+		imports = append(imports, &ast.ImportSpec{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: f.project.RateLimitImportPath(),
+			},
+		})
+	}
 	for _, modelConfig := range f.project.Apps {
 		imports = append(
 			imports,
@@ -201,7 +261,7 @@ func (f Generator) toProvide() []ast.Expr {
 						{
 							Type: &ast.StarExpr{
 								X: &ast.SelectorExpr{
-									X:   ast.NewIdent("postgres"),
+									X:   ast.NewIdent(f.project.Database),
 									Sel: ast.NewIdent("Config"),
 								},
 							},
@@ -223,11 +283,11 @@ func (f Generator) toProvide() []ast.Expr {
 			},
 		},
 		&ast.SelectorExpr{
-			X:   ast.NewIdent("postgres"),
+			X:   ast.NewIdent(f.project.Database),
 			Sel: ast.NewIdent("NewDatabase"),
 		},
 		&ast.SelectorExpr{
-			X:   ast.NewIdent("postgres"),
+			X:   ast.NewIdent(f.project.Database),
 			Sel: ast.NewIdent("NewMigrateManager"),
 		},
 		&ast.SelectorExpr{
@@ -300,6 +360,36 @@ func (f Generator) toProvide() []ast.Expr {
 			},
 		)
 	}
+	if f.project.OTLPEnabled() {
+		toProvide = append(toProvide, f.tracingProviders()...)
+	}
+	if f.project.PrometheusEnabled() {
+		toProvide = append(toProvide, f.metricsProviders()...)
+	}
+	if f.project.RateLimitEnabled {
+		toProvide = append(toProvide, f.rateLimitProviders()...)
+	}
+	if f.project.CacheEnabled() {
+		toProvide = append(
+			toProvide,
+			f.configProvider("cache", "Cache"),
+			&ast.SelectorExpr{
+				X:   ast.NewIdent("cache"),
+				Sel: ast.NewIdent("NewCache"),
+			},
+		)
+	}
+	if f.project.MongoEnabled() {
+		toProvide = append(
+			toProvide,
+			f.configProvider("mongo", "Mongo"),
+			&ast.SelectorExpr{
+				X:   ast.NewIdent("mongo"),
+				Sel: ast.NewIdent("NewDatabase"),
+			},
+		)
+	}
+	toProvide = append(toProvide, f.healthProviders()...)
 	for _, model := range f.project.Apps {
 		toProvide = append(
 			toProvide,
@@ -634,6 +724,21 @@ func (f Generator) astServerContainer() *ast.FuncDecl {
 			},
 		})
 	}
+	if f.project.OTLPEnabled() {
+		args = append(args, f.tracingInvoke())
+	}
+	if f.project.PrometheusEnabled() {
+		args = append(args, f.metricsInvokes()...)
+	}
+	if f.project.RateLimitEnabled {
+		args = append(args, f.rateLimitInvokes()...)
+	}
+	if f.project.CacheEnabled() {
+		args = append(args, f.cacheInvoke())
+	}
+	if f.project.MongoEnabled() {
+		args = append(args, f.mongoInvoke())
+	}
 	if f.project.KafkaEnabled {
 		for _, domain := range f.project.Apps {
 			args = append(args, &ast.CallExpr{
@@ -1768,6 +1873,9 @@ func (f Generator) astServerContainer() *ast.FuncDecl {
 				},
 			})
 		}
+		if f.project.GatewayEnabled {
+			args = append(args, f.gatewayInvokes()...)
+		}
 		args = append(args, &ast.CallExpr{
 			Fun: &ast.SelectorExpr{
 				X:   ast.NewIdent("fx"),
@@ -2006,6 +2114,7 @@ func (f Generator) astServerContainer() *ast.FuncDecl {
 			},
 		})
 	}
+	args = append(args, f.healthInvokes()...)
 	return &ast.FuncDecl{
 		Name: ast.NewIdent("NewServerContainer"),
 		Type: &ast.FuncType{
@@ -2059,345 +2168,282 @@ func (f Generator) astServerContainer() *ast.FuncDecl {
 	}
 }
 
-func (f Generator) syncServerContainer() error {
-	fileset := token.NewFileSet()
-	filename := path.Join("internal", "pkg", "containers", "fx.go")
-	file, err := parser.ParseFile(fileset, filename, nil, parser.ParseComments)
-	if err != nil {
-		return err
-	}
-	var functionExists bool
-	var function *ast.FuncDecl
-	ast.Inspect(file, func(node ast.Node) bool {
-		if t, ok := node.(*ast.FuncDecl); ok && t.Name.String() == "NewServerContainer" {
-			functionExists = true
-			function = t
-			return false
-		}
-		return true
-	})
-	if function == nil {
-		function = f.astServerContainer()
-	}
-	if !functionExists {
-		file.Decls = append(file.Decls, function)
-	}
-	buff := &bytes.Buffer{}
-	if err := printer.Fprint(buff, fileset, file); err != nil {
-		return err
-	}
-	if err := os.WriteFile(filename, buff.Bytes(), 0777); err != nil {
-		return err
+func (f Generator) gatewayInvokes() []ast.Expr {
+	exprs := []ast.Expr{
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("fx"),
+				Sel: ast.NewIdent("Provide"),
+			},
+			Args: []ast.Expr{
+				&ast.SelectorExpr{
+					X:   ast.NewIdent("http"),
+					Sel: ast.NewIdent("NewGatewayMux"),
+				},
+				&ast.SelectorExpr{
+					X:   ast.NewIdent("http"),
+					Sel: ast.NewIdent("NewGatewayEndpoint"),
+				},
+			},
+		},
 	}
-	return nil
-}
-
-func (f Generator) astMigrateContainer() *ast.FuncDecl {
-	return &ast.FuncDecl{
-		Name: ast.NewIdent("NewMigrateContainer"),
-		Type: &ast.FuncType{
-			Params: &ast.FieldList{
-				List: []*ast.Field{
-					{
-						Names: []*ast.Ident{
-							ast.NewIdent("config"),
+	for _, domain := range f.project.Apps {
+		exprs = append(exprs, &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("fx"),
+				Sel: ast.NewIdent("Invoke"),
+			},
+			Args: []ast.Expr{
+				&ast.FuncLit{
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("app"),
+									},
+									Type: &ast.StarExpr{
+										X: &ast.SelectorExpr{
+											X:   ast.NewIdent(domain.AppAlias()),
+											Sel: ast.NewIdent("App"),
+										},
+									},
+								},
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("mux"),
+									},
+									Type: &ast.StarExpr{
+										X: &ast.SelectorExpr{
+											X:   ast.NewIdent("runtime"),
+											Sel: ast.NewIdent("ServeMux"),
+										},
+									},
+								},
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("endpoint"),
+									},
+									Type: &ast.StarExpr{
+										X: &ast.SelectorExpr{
+											X:   ast.NewIdent("http"),
+											Sel: ast.NewIdent("GatewayEndpoint"),
+										},
+									},
+								},
+							},
+						},
+						Results: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: ast.NewIdent("error"),
+								},
+							},
 						},
-						Type: ast.NewIdent("string"),
 					},
-				},
-			},
-			Results: &ast.FieldList{
-				List: []*ast.Field{
-					{
-						Type: &ast.StarExpr{
-							X: &ast.SelectorExpr{
-								X:   ast.NewIdent("fx"),
-								Sel: ast.NewIdent("App"),
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									&ast.CallExpr{
+										Fun: &ast.SelectorExpr{
+											X:   ast.NewIdent("app"),
+											Sel: ast.NewIdent("RegisterGateway"),
+										},
+										Args: []ast.Expr{
+											ast.NewIdent("mux"),
+											ast.NewIdent("endpoint"),
+										},
+									},
+								},
 							},
 						},
 					},
 				},
 			},
+		})
+	}
+	exprs = append(exprs, &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("fx"),
+			Sel: ast.NewIdent("Invoke"),
 		},
-		Body: &ast.BlockStmt{
-			List: []ast.Stmt{
-				&ast.AssignStmt{
-					Lhs: []ast.Expr{
-						ast.NewIdent("app"),
-					},
-					Tok: token.DEFINE,
-					Rhs: []ast.Expr{
-						&ast.CallExpr{
-							Fun: &ast.SelectorExpr{
-								X:   ast.NewIdent("fx"),
-								Sel: ast.NewIdent("New"),
+		Args: []ast.Expr{
+			&ast.FuncLit{
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("server"),
+								},
+								Type: &ast.StarExpr{
+									X: &ast.SelectorExpr{
+										X:   ast.NewIdent("http"),
+										Sel: ast.NewIdent("Server"),
+									},
+								},
 							},
-							Args: []ast.Expr{
-								&ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X:   ast.NewIdent("fx"),
-										Sel: ast.NewIdent("Provide"),
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("mux"),
+								},
+								Type: &ast.StarExpr{
+									X: &ast.SelectorExpr{
+										X:   ast.NewIdent("runtime"),
+										Sel: ast.NewIdent("ServeMux"),
 									},
-									Args: []ast.Expr{
-										&ast.FuncLit{
-											Type: &ast.FuncType{
-												Params: &ast.FieldList{},
-												Results: &ast.FieldList{
-													List: []*ast.Field{
-														{
-															Type: ast.NewIdent("string"),
-														},
-													},
-												},
-											},
-											Body: &ast.BlockStmt{
-												List: []ast.Stmt{
-													&ast.ReturnStmt{
-														Results: []ast.Expr{
-															ast.NewIdent("config"),
-														},
-													},
-												},
-											},
+								},
+							},
+						},
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{
+							X: &ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("server"),
+									Sel: ast.NewIdent("Mount"),
+								},
+								Args: []ast.Expr{
+									&ast.SelectorExpr{
+										X:   ast.NewIdent("http"),
+										Sel: ast.NewIdent("GatewayPrefix"),
+									},
+									&ast.CallExpr{
+										Fun: &ast.SelectorExpr{
+											X:   ast.NewIdent("http"),
+											Sel: ast.NewIdent("NewGatewayHandler"),
+										},
+										Args: []ast.Expr{
+											ast.NewIdent("mux"),
 										},
 									},
 								},
-								ast.NewIdent("FXModule"),
-								&ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X:   ast.NewIdent("fx"),
-										Sel: ast.NewIdent("Invoke"),
-									},
-									Args: []ast.Expr{
-										&ast.FuncLit{
-											Type: &ast.FuncType{
-												Params: &ast.FieldList{
-													List: []*ast.Field{
-														{
-															Names: []*ast.Ident{
-																ast.NewIdent("lifecycle"),
-															},
-															Type: &ast.SelectorExpr{
-																X:   ast.NewIdent("fx"),
-																Sel: ast.NewIdent("Lifecycle"),
-															},
-														},
-														{
-															Names: []*ast.Ident{
-																ast.NewIdent("logger"),
-															},
-															Type: &ast.SelectorExpr{
-																X: &ast.Ident{
-																	Name: "log",
-																},
-																Sel: &ast.Ident{
-																	Name: "Logger",
-																},
-															},
-														},
-														{
-															Names: []*ast.Ident{
-																ast.NewIdent("manager"),
-															},
-															Type: &ast.StarExpr{
-																X: &ast.SelectorExpr{
-																	X: ast.NewIdent("postgres"),
-																	Sel: ast.NewIdent(
-																		"MigrateManager",
-																	),
-																},
-															},
-														},
-														{
-															Names: []*ast.Ident{
-																ast.NewIdent("shutdowner"),
-															},
-															Type: &ast.SelectorExpr{
-																X:   ast.NewIdent("fx"),
-																Sel: ast.NewIdent("Shutdowner"),
-															},
-														},
-													},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	return exprs
+}
+
+func (f Generator) tracingProviders() []ast.Expr {
+	return []ast.Expr{
+		&ast.FuncLit{
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{
+								ast.NewIdent("config"),
+							},
+							Type: &ast.StarExpr{
+								X: &ast.SelectorExpr{
+									X:   ast.NewIdent("configs"),
+									Sel: ast.NewIdent("Config"),
+								},
+							},
+						},
+					},
+				},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Type: &ast.StarExpr{
+								X: &ast.SelectorExpr{
+									X:   ast.NewIdent("tracing"),
+									Sel: ast.NewIdent("Config"),
+								},
+							},
+						},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							&ast.SelectorExpr{
+								X:   ast.NewIdent("config"),
+								Sel: ast.NewIdent("Tracing"),
+							},
+						},
+					},
+				},
+			},
+		},
+		&ast.SelectorExpr{
+			X:   ast.NewIdent("tracing"),
+			Sel: ast.NewIdent("NewProvider"),
+		},
+	}
+}
+
+func (f Generator) tracingInvoke() ast.Expr {
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("fx"),
+			Sel: ast.NewIdent("Invoke"),
+		},
+		Args: []ast.Expr{
+			&ast.FuncLit{
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("lifecycle"),
+								},
+								Type: &ast.SelectorExpr{
+									X:   ast.NewIdent("fx"),
+									Sel: ast.NewIdent("Lifecycle"),
+								},
+							},
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("provider"),
+								},
+								Type: &ast.StarExpr{
+									X: &ast.SelectorExpr{
+										X:   ast.NewIdent("tracing"),
+										Sel: ast.NewIdent("Provider"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{
+							X: &ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("lifecycle"),
+									Sel: ast.NewIdent("Append"),
+								},
+								Args: []ast.Expr{
+									&ast.CompositeLit{
+										Type: &ast.SelectorExpr{
+											X:   ast.NewIdent("fx"),
+											Sel: ast.NewIdent("Hook"),
+										},
+										Elts: []ast.Expr{
+											&ast.KeyValueExpr{
+												Key: ast.NewIdent("OnStart"),
+												Value: &ast.SelectorExpr{
+													X:   ast.NewIdent("provider"),
+													Sel: ast.NewIdent("Start"),
 												},
 											},
-											Body: &ast.BlockStmt{
-												List: []ast.Stmt{
-													&ast.ExprStmt{
-														X: &ast.CallExpr{
-															Fun: &ast.SelectorExpr{
-																X:   ast.NewIdent("lifecycle"),
-																Sel: ast.NewIdent("Append"),
-															},
-															Args: []ast.Expr{
-																&ast.CompositeLit{
-																	Type: &ast.SelectorExpr{
-																		X:   ast.NewIdent("fx"),
-																		Sel: ast.NewIdent("Hook"),
-																	},
-																	Elts: []ast.Expr{
-																		&ast.KeyValueExpr{
-																			Key: ast.NewIdent(
-																				"OnStart",
-																			),
-																			Value: &ast.FuncLit{
-																				Type: &ast.FuncType{
-																					Params: &ast.FieldList{
-																						List: []*ast.Field{
-																							{
-																								Names: []*ast.Ident{
-																									ast.NewIdent(
-																										"ctx",
-																									),
-																								},
-																								Type: &ast.SelectorExpr{
-																									X: ast.NewIdent(
-																										"context",
-																									),
-																									Sel: ast.NewIdent(
-																										"Context",
-																									),
-																								},
-																							},
-																						},
-																					},
-																					Results: &ast.FieldList{
-																						List: []*ast.Field{
-																							{
-																								Type: ast.NewIdent(
-																									"error",
-																								),
-																							},
-																						},
-																					},
-																				},
-																				Body: &ast.BlockStmt{
-																					List: []ast.Stmt{
-																						&ast.GoStmt{
-																							Call: &ast.CallExpr{
-																								Fun: &ast.FuncLit{
-																									Type: &ast.FuncType{
-																										Params: &ast.FieldList{},
-																									},
-																									Body: &ast.BlockStmt{
-																										List: []ast.Stmt{
-																											&ast.AssignStmt{
-																												Lhs: []ast.Expr{
-																													ast.NewIdent(
-																														"err",
-																													),
-																												},
-																												Tok: token.DEFINE,
-																												Rhs: []ast.Expr{
-																													&ast.CallExpr{
-																														Fun: &ast.SelectorExpr{
-																															X: ast.NewIdent(
-																																"manager",
-																															),
-																															Sel: ast.NewIdent(
-																																"Up",
-																															),
-																														},
-																														Args: []ast.Expr{
-																															ast.NewIdent(
-																																"ctx",
-																															),
-																														},
-																													},
-																												},
-																											},
-																											&ast.IfStmt{
-																												Cond: &ast.BinaryExpr{
-																													X: ast.NewIdent(
-																														"err",
-																													),
-																													Op: token.NEQ,
-																													Y: ast.NewIdent(
-																														"nil",
-																													),
-																												},
-																												Body: &ast.BlockStmt{
-																													List: []ast.Stmt{
-																														&ast.ExprStmt{
-																															X: &ast.CallExpr{
-																																Fun: &ast.SelectorExpr{
-																																	X: ast.NewIdent(
-																																		"logger",
-																																	),
-																																	Sel: ast.NewIdent(
-																																		"Error",
-																																	),
-																																},
-																																Args: []ast.Expr{
-																																	&ast.BasicLit{
-																																		Kind:  token.STRING,
-																																		Value: `"shutdown"`,
-																																	},
-																																	&ast.CallExpr{
-																																		Fun: &ast.SelectorExpr{
-																																			X: ast.NewIdent(
-																																				"log",
-																																			),
-																																			Sel: ast.NewIdent(
-																																				"Any",
-																																			),
-																																		},
-																																		Args: []ast.Expr{
-																																			&ast.BasicLit{
-																																				Kind:  token.STRING,
-																																				Value: `"error"`,
-																																			},
-																																			ast.NewIdent(
-																																				"err",
-																																			),
-																																		},
-																																	},
-																																},
-																															},
-																														},
-																													},
-																												},
-																											},
-																										},
-																									},
-																								},
-																							},
-																						},
-																						&ast.AssignStmt{
-																							Lhs: []ast.Expr{
-																								ast.NewIdent(
-																									"_",
-																								),
-																							},
-																							Tok: token.ASSIGN,
-																							Rhs: []ast.Expr{
-																								&ast.CallExpr{
-																									Fun: &ast.SelectorExpr{
-																										X: ast.NewIdent(
-																											"shutdowner",
-																										),
-																										Sel: ast.NewIdent(
-																											"Shutdown",
-																										),
-																									},
-																								},
-																							},
-																						},
-																						&ast.ReturnStmt{
-																							Results: []ast.Expr{
-																								ast.NewIdent(
-																									"nil",
-																								),
-																							},
-																						},
-																					},
-																				},
-																			},
-																		},
-																	},
-																},
-															},
-														},
-													},
+											&ast.KeyValueExpr{
+												Key: ast.NewIdent("OnStop"),
+												Value: &ast.SelectorExpr{
+													X:   ast.NewIdent("provider"),
+													Sel: ast.NewIdent("Stop"),
 												},
 											},
 										},
@@ -2407,45 +2453,1404 @@ func (f Generator) astMigrateContainer() *ast.FuncDecl {
 						},
 					},
 				},
-				&ast.ReturnStmt{
-					Results: []ast.Expr{
-						ast.NewIdent("app"),
-					},
-				},
 			},
 		},
 	}
 }
 
-func (f Generator) syncMigrateContainer() error {
-	fileset := token.NewFileSet()
-	filename := path.Join("internal", "pkg", "containers", "fx.go")
-	file, err := parser.ParseFile(fileset, filename, nil, parser.ParseComments)
-	if err != nil {
-		return err
-	}
-	var functionExists bool
-	var function *ast.FuncDecl
-	ast.Inspect(file, func(node ast.Node) bool {
-		if t, ok := node.(*ast.FuncDecl); ok && t.Name.String() == "NewMigrateContainer" {
-			functionExists = true
-			function = t
-			return false
-		}
-		return true
-	})
-	if function == nil {
-		function = f.astMigrateContainer()
+func (f Generator) metricsProviders() []ast.Expr {
+	exprs := []ast.Expr{
+		&ast.FuncLit{
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{
+								ast.NewIdent("config"),
+							},
+							Type: &ast.StarExpr{
+								X: &ast.SelectorExpr{
+									X:   ast.NewIdent("configs"),
+									Sel: ast.NewIdent("Config"),
+								},
+							},
+						},
+					},
+				},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Type: &ast.StarExpr{
+								X: &ast.SelectorExpr{
+									X:   ast.NewIdent("metrics"),
+									Sel: ast.NewIdent("Config"),
+								},
+							},
+						},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							&ast.SelectorExpr{
+								X:   ast.NewIdent("config"),
+								Sel: ast.NewIdent("Metrics"),
+							},
+						},
+					},
+				},
+			},
+		},
+		&ast.SelectorExpr{
+			X:   ast.NewIdent("metrics"),
+			Sel: ast.NewIdent("NewRegistry"),
+		},
+		&ast.SelectorExpr{
+			X:   ast.NewIdent("metrics"),
+			Sel: ast.NewIdent("NewServer"),
+		},
 	}
-	if !functionExists {
-		file.Decls = append(file.Decls, function)
+	if f.project.KafkaEnabled {
+		exprs = append(exprs, &ast.SelectorExpr{
+			X:   ast.NewIdent("kafka"),
+			Sel: ast.NewIdent("NewMetrics"),
+		})
 	}
-	buff := &bytes.Buffer{}
-	if err := printer.Fprint(buff, fileset, file); err != nil {
-		return err
+	if f.project.HTTPEnabled {
+		exprs = append(exprs, &ast.SelectorExpr{
+			X:   ast.NewIdent("metrics"),
+			Sel: ast.NewIdent("NewHTTPMetrics"),
+		})
 	}
-	if err := os.WriteFile(filename, buff.Bytes(), 0777); err != nil {
-		return err
+	if f.project.GRPCEnabled {
+		exprs = append(exprs, &ast.SelectorExpr{
+			X:   ast.NewIdent("metrics"),
+			Sel: ast.NewIdent("NewGRPCMetrics"),
+		})
 	}
-	return nil
+	return exprs
+}
+
+func (f Generator) metricsInvokes() []ast.Expr {
+	exprs := []ast.Expr{
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("fx"),
+				Sel: ast.NewIdent("Invoke"),
+			},
+			Args: []ast.Expr{
+				&ast.SelectorExpr{
+					X:   ast.NewIdent("metrics"),
+					Sel: ast.NewIdent("RegisterDatabase"),
+				},
+			},
+		},
+	}
+	if f.project.HTTPEnabled {
+		exprs = append(exprs, &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("fx"),
+				Sel: ast.NewIdent("Invoke"),
+			},
+			Args: []ast.Expr{
+				&ast.FuncLit{
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("server"),
+									},
+									Type: &ast.StarExpr{
+										X: &ast.SelectorExpr{
+											X:   ast.NewIdent("http"),
+											Sel: ast.NewIdent("Server"),
+										},
+									},
+								},
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("httpMetrics"),
+									},
+									Type: &ast.StarExpr{
+										X: &ast.SelectorExpr{
+											X:   ast.NewIdent("metrics"),
+											Sel: ast.NewIdent("HTTPMetrics"),
+										},
+									},
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X:   ast.NewIdent("server"),
+										Sel: ast.NewIdent("Use"),
+									},
+									Args: []ast.Expr{
+										&ast.SelectorExpr{
+											X:   ast.NewIdent("httpMetrics"),
+											Sel: ast.NewIdent("Middleware"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	if f.project.GRPCEnabled {
+		exprs = append(exprs, &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("fx"),
+				Sel: ast.NewIdent("Invoke"),
+			},
+			Args: []ast.Expr{
+				&ast.FuncLit{
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("server"),
+									},
+									Type: &ast.StarExpr{
+										X: &ast.SelectorExpr{
+											X:   ast.NewIdent("grpc"),
+											Sel: ast.NewIdent("Server"),
+										},
+									},
+								},
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("grpcMetrics"),
+									},
+									Type: &ast.StarExpr{
+										X: &ast.SelectorExpr{
+											X:   ast.NewIdent("metrics"),
+											Sel: ast.NewIdent("GRPCMetrics"),
+										},
+									},
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X:   ast.NewIdent("server"),
+										Sel: ast.NewIdent("AddInterceptor"),
+									},
+									Args: []ast.Expr{
+										&ast.SelectorExpr{
+											X:   ast.NewIdent("grpcMetrics"),
+											Sel: ast.NewIdent("UnaryServerInterceptor"),
+										},
+									},
+								},
+							},
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X:   ast.NewIdent("server"),
+										Sel: ast.NewIdent("AddStreamInterceptor"),
+									},
+									Args: []ast.Expr{
+										&ast.SelectorExpr{
+											X:   ast.NewIdent("grpcMetrics"),
+											Sel: ast.NewIdent("StreamServerInterceptor"),
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	exprs = append(exprs, f.adminServerInvoke("metrics"))
+	return exprs
+}
+
+// adminServerInvoke - run pkg.Server in background and shutdown the app when it fails.
+func (f Generator) adminServerInvoke(pkg string) ast.Expr {
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("fx"),
+			Sel: ast.NewIdent("Invoke"),
+		},
+		Args: []ast.Expr{
+			&ast.FuncLit{
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("lifecycle"),
+								},
+								Type: &ast.SelectorExpr{
+									X:   ast.NewIdent("fx"),
+									Sel: ast.NewIdent("Lifecycle"),
+								},
+							},
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("logger"),
+								},
+								Type: &ast.SelectorExpr{
+									X:   ast.NewIdent("log"),
+									Sel: ast.NewIdent("Logger"),
+								},
+							},
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("server"),
+								},
+								Type: &ast.StarExpr{
+									X: &ast.SelectorExpr{
+										X:   ast.NewIdent(pkg),
+										Sel: ast.NewIdent("Server"),
+									},
+								},
+							},
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("shutdowner"),
+								},
+								Type: &ast.SelectorExpr{
+									X:   ast.NewIdent("fx"),
+									Sel: ast.NewIdent("Shutdowner"),
+								},
+							},
+						},
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{
+							X: &ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("lifecycle"),
+									Sel: ast.NewIdent("Append"),
+								},
+								Args: []ast.Expr{
+									&ast.CompositeLit{
+										Type: &ast.SelectorExpr{
+											X:   ast.NewIdent("fx"),
+											Sel: ast.NewIdent("Hook"),
+										},
+										Elts: []ast.Expr{
+											&ast.KeyValueExpr{
+												Key: ast.NewIdent("OnStart"),
+												Value: &ast.FuncLit{
+													Type: &ast.FuncType{
+														Params: &ast.FieldList{
+															List: []*ast.Field{
+																{
+																	Names: []*ast.Ident{
+																		ast.NewIdent("ctx"),
+																	},
+																	Type: &ast.SelectorExpr{
+																		X:   ast.NewIdent("context"),
+																		Sel: ast.NewIdent("Context"),
+																	},
+																},
+															},
+														},
+														Results: &ast.FieldList{
+															List: []*ast.Field{
+																{
+																	Type: ast.NewIdent("error"),
+																},
+															},
+														},
+													},
+													Body: &ast.BlockStmt{
+														List: []ast.Stmt{
+															&ast.GoStmt{
+																Call: &ast.CallExpr{
+																	Fun: &ast.FuncLit{
+																		Type: &ast.FuncType{
+																			Params: &ast.FieldList{},
+																		},
+																		Body: &ast.BlockStmt{
+																			List: []ast.Stmt{
+																				&ast.AssignStmt{
+																					Lhs: []ast.Expr{
+																						ast.NewIdent("err"),
+																					},
+																					Tok: token.DEFINE,
+																					Rhs: []ast.Expr{
+																						&ast.CallExpr{
+																							Fun: &ast.SelectorExpr{
+																								X:   ast.NewIdent("server"),
+																								Sel: ast.NewIdent("Start"),
+																							},
+																							Args: []ast.Expr{
+																								ast.NewIdent("ctx"),
+																							},
+																						},
+																					},
+																				},
+																				&ast.IfStmt{
+																					Cond: &ast.BinaryExpr{
+																						X:  ast.NewIdent("err"),
+																						Op: token.NEQ,
+																						Y:  ast.NewIdent("nil"),
+																					},
+																					Body: &ast.BlockStmt{
+																						List: []ast.Stmt{
+																							&ast.ExprStmt{
+																								X: &ast.CallExpr{
+																									Fun: &ast.SelectorExpr{
+																										X:   ast.NewIdent("logger"),
+																										Sel: ast.NewIdent("Error"),
+																									},
+																									Args: []ast.Expr{
+																										&ast.BasicLit{
+																											Kind:  token.STRING,
+																											Value: `"shutdown"`,
+																										},
+																										&ast.CallExpr{
+																											Fun: &ast.SelectorExpr{
+																												X:   ast.NewIdent("log"),
+																												Sel: ast.NewIdent("Any"),
+																											},
+																											Args: []ast.Expr{
+																												&ast.BasicLit{
+																													Kind:  token.STRING,
+																													Value: `"error"`,
+																												},
+																												ast.NewIdent("err"),
+																											},
+																										},
+																									},
+																								},
+																							},
+																							&ast.AssignStmt{
+																								Lhs: []ast.Expr{
+																									ast.NewIdent("_"),
+																								},
+																								Tok: token.ASSIGN,
+																								Rhs: []ast.Expr{
+																									&ast.CallExpr{
+																										Fun: &ast.SelectorExpr{
+																											X:   ast.NewIdent("shutdowner"),
+																											Sel: ast.NewIdent("Shutdown"),
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+															},
+															&ast.ReturnStmt{
+																Results: []ast.Expr{
+																	ast.NewIdent("nil"),
+																},
+															},
+														},
+													},
+												},
+											},
+											&ast.KeyValueExpr{
+												Key: ast.NewIdent("OnStop"),
+												Value: &ast.SelectorExpr{
+													X:   ast.NewIdent("server"),
+													Sel: ast.NewIdent("Stop"),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (f Generator) syncServerContainer() error {
+	fileset := token.NewFileSet()
+	filename := path.Join("internal", "pkg", "containers", "fx.go")
+	file, err := parser.ParseFile(fileset, filename, nil, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	var functionExists bool
+	var function *ast.FuncDecl
+	ast.Inspect(file, func(node ast.Node) bool {
+		if t, ok := node.(*ast.FuncDecl); ok && t.Name.String() == "NewServerContainer" {
+			functionExists = true
+			function = t
+			return false
+		}
+		return true
+	})
+	if function == nil {
+		function = f.astServerContainer()
+	}
+	if !functionExists {
+		file.Decls = append(file.Decls, function)
+	}
+	buff := &bytes.Buffer{}
+	if err := printer.Fprint(buff, fileset, file); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filename, buff.Bytes(), 0777); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (f Generator) astMigrateContainer() *ast.FuncDecl {
+	args := []ast.Expr{
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("fx"),
+				Sel: ast.NewIdent("Provide"),
+			},
+			Args: []ast.Expr{
+				&ast.FuncLit{
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{},
+						Results: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Type: ast.NewIdent("string"),
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{
+								Results: []ast.Expr{
+									ast.NewIdent("config"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		ast.NewIdent("FXModule"),
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("fx"),
+				Sel: ast.NewIdent("Invoke"),
+			},
+			Args: []ast.Expr{
+				&ast.FuncLit{
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{
+							List: []*ast.Field{
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("lifecycle"),
+									},
+									Type: &ast.SelectorExpr{
+										X:   ast.NewIdent("fx"),
+										Sel: ast.NewIdent("Lifecycle"),
+									},
+								},
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("logger"),
+									},
+									Type: &ast.SelectorExpr{
+										X: &ast.Ident{
+											Name: "log",
+										},
+										Sel: &ast.Ident{
+											Name: "Logger",
+										},
+									},
+								},
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("manager"),
+									},
+									Type: &ast.StarExpr{
+										X: &ast.SelectorExpr{
+											X: ast.NewIdent(f.project.Database),
+											Sel: ast.NewIdent(
+												"MigrateManager",
+											),
+										},
+									},
+								},
+								{
+									Names: []*ast.Ident{
+										ast.NewIdent("shutdowner"),
+									},
+									Type: &ast.SelectorExpr{
+										X:   ast.NewIdent("fx"),
+										Sel: ast.NewIdent("Shutdowner"),
+									},
+								},
+							},
+						},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X:   ast.NewIdent("lifecycle"),
+										Sel: ast.NewIdent("Append"),
+									},
+									Args: []ast.Expr{
+										&ast.CompositeLit{
+											Type: &ast.SelectorExpr{
+												X:   ast.NewIdent("fx"),
+												Sel: ast.NewIdent("Hook"),
+											},
+											Elts: []ast.Expr{
+												&ast.KeyValueExpr{
+													Key: ast.NewIdent(
+														"OnStart",
+													),
+													Value: &ast.FuncLit{
+														Type: &ast.FuncType{
+															Params: &ast.FieldList{
+																List: []*ast.Field{
+																	{
+																		Names: []*ast.Ident{
+																			ast.NewIdent(
+																				"ctx",
+																			),
+																		},
+																		Type: &ast.SelectorExpr{
+																			X: ast.NewIdent(
+																				"context",
+																			),
+																			Sel: ast.NewIdent(
+																				"Context",
+																			),
+																		},
+																	},
+																},
+															},
+															Results: &ast.FieldList{
+																List: []*ast.Field{
+																	{
+																		Type: ast.NewIdent(
+																			"error",
+																		),
+																	},
+																},
+															},
+														},
+														Body: &ast.BlockStmt{
+															List: []ast.Stmt{
+																&ast.GoStmt{
+																	Call: &ast.CallExpr{
+																		Fun: &ast.FuncLit{
+																			Type: &ast.FuncType{
+																				Params: &ast.FieldList{},
+																			},
+																			Body: &ast.BlockStmt{
+																				List: []ast.Stmt{
+																					&ast.AssignStmt{
+																						Lhs: []ast.Expr{
+																							ast.NewIdent(
+																								"err",
+																							),
+																						},
+																						Tok: token.DEFINE,
+																						Rhs: []ast.Expr{
+																							&ast.CallExpr{
+																								Fun: &ast.SelectorExpr{
+																									X: ast.NewIdent(
+																										"manager",
+																									),
+																									Sel: ast.NewIdent(
+																										"Up",
+																									),
+																								},
+																								Args: []ast.Expr{
+																									ast.NewIdent(
+																										"ctx",
+																									),
+																								},
+																							},
+																						},
+																					},
+																					&ast.IfStmt{
+																						Cond: &ast.BinaryExpr{
+																							X: ast.NewIdent(
+																								"err",
+																							),
+																							Op: token.NEQ,
+																							Y: ast.NewIdent(
+																								"nil",
+																							),
+																						},
+																						Body: &ast.BlockStmt{
+																							List: []ast.Stmt{
+																								&ast.ExprStmt{
+																									X: &ast.CallExpr{
+																										Fun: &ast.SelectorExpr{
+																											X: ast.NewIdent(
+																												"logger",
+																											),
+																											Sel: ast.NewIdent(
+																												"Error",
+																											),
+																										},
+																										Args: []ast.Expr{
+																											&ast.BasicLit{
+																												Kind:  token.STRING,
+																												Value: `"shutdown"`,
+																											},
+																											&ast.CallExpr{
+																												Fun: &ast.SelectorExpr{
+																													X: ast.NewIdent(
+																														"log",
+																													),
+																													Sel: ast.NewIdent(
+																														"Any",
+																													),
+																												},
+																												Args: []ast.Expr{
+																													&ast.BasicLit{
+																														Kind:  token.STRING,
+																														Value: `"error"`,
+																													},
+																													ast.NewIdent(
+																														"err",
+																													),
+																												},
+																											},
+																										},
+																									},
+																								},
+																							},
+																						},
+																					},
+																				},
+																			},
+																		},
+																	},
+																},
+																&ast.AssignStmt{
+																	Lhs: []ast.Expr{
+																		ast.NewIdent(
+																			"_",
+																		),
+																	},
+																	Tok: token.ASSIGN,
+																	Rhs: []ast.Expr{
+																		&ast.CallExpr{
+																			Fun: &ast.SelectorExpr{
+																				X: ast.NewIdent(
+																					"shutdowner",
+																				),
+																				Sel: ast.NewIdent(
+																					"Shutdown",
+																				),
+																			},
+																		},
+																	},
+																},
+																&ast.ReturnStmt{
+																	Results: []ast.Expr{
+																		ast.NewIdent(
+																			"nil",
+																		),
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, app := range f.project.Apps {
+		if app.MongoEnabled() {
+			args = append(args, f.createIndexesInvoke(app))
+		}
+	}
+	return &ast.FuncDecl{
+		Name: ast.NewIdent("NewMigrateContainer"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							ast.NewIdent("config"),
+						},
+						Type: ast.NewIdent("string"),
+					},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: &ast.StarExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent("fx"),
+								Sel: ast.NewIdent("App"),
+							},
+						},
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("app"),
+					},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("fx"),
+								Sel: ast.NewIdent("New"),
+							},
+							Args: args,
+						},
+					},
+				},
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						ast.NewIdent("app"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func (f Generator) syncMigrateContainer() error {
+	fileset := token.NewFileSet()
+	filename := path.Join("internal", "pkg", "containers", "fx.go")
+	file, err := parser.ParseFile(fileset, filename, nil, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	var functionExists bool
+	var function *ast.FuncDecl
+	ast.Inspect(file, func(node ast.Node) bool {
+		if t, ok := node.(*ast.FuncDecl); ok && t.Name.String() == "NewMigrateContainer" {
+			functionExists = true
+			function = t
+			return false
+		}
+		return true
+	})
+	if function == nil {
+		function = f.astMigrateContainer()
+	}
+	if !functionExists {
+		file.Decls = append(file.Decls, function)
+	}
+	buff := &bytes.Buffer{}
+	if err := printer.Fprint(buff, fileset, file); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filename, buff.Bytes(), 0777); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (f Generator) healthProviders() []ast.Expr {
+	exprs := []ast.Expr{
+		&ast.FuncLit{
+			Type: &ast.FuncType{
+				Params: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{
+								ast.NewIdent("config"),
+							},
+							Type: &ast.StarExpr{
+								X: &ast.SelectorExpr{
+									X:   ast.NewIdent("configs"),
+									Sel: ast.NewIdent("Config"),
+								},
+							},
+						},
+					},
+				},
+				Results: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Type: &ast.StarExpr{
+								X: &ast.SelectorExpr{
+									X:   ast.NewIdent("health"),
+									Sel: ast.NewIdent("Config"),
+								},
+							},
+						},
+					},
+				},
+			},
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							&ast.SelectorExpr{
+								X:   ast.NewIdent("config"),
+								Sel: ast.NewIdent("Health"),
+							},
+						},
+					},
+				},
+			},
+		},
+		&ast.SelectorExpr{
+			X:   ast.NewIdent("health"),
+			Sel: ast.NewIdent("NewHealth"),
+		},
+	}
+	if !f.project.HTTPEnabled {
+		exprs = append(exprs, &ast.SelectorExpr{
+			X:   ast.NewIdent("health"),
+			Sel: ast.NewIdent("NewServer"),
+		})
+	}
+	return exprs
+}
+
+// healthInvoke - invoke body with the health monitor and a dependency named name.
+func (f Generator) healthInvoke(name string, dependency ast.Expr, body ast.Expr) ast.Expr {
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("fx"),
+			Sel: ast.NewIdent("Invoke"),
+		},
+		Args: []ast.Expr{
+			&ast.FuncLit{
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("monitor"),
+								},
+								Type: &ast.StarExpr{
+									X: &ast.SelectorExpr{
+										X:   ast.NewIdent("health"),
+										Sel: ast.NewIdent("Health"),
+									},
+								},
+							},
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent(name),
+								},
+								Type: dependency,
+							},
+						},
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{
+							X: body,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func (f Generator) healthInvokes() []ast.Expr {
+	exprs := []ast.Expr{
+		f.healthInvoke(
+			"database",
+			&ast.StarExpr{
+				X: &ast.SelectorExpr{
+					X:   ast.NewIdent("sqlx"),
+					Sel: ast.NewIdent("DB"),
+				},
+			},
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("monitor"),
+					Sel: ast.NewIdent("AddChecker"),
+				},
+				Args: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("health"),
+							Sel: ast.NewIdent("NewDatabaseChecker"),
+						},
+						Args: []ast.Expr{
+							ast.NewIdent("database"),
+						},
+					},
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("health"),
+							Sel: ast.NewIdent("NewMigrationChecker"),
+						},
+						Args: []ast.Expr{
+							ast.NewIdent("database"),
+							&ast.SelectorExpr{
+								X:   ast.NewIdent(f.project.Database),
+								Sel: ast.NewIdent("MigrationsFS"),
+							},
+						},
+					},
+				},
+			},
+		),
+	}
+	if f.project.MongoEnabled() {
+		exprs = append(exprs, f.healthInvoke(
+			"mongoDB",
+			&ast.StarExpr{
+				X: &ast.SelectorExpr{
+					X:   ast.NewIdent("mongo"),
+					Sel: ast.NewIdent("Database"),
+				},
+			},
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("monitor"),
+					Sel: ast.NewIdent("AddChecker"),
+				},
+				Args: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("health"),
+							Sel: ast.NewIdent("NewMongoChecker"),
+						},
+						Args: []ast.Expr{
+							ast.NewIdent("mongoDB"),
+						},
+					},
+				},
+			},
+		))
+	}
+	if f.project.KafkaEnabled {
+		exprs = append(exprs, f.healthInvoke(
+			"config",
+			&ast.StarExpr{
+				X: &ast.SelectorExpr{
+					X:   ast.NewIdent("kafka"),
+					Sel: ast.NewIdent("Config"),
+				},
+			},
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("monitor"),
+					Sel: ast.NewIdent("AddChecker"),
+				},
+				Args: []ast.Expr{
+					&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X:   ast.NewIdent("health"),
+							Sel: ast.NewIdent("NewKafkaChecker"),
+						},
+						Args: []ast.Expr{
+							&ast.SelectorExpr{
+								X:   ast.NewIdent("config"),
+								Sel: ast.NewIdent("Brokers"),
+							},
+						},
+					},
+				},
+			},
+		))
+	}
+	if f.project.GRPCEnabled {
+		exprs = append(exprs, f.healthInvoke(
+			"server",
+			&ast.StarExpr{
+				X: &ast.SelectorExpr{
+					X:   ast.NewIdent("grpc"),
+					Sel: ast.NewIdent("Server"),
+				},
+			},
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("monitor"),
+					Sel: ast.NewIdent("AddListener"),
+				},
+				Args: []ast.Expr{
+					&ast.SelectorExpr{
+						X:   ast.NewIdent("server"),
+						Sel: ast.NewIdent("SetServingStatus"),
+					},
+				},
+			},
+		))
+	}
+	if f.project.HTTPEnabled {
+		exprs = append(exprs, f.healthInvoke(
+			"server",
+			&ast.StarExpr{
+				X: &ast.SelectorExpr{
+					X:   ast.NewIdent("http"),
+					Sel: ast.NewIdent("Server"),
+				},
+			},
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("monitor"),
+					Sel: ast.NewIdent("Register"),
+				},
+				Args: []ast.Expr{
+					&ast.SelectorExpr{
+						X:   ast.NewIdent("server"),
+						Sel: ast.NewIdent("Mount"),
+					},
+				},
+			},
+		))
+	} else {
+		exprs = append(exprs, f.adminServerInvoke("health"))
+	}
+	// registered last so OnStop reports NOT_SERVING before any server stops
+	exprs = append(exprs, f.healthInvoke(
+		"lifecycle",
+		&ast.SelectorExpr{
+			X:   ast.NewIdent("fx"),
+			Sel: ast.NewIdent("Lifecycle"),
+		},
+		&ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("lifecycle"),
+				Sel: ast.NewIdent("Append"),
+			},
+			Args: []ast.Expr{
+				&ast.CompositeLit{
+					Type: &ast.SelectorExpr{
+						X:   ast.NewIdent("fx"),
+						Sel: ast.NewIdent("Hook"),
+					},
+					Elts: []ast.Expr{
+						&ast.KeyValueExpr{
+							Key: ast.NewIdent("OnStart"),
+							Value: &ast.SelectorExpr{
+								X:   ast.NewIdent("monitor"),
+								Sel: ast.NewIdent("Start"),
+							},
+						},
+						&ast.KeyValueExpr{
+							Key: ast.NewIdent("OnStop"),
+							Value: &ast.SelectorExpr{
+								X:   ast.NewIdent("monitor"),
+								Sel: ast.NewIdent("Stop"),
+							},
+						},
+					},
+				},
+			},
+		},
+	))
+	return exprs
+}
+
+func (f Generator) rateLimitProviders() []ast.Expr {
+	return []ast.Expr{
+		f.configProvider("ratelimit", "RateLimit"),
+		&ast.SelectorExpr{
+			X:   ast.NewIdent("ratelimit"),
+			Sel: ast.NewIdent("NewLimiter"),
+		},
+	}
+}
+
+// rateLimitInvokes - put the limiter in front of the handlers, after metrics so limited requests are counted.
+func (f Generator) rateLimitInvokes() []ast.Expr {
+	var exprs []ast.Expr
+	if f.project.HTTPEnabled {
+		exprs = append(exprs, f.rateLimitInvoke("http", "Use", "Middleware"))
+	}
+	if f.project.GRPCEnabled {
+		exprs = append(
+			exprs,
+			f.rateLimitInvoke("grpc", "AddInterceptor", "UnaryServerInterceptor"),
+			f.rateLimitInvoke("grpc", "AddStreamInterceptor", "StreamServerInterceptor"),
+		)
+	}
+	return exprs
+}
+
+// rateLimitInvoke - fx.Invoke(func(server *pkg.Server, limiter *ratelimit.Limiter) { server.add(limiter.method) }).
+func (f Generator) rateLimitInvoke(pkg, add, method string) ast.Expr {
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("fx"),
+			Sel: ast.NewIdent("Invoke"),
+		},
+		Args: []ast.Expr{
+			&ast.FuncLit{
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("server"),
+								},
+								Type: &ast.StarExpr{
+									X: &ast.SelectorExpr{
+										X:   ast.NewIdent(pkg),
+										Sel: ast.NewIdent("Server"),
+									},
+								},
+							},
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("limiter"),
+								},
+								Type: &ast.StarExpr{
+									X: &ast.SelectorExpr{
+										X:   ast.NewIdent("ratelimit"),
+										Sel: ast.NewIdent("Limiter"),
+									},
+								},
+							},
+						},
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{
+							X: &ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("server"),
+									Sel: ast.NewIdent(add),
+								},
+								Args: []ast.Expr{
+									&ast.SelectorExpr{
+										X:   ast.NewIdent("limiter"),
+										Sel: ast.NewIdent(method),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// configProvider - func(config *configs.Config) *pkg.Config { return config.field }.
+func (f Generator) configProvider(pkg, field string) ast.Expr {
+	return &ast.FuncLit{
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							ast.NewIdent("config"),
+						},
+						Type: &ast.StarExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent("configs"),
+								Sel: ast.NewIdent("Config"),
+							},
+						},
+					},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: &ast.StarExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent(pkg),
+								Sel: ast.NewIdent("Config"),
+							},
+						},
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.SelectorExpr{
+							X:   ast.NewIdent("config"),
+							Sel: ast.NewIdent(field),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// cacheInvoke - close the cache connection on shutdown.
+func (f Generator) cacheInvoke() ast.Expr {
+	return f.lifecycleInvoke(
+		"store",
+		&ast.SelectorExpr{
+			X:   ast.NewIdent("cache"),
+			Sel: ast.NewIdent("Cache"),
+		},
+		&ast.KeyValueExpr{
+			Key: ast.NewIdent("OnStop"),
+			Value: &ast.FuncLit{
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Type: &ast.SelectorExpr{
+									X:   ast.NewIdent("context"),
+									Sel: ast.NewIdent("Context"),
+								},
+							},
+						},
+					},
+					Results: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Type: ast.NewIdent("error"),
+							},
+						},
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ReturnStmt{
+							Results: []ast.Expr{
+								&ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X:   ast.NewIdent("store"),
+										Sel: ast.NewIdent("Close"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	)
+}
+
+// lifecycleInvoke - append a hook built from the dependency named name.
+func (f Generator) lifecycleInvoke(name string, dependency ast.Expr, hook ...ast.Expr) ast.Expr {
+	return &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("fx"),
+			Sel: ast.NewIdent("Invoke"),
+		},
+		Args: []ast.Expr{
+			&ast.FuncLit{
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("lifecycle"),
+								},
+								Type: &ast.SelectorExpr{
+									X:   ast.NewIdent("fx"),
+									Sel: ast.NewIdent("Lifecycle"),
+								},
+							},
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent(name),
+								},
+								Type: dependency,
+							},
+						},
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{
+							X: &ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("lifecycle"),
+									Sel: ast.NewIdent("Append"),
+								},
+								Args: []ast.Expr{
+									&ast.CompositeLit{
+										Type: &ast.SelectorExpr{
+											X:   ast.NewIdent("fx"),
+											Sel: ast.NewIdent("Hook"),
+										},
+										Elts: hook,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// mongoInvoke - disconnect the MongoDB client on shutdown.
+func (f Generator) mongoInvoke() ast.Expr {
+	return f.lifecycleInvoke(
+		"mongoDB",
+		&ast.StarExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent("mongo"),
+				Sel: ast.NewIdent("Database"),
+			},
+		},
+		&ast.KeyValueExpr{
+			Key: ast.NewIdent("OnStop"),
+			Value: &ast.SelectorExpr{
+				X:   ast.NewIdent("mongoDB"),
+				Sel: ast.NewIdent("Close"),
+			},
+		},
+	)
+}
+
+// createIndexesInvoke - create the MongoDB indexes of the app, in place of SQL migrations.
+func (f Generator) createIndexesInvoke(app configs.AppConfig) ast.Expr {
+	return f.lifecycleInvoke(
+		"app",
+		&ast.StarExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent(app.AppAlias()),
+				Sel: ast.NewIdent("App"),
+			},
+		},
+		&ast.KeyValueExpr{
+			Key: ast.NewIdent("OnStart"),
+			Value: &ast.SelectorExpr{
+				X:   ast.NewIdent("app"),
+				Sel: ast.NewIdent("CreateIndexes"),
+			},
+		},
+	)
 }
@@ -29,6 +29,11 @@ func (c *Generator) Sync() error {
 			DestinationPath: path.Join(destinationPath, "internal", "pkg", "dtx", "tx.go"),
 			Name:            "tx",
 		},
+		{
+			SourcePath:      "templates/internal/pkg/dtx/manager_test.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "dtx", "manager_test.go"),
+			Name:            "manager tests",
+		},
 	}
 	for _, file := range files {
 		if err := file.RenderToFile(c.project); err != nil {
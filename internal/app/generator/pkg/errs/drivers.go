@@ -0,0 +1,638 @@
+package errs
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"os"
+	"path"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+// driverError describes how errors of a database driver are mapped to domain errors.
+type driverError struct {
+	function   string
+	importPath string
+	variable   string
+	errorType  ast.Expr
+	consts     []ast.Spec
+	params     []ast.Stmt
+	conflict   ast.Expr
+}
+
+func (i Generator) syncDatabase() error {
+	switch i.project.Database {
+	case "mysql":
+		return i.syncDriver("mysql", i.fileMySQL)
+	case "sqlite":
+		return i.syncDriver("sqlite", i.fileSQLite)
+	default:
+		if i.project.PgxEnabled() {
+			return i.syncDriver("postgres", i.filePgx)
+		}
+		return i.syncPostgres()
+	}
+}
+
+// syncDriver writes the error mapping of the driver to errs/<name>.go and its tests.
+func (i Generator) syncDriver(name string, file func() *ast.File) error {
+	fileset := token.NewFileSet()
+	filename := path.Join("internal", "pkg", "errs", fmt.Sprintf("%s.go", name))
+	err := os.MkdirAll(path.Dir(filename), 0777)
+	if err != nil {
+		return err
+	}
+	existing, err := parser.ParseFile(fileset, filename, nil, parser.ParseComments)
+	if err != nil {
+		existing = file()
+	}
+	buff := &bytes.Buffer{}
+	if err := printer.Fprint(buff, fileset, existing); err != nil {
+		return err
+	}
+	if err := os.WriteFile(filename, buff.Bytes(), 0777); err != nil {
+		return err
+	}
+	test := &tmpl.Template{
+		SourcePath: fmt.Sprintf("templates/internal/pkg/errs/%s_test.go.tmpl", name),
+		DestinationPath: path.Join(
+			destinationPath,
+			"internal",
+			"pkg",
+			"errs",
+			fmt.Sprintf("%s_test.go", name),
+		),
+		Name: fmt.Sprintf("%s errors tests", name),
+	}
+	if err := test.RenderToFile(i.project); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (i Generator) fileMySQL() *ast.File {
+	return i.fileDriverError(driverError{
+		function:   "FromMySQLError",
+		importPath: `"github.com/go-sql-driver/mysql"`,
+		variable:   "mysqlErr",
+		errorType: &ast.SelectorExpr{
+			X:   ast.NewIdent("mysql"),
+			Sel: ast.NewIdent("MySQLError"),
+		},
+		consts: []ast.Spec{
+			&ast.ValueSpec{
+				Names: []*ast.Ident{
+					ast.NewIdent("mysqlConflictCode"),
+				},
+				Values: []ast.Expr{
+					&ast.BasicLit{
+						Kind:  token.INT,
+						Value: "1062",
+					},
+				},
+			},
+		},
+		params: []ast.Stmt{
+			addParam("message", &ast.SelectorExpr{
+				X:   ast.NewIdent("mysqlErr"),
+				Sel: ast.NewIdent("Message"),
+			}),
+			addParam("mysql_code", &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("fmt"),
+					Sel: ast.NewIdent("Sprint"),
+				},
+				Args: []ast.Expr{
+					&ast.SelectorExpr{
+						X:   ast.NewIdent("mysqlErr"),
+						Sel: ast.NewIdent("Number"),
+					},
+				},
+			}),
+		},
+		conflict: &ast.BinaryExpr{
+			X: &ast.SelectorExpr{
+				X:   ast.NewIdent("mysqlErr"),
+				Sel: ast.NewIdent("Number"),
+			},
+			Op: token.EQL,
+			Y:  ast.NewIdent("mysqlConflictCode"),
+		},
+	})
+}
+
+func (i Generator) fileSQLite() *ast.File {
+	code := &ast.CallExpr{
+		Fun: &ast.SelectorExpr{
+			X:   ast.NewIdent("sqliteErr"),
+			Sel: ast.NewIdent("Code"),
+		},
+	}
+	return i.fileDriverError(driverError{
+		function:   "FromSQLiteError",
+		importPath: `"modernc.org/sqlite"`,
+		variable:   "sqliteErr",
+		errorType: &ast.SelectorExpr{
+			X:   ast.NewIdent("sqlite"),
+			Sel: ast.NewIdent("Error"),
+		},
+		consts: []ast.Spec{
+			&ast.ValueSpec{
+				Names: []*ast.Ident{
+					ast.NewIdent("sqliteConflictCode"),
+				},
+				Values: []ast.Expr{
+					&ast.BasicLit{
+						Kind:  token.INT,
+						Value: "2067",
+					},
+				},
+			},
+			&ast.ValueSpec{
+				Names: []*ast.Ident{
+					ast.NewIdent("sqlitePrimaryKeyCode"),
+				},
+				Values: []ast.Expr{
+					&ast.BasicLit{
+						Kind:  token.INT,
+						Value: "1555",
+					},
+				},
+			},
+		},
+		params: []ast.Stmt{
+			addParam("sqlite_code", &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("fmt"),
+					Sel: ast.NewIdent("Sprint"),
+				},
+				Args: []ast.Expr{code},
+			}),
+		},
+		conflict: &ast.BinaryExpr{
+			X: &ast.BinaryExpr{
+				X:  code,
+				Op: token.EQL,
+				Y:  ast.NewIdent("sqliteConflictCode"),
+			},
+			Op: token.LOR,
+			Y: &ast.BinaryExpr{
+				X:  code,
+				Op: token.EQL,
+				Y:  ast.NewIdent("sqlitePrimaryKeyCode"),
+			},
+		},
+	})
+}
+
+// filePgx maps *pgconn.PgError the same way filePostgres maps *pq.Error.
+func (i Generator) filePgx() *ast.File {
+	field := func(name string) ast.Expr {
+		return &ast.SelectorExpr{
+			X:   ast.NewIdent("pgErr"),
+			Sel: ast.NewIdent(name),
+		}
+	}
+	return i.fileDriverError(driverError{
+		function:   "FromPostgresError",
+		importPath: `"github.com/jackc/pgx/v5/pgconn"`,
+		variable:   "pgErr",
+		errorType: &ast.SelectorExpr{
+			X:   ast.NewIdent("pgconn"),
+			Sel: ast.NewIdent("PgError"),
+		},
+		consts: []ast.Spec{
+			&ast.ValueSpec{
+				Names: []*ast.Ident{
+					ast.NewIdent("sqlConflictCode"),
+				},
+				Values: []ast.Expr{
+					&ast.BasicLit{
+						Kind:  token.STRING,
+						Value: `"23505"`,
+					},
+				},
+			},
+		},
+		params: []ast.Stmt{
+			addParam("details", field("Detail")),
+			addParam("message", field("Message")),
+			addParam("postgres_code", &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("fmt"),
+					Sel: ast.NewIdent("Sprint"),
+				},
+				Args: []ast.Expr{field("Code")},
+			}),
+		},
+		conflict: &ast.BinaryExpr{
+			X:  field("Code"),
+			Op: token.EQL,
+			Y:  ast.NewIdent("sqlConflictCode"),
+		},
+	})
+}
+
+func addParam(key string, value ast.Expr) ast.Stmt {
+	return &ast.ExprStmt{
+		X: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("e"),
+				Sel: ast.NewIdent("AddParam"),
+			},
+			Args: []ast.Expr{
+				&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: fmt.Sprintf(`"%s"`, key),
+				},
+				value,
+			},
+		},
+	}
+}
+
+func (i Generator) fileDriverError(driver driverError) *ast.File {
+	imports := []*ast.ImportSpec{
+		{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"database/sql"`,
+			},
+		},
+		{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"errors"`,
+			},
+		},
+		{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"fmt"`,
+			},
+		},
+		{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: driver.importPath,
+			},
+		},
+	}
+	specs := make([]ast.Spec, 0, len(imports))
+	for _, spec := range imports {
+		specs = append(specs, spec)
+	}
+	conflict := &ast.IfStmt{
+		Cond: driver.conflict,
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("e"),
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X: &ast.CallExpr{
+									Fun: ast.NewIdent("NewInvalidFormError"),
+								},
+								Sel: ast.NewIdent("WithCause"),
+							},
+							Args: []ast.Expr{
+								ast.NewIdent("err"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	return &ast.File{
+		Package: 1,
+		Name:    ast.NewIdent("errs"),
+		Decls: []ast.Decl{
+			&ast.GenDecl{
+				Tok:   token.IMPORT,
+				Specs: specs,
+			},
+			&ast.GenDecl{
+				Tok:    token.CONST,
+				Lparen: 1,
+				Specs:  driver.consts,
+			},
+			&ast.FuncDecl{
+				Name: ast.NewIdent(driver.function),
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("err"),
+								},
+								Type: ast.NewIdent("error"),
+							},
+						},
+					},
+					Results: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Type: &ast.StarExpr{
+									X: ast.NewIdent("Error"),
+								},
+							},
+						},
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.AssignStmt{
+							Lhs: []ast.Expr{
+								ast.NewIdent("e"),
+							},
+							Tok: token.DEFINE,
+							Rhs: []ast.Expr{
+								&ast.UnaryExpr{
+									Op: token.AND,
+									X: &ast.CompositeLit{
+										Type: ast.NewIdent("Error"),
+										Elts: []ast.Expr{
+											&ast.KeyValueExpr{
+												Key:   ast.NewIdent("Code"),
+												Value: ast.NewIdent("ErrorCodeInternal"),
+											},
+											&ast.KeyValueExpr{
+												Key: ast.NewIdent("Message"),
+												Value: &ast.BasicLit{
+													Kind:  token.STRING,
+													Value: `"Unexpected behavior."`,
+												},
+											},
+											&ast.KeyValueExpr{
+												Key:   ast.NewIdent("Params"),
+												Value: ast.NewIdent("nil"),
+											},
+											&ast.KeyValueExpr{
+												Key:   ast.NewIdent("Err"),
+												Value: ast.NewIdent("err"),
+											},
+										},
+									},
+								},
+							},
+						},
+						&ast.DeclStmt{
+							Decl: &ast.GenDecl{
+								Tok: token.VAR,
+								Specs: []ast.Spec{
+									&ast.ValueSpec{
+										Names: []*ast.Ident{
+											ast.NewIdent(driver.variable),
+										},
+										Type: &ast.StarExpr{
+											X: driver.errorType,
+										},
+									},
+								},
+							},
+						},
+						&ast.IfStmt{
+							Cond: &ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("errors"),
+									Sel: ast.NewIdent("As"),
+								},
+								Args: []ast.Expr{
+									ast.NewIdent("err"),
+									&ast.UnaryExpr{
+										Op: token.AND,
+										X:  ast.NewIdent(driver.variable),
+									},
+								},
+							},
+							Body: &ast.BlockStmt{
+								List: append(driver.params, conflict),
+							},
+						},
+						addParam("error", &ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("err"),
+								Sel: ast.NewIdent("Error"),
+							},
+						}),
+						&ast.IfStmt{
+							Cond: &ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("errors"),
+									Sel: ast.NewIdent("Is"),
+								},
+								Args: []ast.Expr{
+									ast.NewIdent("err"),
+									&ast.SelectorExpr{
+										X:   ast.NewIdent("sql"),
+										Sel: ast.NewIdent("ErrNoRows"),
+									},
+								},
+							},
+							Body: &ast.BlockStmt{
+								List: []ast.Stmt{
+									&ast.AssignStmt{
+										Lhs: []ast.Expr{
+											ast.NewIdent("e"),
+										},
+										Tok: token.ASSIGN,
+										Rhs: []ast.Expr{
+											&ast.CallExpr{
+												Fun: ast.NewIdent("NewEntityNotFoundError"),
+											},
+										},
+									},
+								},
+							},
+						},
+						&ast.ReturnStmt{
+							Results: []ast.Expr{
+								ast.NewIdent("e"),
+							},
+						},
+					},
+				},
+			},
+		},
+		Imports: imports,
+	}
+}
+
+func (i Generator) fileMongo() *ast.File {
+	imports := []*ast.ImportSpec{
+		{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"errors"`,
+			},
+		},
+		{
+			Path: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: `"go.mongodb.org/mongo-driver/mongo"`,
+			},
+		},
+	}
+	specs := make([]ast.Spec, 0, len(imports))
+	for _, spec := range imports {
+		specs = append(specs, spec)
+	}
+	return &ast.File{
+		Package: 1,
+		Name:    ast.NewIdent("errs"),
+		Decls: []ast.Decl{
+			&ast.GenDecl{
+				Tok:   token.IMPORT,
+				Specs: specs,
+			},
+			&ast.FuncDecl{
+				Name: ast.NewIdent("FromMongoError"),
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("err"),
+								},
+								Type: ast.NewIdent("error"),
+							},
+						},
+					},
+					Results: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Type: &ast.StarExpr{
+									X: ast.NewIdent("Error"),
+								},
+							},
+						},
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.AssignStmt{
+							Lhs: []ast.Expr{
+								ast.NewIdent("e"),
+							},
+							Tok: token.DEFINE,
+							Rhs: []ast.Expr{
+								&ast.UnaryExpr{
+									Op: token.AND,
+									X: &ast.CompositeLit{
+										Type: ast.NewIdent("Error"),
+										Elts: []ast.Expr{
+											&ast.KeyValueExpr{
+												Key:   ast.NewIdent("Code"),
+												Value: ast.NewIdent("ErrorCodeInternal"),
+											},
+											&ast.KeyValueExpr{
+												Key: ast.NewIdent("Message"),
+												Value: &ast.BasicLit{
+													Kind:  token.STRING,
+													Value: `"Unexpected behavior."`,
+												},
+											},
+											&ast.KeyValueExpr{
+												Key:   ast.NewIdent("Params"),
+												Value: ast.NewIdent("nil"),
+											},
+											&ast.KeyValueExpr{
+												Key:   ast.NewIdent("Err"),
+												Value: ast.NewIdent("err"),
+											},
+										},
+									},
+								},
+							},
+						},
+						&ast.IfStmt{
+							Cond: &ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("mongo"),
+									Sel: ast.NewIdent("IsDuplicateKeyError"),
+								},
+								Args: []ast.Expr{
+									ast.NewIdent("err"),
+								},
+							},
+							Body: &ast.BlockStmt{
+								List: []ast.Stmt{
+									&ast.AssignStmt{
+										Lhs: []ast.Expr{
+											ast.NewIdent("e"),
+										},
+										Tok: token.ASSIGN,
+										Rhs: []ast.Expr{
+											&ast.CallExpr{
+												Fun: &ast.SelectorExpr{
+													X: &ast.CallExpr{
+														Fun: ast.NewIdent("NewInvalidFormError"),
+													},
+													Sel: ast.NewIdent("WithCause"),
+												},
+												Args: []ast.Expr{
+													ast.NewIdent("err"),
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+						addParam("error", &ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("err"),
+								Sel: ast.NewIdent("Error"),
+							},
+						}),
+						&ast.IfStmt{
+							Cond: &ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   ast.NewIdent("errors"),
+									Sel: ast.NewIdent("Is"),
+								},
+								Args: []ast.Expr{
+									ast.NewIdent("err"),
+									&ast.SelectorExpr{
+										X:   ast.NewIdent("mongo"),
+										Sel: ast.NewIdent("ErrNoDocuments"),
+									},
+								},
+							},
+							Body: &ast.BlockStmt{
+								List: []ast.Stmt{
+									&ast.AssignStmt{
+										Lhs: []ast.Expr{
+											ast.NewIdent("e"),
+										},
+										Tok: token.ASSIGN,
+										Rhs: []ast.Expr{
+											&ast.CallExpr{
+												Fun: ast.NewIdent("NewEntityNotFoundError"),
+											},
+										},
+									},
+								},
+							},
+						},
+						&ast.ReturnStmt{
+							Results: []ast.Expr{
+								ast.NewIdent("e"),
+							},
+						},
+					},
+				},
+			},
+		},
+		Imports: imports,
+	}
+}
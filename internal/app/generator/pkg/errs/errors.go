@@ -664,6 +664,39 @@ func (i Generator) file() *ast.File {
 					},
 				},
 			},
+			&ast.FuncDecl{
+				Name: ast.NewIdent("NewResourceExhaustedError"),
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{},
+					Results: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Type: &ast.StarExpr{
+									X: ast.NewIdent("Error"),
+								},
+							},
+						},
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ReturnStmt{
+							Results: []ast.Expr{
+								&ast.CallExpr{
+									Fun: ast.NewIdent("NewError"),
+									Args: []ast.Expr{
+										ast.NewIdent("ErrorCodeResourceExhausted"),
+										&ast.BasicLit{
+											Kind:  token.STRING,
+											Value: `"Too many requests."`,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			&ast.FuncDecl{
 				Name: ast.NewIdent("NewPermissionDeniedError"),
 				Type: &ast.FuncType{
@@ -1835,7 +1868,7 @@ func (i Generator) fileHttp() *ast.File {
 													&ast.SelectorExpr{
 														X: ast.NewIdent("http"),
 														Sel: ast.NewIdent(
-															"StatusInternalServerError",
+															"StatusTooManyRequests",
 														),
 													},
 												},
@@ -3343,9 +3376,14 @@ func (i Generator) Sync() error {
 			return err
 		}
 	}
-	if err := i.syncPostgres(); err != nil {
+	if err := i.syncDatabase(); err != nil {
 		return err
 	}
+	if i.project.MongoEnabled() {
+		if err := i.syncDriver("mongo", i.fileMongo); err != nil {
+			return err
+		}
+	}
 	if i.project.KafkaEnabled {
 		if err := i.syncKafka(); err != nil {
 			return err
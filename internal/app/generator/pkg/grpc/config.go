@@ -21,10 +21,218 @@ func NewConfig(project *configs.Project) *Config {
 }
 
 func (u Config) file() *ast.File {
+	configFields := []*ast.Field{
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("Address"),
+			},
+			Type: ast.NewIdent("string"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"GRPC_ADDRESS\" toml:\"address\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("MaxRecvMsgSize"),
+			},
+			Type: ast.NewIdent("int"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"GRPC_MAX_RECV_MSG_SIZE\" toml:\"max_recv_msg_size\" env-default:\"4194304\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("MaxSendMsgSize"),
+			},
+			Type: ast.NewIdent("int"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"GRPC_MAX_SEND_MSG_SIZE\" toml:\"max_send_msg_size\" env-default:\"4194304\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("GracefulStopTimeout"),
+			},
+			Type: &ast.SelectorExpr{
+				X:   ast.NewIdent("time"),
+				Sel: ast.NewIdent("Duration"),
+			},
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"GRPC_GRACEFUL_STOP_TIMEOUT\" toml:\"graceful_stop_timeout\" env-default:\"10s\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("Keepalive"),
+			},
+			Type: ast.NewIdent("KeepaliveConfig"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`toml:\"keepalive\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("TLS"),
+			},
+			Type: ast.NewIdent("TLSConfig"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`toml:\"tls\"`",
+			},
+		},
+	}
+	keepaliveFields := []*ast.Field{
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("MinTime"),
+			},
+			Type: &ast.SelectorExpr{
+				X:   ast.NewIdent("time"),
+				Sel: ast.NewIdent("Duration"),
+			},
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"GRPC_KEEPALIVE_MIN_TIME\" toml:\"min_time\" env-default:\"5m\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("PermitWithoutStream"),
+			},
+			Type: ast.NewIdent("bool"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"GRPC_KEEPALIVE_PERMIT_WITHOUT_STREAM\" toml:\"permit_without_stream\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("Time"),
+			},
+			Type: &ast.SelectorExpr{
+				X:   ast.NewIdent("time"),
+				Sel: ast.NewIdent("Duration"),
+			},
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"GRPC_KEEPALIVE_TIME\" toml:\"time\" env-default:\"2h\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("Timeout"),
+			},
+			Type: &ast.SelectorExpr{
+				X:   ast.NewIdent("time"),
+				Sel: ast.NewIdent("Duration"),
+			},
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"GRPC_KEEPALIVE_TIMEOUT\" toml:\"timeout\" env-default:\"20s\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("MaxConnectionIdle"),
+			},
+			Type: &ast.SelectorExpr{
+				X:   ast.NewIdent("time"),
+				Sel: ast.NewIdent("Duration"),
+			},
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"GRPC_KEEPALIVE_MAX_CONNECTION_IDLE\" toml:\"max_connection_idle\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("MaxConnectionAge"),
+			},
+			Type: &ast.SelectorExpr{
+				X:   ast.NewIdent("time"),
+				Sel: ast.NewIdent("Duration"),
+			},
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"GRPC_KEEPALIVE_MAX_CONNECTION_AGE\" toml:\"max_connection_age\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("MaxConnectionAgeGrace"),
+			},
+			Type: &ast.SelectorExpr{
+				X:   ast.NewIdent("time"),
+				Sel: ast.NewIdent("Duration"),
+			},
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"GRPC_KEEPALIVE_MAX_CONNECTION_AGE_GRACE\" toml:\"max_connection_age_grace\"`",
+			},
+		},
+	}
+	tlsFields := []*ast.Field{
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("Enabled"),
+			},
+			Type: ast.NewIdent("bool"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"GRPC_TLS_ENABLED\" toml:\"enabled\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("CertFile"),
+			},
+			Type: ast.NewIdent("string"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"GRPC_TLS_CERT_FILE\" toml:\"cert_file\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("KeyFile"),
+			},
+			Type: ast.NewIdent("string"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"GRPC_TLS_KEY_FILE\" toml:\"key_file\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("ClientCAFile"),
+			},
+			Type: ast.NewIdent("string"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"GRPC_TLS_CLIENT_CA_FILE\" toml:\"client_ca_file\"`",
+			},
+		},
+	}
 	return &ast.File{
 		Package: 1,
 		Name:    ast.NewIdent("grpc"),
 		Decls: []ast.Decl{
+			&ast.GenDecl{
+				Tok: token.IMPORT,
+				Specs: []ast.Spec{
+					&ast.ImportSpec{
+						Path: &ast.BasicLit{
+							Kind:  token.STRING,
+							Value: `"time"`,
+						},
+					},
+				},
+			},
 			&ast.GenDecl{
 				Tok: token.TYPE,
 				Specs: []ast.Spec{
@@ -32,18 +240,33 @@ func (u Config) file() *ast.File {
 						Name: ast.NewIdent("Config"),
 						Type: &ast.StructType{
 							Fields: &ast.FieldList{
-								List: []*ast.Field{
-									{
-										Names: []*ast.Ident{
-											ast.NewIdent("Address"),
-										},
-										Type: ast.NewIdent("string"),
-										Tag: &ast.BasicLit{
-											Kind:  token.STRING,
-											Value: "`toml:\"address\"`",
-										},
-									},
-								},
+								List: configFields,
+							},
+						},
+					},
+				},
+			},
+			&ast.GenDecl{
+				Tok: token.TYPE,
+				Specs: []ast.Spec{
+					&ast.TypeSpec{
+						Name: ast.NewIdent("KeepaliveConfig"),
+						Type: &ast.StructType{
+							Fields: &ast.FieldList{
+								List: keepaliveFields,
+							},
+						},
+					},
+				},
+			},
+			&ast.GenDecl{
+				Tok: token.TYPE,
+				Specs: []ast.Spec{
+					&ast.TypeSpec{
+						Name: ast.NewIdent("TLSConfig"),
+						Type: &ast.StructType{
+							Fields: &ast.FieldList{
+								List: tlsFields,
 							},
 						},
 					},
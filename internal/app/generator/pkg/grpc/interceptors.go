@@ -0,0 +1,37 @@
+package grpc
+
+import (
+	"path"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+type Interceptors struct {
+	project *configs.Project
+}
+
+func NewInterceptors(project *configs.Project) *Interceptors {
+	return &Interceptors{project: project}
+}
+
+func (i Interceptors) Sync() error {
+	files := []*tmpl.Template{
+		{
+			SourcePath:      "templates/internal/pkg/grpc/interceptors.go.tmpl",
+			DestinationPath: path.Join("internal", "pkg", "grpc", "interceptors.go"),
+			Name:            "grpc interceptors and server options",
+		},
+		{
+			SourcePath:      "templates/internal/pkg/grpc/interceptors_test.go.tmpl",
+			DestinationPath: path.Join("internal", "pkg", "grpc", "interceptors_test.go"),
+			Name:            "grpc interceptors tests",
+		},
+	}
+	for _, file := range files {
+		if err := file.RenderToFile(i.project); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -149,6 +149,28 @@ func (u Server) file() *ast.File {
 											},
 										},
 									},
+									{
+										Names: []*ast.Ident{
+											ast.NewIdent("streamInterceptors"),
+										},
+										Type: &ast.ArrayType{
+											Elt: &ast.SelectorExpr{
+												X:   ast.NewIdent("grpc"),
+												Sel: ast.NewIdent("StreamServerInterceptor"),
+											},
+										},
+									},
+									{
+										Names: []*ast.Ident{
+											ast.NewIdent("health"),
+										},
+										Type: &ast.StarExpr{
+											X: &ast.SelectorExpr{
+												X:   ast.NewIdent("health"),
+												Sel: ast.NewIdent("Server"),
+											},
+										},
+									},
 								},
 							},
 						},
@@ -224,6 +246,15 @@ func (u Server) file() *ast.File {
 													},
 												},
 											},
+											&ast.KeyValueExpr{
+												Key: ast.NewIdent("health"),
+												Value: &ast.CallExpr{
+													Fun: &ast.SelectorExpr{
+														X:   ast.NewIdent("health"),
+														Sel: ast.NewIdent("NewServer"),
+													},
+												},
+											},
 											&ast.KeyValueExpr{
 												Key: ast.NewIdent("unaryInterceptors"),
 												Value: &ast.CompositeLit{
@@ -268,6 +299,65 @@ func (u Server) file() *ast.File {
 																},
 															},
 														},
+														&ast.CallExpr{
+															Fun: ast.NewIdent("unaryRecoveryServerInterceptor"),
+															Args: []ast.Expr{
+																ast.NewIdent("logger"),
+															},
+														},
+													},
+												},
+											},
+											&ast.KeyValueExpr{
+												Key: ast.NewIdent("streamInterceptors"),
+												Value: &ast.CompositeLit{
+													Type: &ast.ArrayType{
+														Elt: &ast.SelectorExpr{
+															X: ast.NewIdent("grpc"),
+															Sel: ast.NewIdent(
+																"StreamServerInterceptor",
+															),
+														},
+													},
+													Elts: []ast.Expr{
+														ast.NewIdent("streamErrorServerInterceptor"),
+														&ast.CallExpr{
+															Fun: &ast.SelectorExpr{
+																X: ast.NewIdent("grpc_zap"),
+																Sel: ast.NewIdent(
+																	"StreamServerInterceptor",
+																),
+															},
+															Args: []ast.Expr{
+																&ast.CallExpr{
+																	Fun: &ast.SelectorExpr{
+																		X:   ast.NewIdent("logger"),
+																		Sel: ast.NewIdent("Logger"),
+																	},
+																},
+																&ast.CallExpr{
+																	Fun: &ast.SelectorExpr{
+																		X: ast.NewIdent(
+																			"grpc_zap",
+																		),
+																		Sel: ast.NewIdent(
+																			"WithMessageProducer",
+																		),
+																	},
+																	Args: []ast.Expr{
+																		ast.NewIdent(
+																			"defaultMessageProducer",
+																		),
+																	},
+																},
+															},
+														},
+														&ast.CallExpr{
+															Fun: ast.NewIdent("streamRecoveryServerInterceptor"),
+															Args: []ast.Expr{
+																ast.NewIdent("logger"),
+															},
+														},
 													},
 												},
 											},
@@ -317,6 +407,40 @@ func (u Server) file() *ast.File {
 				},
 				Body: &ast.BlockStmt{
 					List: []ast.Stmt{
+						&ast.AssignStmt{
+							Lhs: []ast.Expr{
+								ast.NewIdent("options"),
+								ast.NewIdent("err"),
+							},
+							Tok: token.DEFINE,
+							Rhs: []ast.Expr{
+								&ast.CallExpr{
+									Fun: ast.NewIdent("serverOptions"),
+									Args: []ast.Expr{
+										&ast.SelectorExpr{
+											X:   ast.NewIdent("s"),
+											Sel: ast.NewIdent("config"),
+										},
+									},
+								},
+							},
+						},
+						&ast.IfStmt{
+							Cond: &ast.BinaryExpr{
+								X:  ast.NewIdent("err"),
+								Op: token.NEQ,
+								Y:  ast.NewIdent("nil"),
+							},
+							Body: &ast.BlockStmt{
+								List: []ast.Stmt{
+									&ast.ReturnStmt{
+										Results: []ast.Expr{
+											ast.NewIdent("err"),
+										},
+									},
+								},
+							},
+						},
 						&ast.AssignStmt{
 							Lhs: []ast.Expr{
 								&ast.SelectorExpr{
@@ -333,34 +457,54 @@ func (u Server) file() *ast.File {
 									},
 									Args: []ast.Expr{
 										&ast.CallExpr{
-											Fun: &ast.SelectorExpr{
-												X:   ast.NewIdent("grpc"),
-												Sel: ast.NewIdent("ChainUnaryInterceptor"),
-											},
+											Fun: ast.NewIdent("append"),
 											Args: []ast.Expr{
-												&ast.SelectorExpr{
-													X:   ast.NewIdent("s"),
-													Sel: ast.NewIdent("unaryInterceptors"),
+												ast.NewIdent("options"),
+												&ast.CallExpr{
+													Fun: &ast.SelectorExpr{
+														X:   ast.NewIdent("grpc"),
+														Sel: ast.NewIdent("ChainUnaryInterceptor"),
+													},
+													Args: []ast.Expr{
+														&ast.SelectorExpr{
+															X:   ast.NewIdent("s"),
+															Sel: ast.NewIdent("unaryInterceptors"),
+														},
+													},
+													Ellipsis: 1180,
 												},
-											},
-											Ellipsis: 1180,
-										},
-										&ast.CallExpr{
-											Fun: &ast.SelectorExpr{
-												X:   ast.NewIdent("grpc"),
-												Sel: ast.NewIdent("StatsHandler"),
-											},
-											Args: []ast.Expr{
 												&ast.CallExpr{
 													Fun: &ast.SelectorExpr{
-														X:   ast.NewIdent("otelgrpc"),
-														Sel: ast.NewIdent("NewServerHandler"),
+														X:   ast.NewIdent("grpc"),
+														Sel: ast.NewIdent("ChainStreamInterceptor"),
+													},
+													Args: []ast.Expr{
+														&ast.SelectorExpr{
+															X:   ast.NewIdent("s"),
+															Sel: ast.NewIdent("streamInterceptors"),
+														},
+													},
+													Ellipsis: 1180,
+												},
+												&ast.CallExpr{
+													Fun: &ast.SelectorExpr{
+														X:   ast.NewIdent("grpc"),
+														Sel: ast.NewIdent("StatsHandler"),
+													},
+													Args: []ast.Expr{
+														&ast.CallExpr{
+															Fun: &ast.SelectorExpr{
+																X:   ast.NewIdent("otelgrpc"),
+																Sel: ast.NewIdent("NewServerHandler"),
+															},
+															Args: []ast.Expr{},
+														},
 													},
-													Args: []ast.Expr{},
 												},
 											},
 										},
 									},
+									Ellipsis: 1180,
 								},
 							},
 						},
@@ -406,20 +550,6 @@ func (u Server) file() *ast.File {
 								},
 							},
 						},
-						&ast.AssignStmt{
-							Lhs: []ast.Expr{
-								ast.NewIdent("healthServer"),
-							},
-							Tok: token.DEFINE,
-							Rhs: []ast.Expr{
-								&ast.CallExpr{
-									Fun: &ast.SelectorExpr{
-										X:   ast.NewIdent("health"),
-										Sel: ast.NewIdent("NewServer"),
-									},
-								},
-							},
-						},
 						&ast.RangeStmt{
 							Key: ast.NewIdent("service"),
 							Tok: token.DEFINE,
@@ -437,7 +567,10 @@ func (u Server) file() *ast.File {
 									&ast.ExprStmt{
 										X: &ast.CallExpr{
 											Fun: &ast.SelectorExpr{
-												X:   ast.NewIdent("healthServer"),
+												X: &ast.SelectorExpr{
+													X:   ast.NewIdent("s"),
+													Sel: ast.NewIdent("health"),
+												},
 												Sel: ast.NewIdent("SetServingStatus"),
 											},
 											Args: []ast.Expr{
@@ -465,7 +598,10 @@ func (u Server) file() *ast.File {
 										X:   ast.NewIdent("s"),
 										Sel: ast.NewIdent("server"),
 									},
-									ast.NewIdent("healthServer"),
+									&ast.SelectorExpr{
+										X:   ast.NewIdent("s"),
+										Sel: ast.NewIdent("health"),
+									},
 								},
 							},
 						},
@@ -551,7 +687,7 @@ func (u Server) file() *ast.File {
 						List: []*ast.Field{
 							{
 								Names: []*ast.Ident{
-									ast.NewIdent("_"),
+									ast.NewIdent("ctx"),
 								},
 								Type: &ast.SelectorExpr{
 									X:   ast.NewIdent("context"),
@@ -574,10 +710,52 @@ func (u Server) file() *ast.File {
 							X: &ast.CallExpr{
 								Fun: &ast.SelectorExpr{
 									X: &ast.SelectorExpr{
+										X:   ast.NewIdent("s"),
+										Sel: ast.NewIdent("health"),
+									},
+									Sel: ast.NewIdent("Shutdown"),
+								},
+							},
+						},
+						&ast.AssignStmt{
+							Lhs: []ast.Expr{
+								ast.NewIdent("ctx"),
+								ast.NewIdent("cancel"),
+							},
+							Tok: token.DEFINE,
+							Rhs: []ast.Expr{
+								&ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X:   ast.NewIdent("context"),
+										Sel: ast.NewIdent("WithTimeout"),
+									},
+									Args: []ast.Expr{
+										ast.NewIdent("ctx"),
+										&ast.SelectorExpr{
+											X: &ast.SelectorExpr{
+												X:   ast.NewIdent("s"),
+												Sel: ast.NewIdent("config"),
+											},
+											Sel: ast.NewIdent("GracefulStopTimeout"),
+										},
+									},
+								},
+							},
+						},
+						&ast.DeferStmt{
+							Call: &ast.CallExpr{
+								Fun: ast.NewIdent("cancel"),
+							},
+						},
+						&ast.ExprStmt{
+							X: &ast.CallExpr{
+								Fun: ast.NewIdent("gracefulStop"),
+								Args: []ast.Expr{
+									ast.NewIdent("ctx"),
+									&ast.SelectorExpr{
 										X:   ast.NewIdent("s"),
 										Sel: ast.NewIdent("server"),
 									},
-									Sel: ast.NewIdent("GracefulStop"),
 								},
 							},
 						},
@@ -701,6 +879,122 @@ func (u Server) file() *ast.File {
 					},
 				},
 			},
+			u.addStreamInterceptor(),
+			u.setServingStatus(),
+		},
+	}
+}
+
+// setServingStatus reports the readiness of the whole server and every registered service to health clients.
+func (u Server) setServingStatus() *ast.FuncDecl {
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{
+						ast.NewIdent("s"),
+					},
+					Type: &ast.StarExpr{
+						X: ast.NewIdent("Server"),
+					},
+				},
+			},
+		},
+		Name: ast.NewIdent("SetServingStatus"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							ast.NewIdent("serving"),
+						},
+						Type: ast.NewIdent("bool"),
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						ast.NewIdent("status"),
+					},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.SelectorExpr{
+							X:   ast.NewIdent("grpc_health_v1"),
+							Sel: ast.NewIdent("HealthCheckResponse_NOT_SERVING"),
+						},
+					},
+				},
+				&ast.IfStmt{
+					Cond: ast.NewIdent("serving"),
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.AssignStmt{
+								Lhs: []ast.Expr{
+									ast.NewIdent("status"),
+								},
+								Tok: token.ASSIGN,
+								Rhs: []ast.Expr{
+									&ast.SelectorExpr{
+										X:   ast.NewIdent("grpc_health_v1"),
+										Sel: ast.NewIdent("HealthCheckResponse_SERVING"),
+									},
+								},
+							},
+						},
+					},
+				},
+				&ast.ExprStmt{
+					X: &ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X: &ast.SelectorExpr{
+								X:   ast.NewIdent("s"),
+								Sel: ast.NewIdent("health"),
+							},
+							Sel: ast.NewIdent("SetServingStatus"),
+						},
+						Args: []ast.Expr{
+							&ast.BasicLit{
+								Kind:  token.STRING,
+								Value: `""`,
+							},
+							ast.NewIdent("status"),
+						},
+					},
+				},
+				&ast.RangeStmt{
+					Key: ast.NewIdent("sd"),
+					Tok: token.DEFINE,
+					X: &ast.SelectorExpr{
+						X:   ast.NewIdent("s"),
+						Sel: ast.NewIdent("handlers"),
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X: &ast.SelectorExpr{
+											X:   ast.NewIdent("s"),
+											Sel: ast.NewIdent("health"),
+										},
+										Sel: ast.NewIdent("SetServingStatus"),
+									},
+									Args: []ast.Expr{
+										&ast.SelectorExpr{
+											X:   ast.NewIdent("sd"),
+											Sel: ast.NewIdent("ServiceName"),
+										},
+										ast.NewIdent("status"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -724,3 +1018,61 @@ func (u Server) Sync() error {
 	}
 	return nil
 }
+
+func (u Server) addStreamInterceptor() *ast.FuncDecl {
+	return &ast.FuncDecl{
+		Recv: &ast.FieldList{
+			List: []*ast.Field{
+				{
+					Names: []*ast.Ident{
+						ast.NewIdent("s"),
+					},
+					Type: &ast.StarExpr{
+						X: ast.NewIdent("Server"),
+					},
+				},
+			},
+		},
+		Name: ast.NewIdent("AddStreamInterceptor"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							ast.NewIdent("interceptor"),
+						},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("grpc"),
+							Sel: ast.NewIdent("StreamServerInterceptor"),
+						},
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{
+						&ast.SelectorExpr{
+							X:   ast.NewIdent("s"),
+							Sel: ast.NewIdent("streamInterceptors"),
+						},
+					},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun: ast.NewIdent("append"),
+							Args: []ast.Expr{
+								&ast.SelectorExpr{
+									X:   ast.NewIdent("s"),
+									Sel: ast.NewIdent("streamInterceptors"),
+								},
+								ast.NewIdent("interceptor"),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
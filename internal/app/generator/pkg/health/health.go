@@ -0,0 +1,57 @@
+package health
+
+import (
+	"path"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+var destinationPath = "."
+
+type Generator struct {
+	project *configs.Project
+}
+
+func NewGenerator(project *configs.Project) *Generator {
+	return &Generator{project: project}
+}
+
+func (g *Generator) Sync() error {
+	files := []*tmpl.Template{
+		{
+			SourcePath:      "templates/internal/pkg/health/health.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "health", "health.go"),
+			Name:            "health endpoints",
+		},
+		{
+			SourcePath:      "templates/internal/pkg/health/checkers.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "health", "checkers.go"),
+			Name:            "health checkers",
+		},
+		{
+			SourcePath: "templates/internal/pkg/health/health_test.go.tmpl",
+			DestinationPath: path.Join(
+				destinationPath,
+				"internal",
+				"pkg",
+				"health",
+				"health_test.go",
+			),
+			Name: "health tests",
+		},
+	}
+	if !g.project.HTTPEnabled {
+		files = append(files, &tmpl.Template{
+			SourcePath:      "templates/internal/pkg/health/server.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "health", "server.go"),
+			Name:            "health admin server",
+		})
+	}
+	for _, file := range files {
+		if err := file.RenderToFile(g.project); err != nil {
+			return err
+		}
+	}
+	return nil
+}
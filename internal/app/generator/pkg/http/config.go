@@ -21,10 +21,182 @@ func NewConfig(project *configs.Project) *Config {
 }
 
 func (u Config) file() *ast.File {
+	configFields := []*ast.Field{
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("Address"),
+			},
+			Type: ast.NewIdent("string"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"HTTP_ADDRESS\" toml:\"address\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("RequestIDHeader"),
+			},
+			Type: ast.NewIdent("string"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"HTTP_REQUEST_ID_HEADER\" toml:\"request_id_header\" env-default:\"X-Request-ID\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("Timeout"),
+			},
+			Type: &ast.SelectorExpr{
+				X:   ast.NewIdent("time"),
+				Sel: ast.NewIdent("Duration"),
+			},
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"HTTP_TIMEOUT\" toml:\"timeout\" env-default:\"30s\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("Timeouts"),
+			},
+			Type: &ast.MapType{
+				Key: ast.NewIdent("string"),
+				Value: &ast.SelectorExpr{
+					X:   ast.NewIdent("time"),
+					Sel: ast.NewIdent("Duration"),
+				},
+			},
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`toml:\"timeouts\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("MaxBodySize"),
+			},
+			Type: ast.NewIdent("int64"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"HTTP_MAX_BODY_SIZE\" toml:\"max_body_size\" env-default:\"4194304\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("Gzip"),
+			},
+			Type: ast.NewIdent("bool"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"HTTP_GZIP\" toml:\"gzip\" env-default:\"true\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("GzipLevel"),
+			},
+			Type: ast.NewIdent("int"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"HTTP_GZIP_LEVEL\" toml:\"gzip_level\" env-default:\"5\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("CORS"),
+			},
+			Type: ast.NewIdent("CORSConfig"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`toml:\"cors\"`",
+			},
+		},
+	}
+	corsFields := []*ast.Field{
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("AllowedOrigins"),
+			},
+			Type: &ast.ArrayType{
+				Elt: ast.NewIdent("string"),
+			},
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"HTTP_CORS_ALLOWED_ORIGINS\" toml:\"allowed_origins\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("AllowedMethods"),
+			},
+			Type: &ast.ArrayType{
+				Elt: ast.NewIdent("string"),
+			},
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"HTTP_CORS_ALLOWED_METHODS\" toml:\"allowed_methods\" env-default:\"GET,POST,PUT,PATCH,DELETE,OPTIONS\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("AllowedHeaders"),
+			},
+			Type: &ast.ArrayType{
+				Elt: ast.NewIdent("string"),
+			},
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"HTTP_CORS_ALLOWED_HEADERS\" toml:\"allowed_headers\" env-default:\"Accept,Authorization,Content-Type,X-Request-ID\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("ExposedHeaders"),
+			},
+			Type: &ast.ArrayType{
+				Elt: ast.NewIdent("string"),
+			},
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"HTTP_CORS_EXPOSED_HEADERS\" toml:\"exposed_headers\" env-default:\"X-Request-ID\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("AllowCredentials"),
+			},
+			Type: ast.NewIdent("bool"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"HTTP_CORS_ALLOW_CREDENTIALS\" toml:\"allow_credentials\"`",
+			},
+		},
+		{
+			Names: []*ast.Ident{
+				ast.NewIdent("MaxAge"),
+			},
+			Type: ast.NewIdent("int"),
+			Tag: &ast.BasicLit{
+				Kind:  token.STRING,
+				Value: "`env:\"HTTP_CORS_MAX_AGE\" toml:\"max_age\" env-default:\"300\"`",
+			},
+		},
+	}
 	return &ast.File{
 		Package: 1,
 		Name:    ast.NewIdent("http"),
 		Decls: []ast.Decl{
+			&ast.GenDecl{
+				Tok: token.IMPORT,
+				Specs: []ast.Spec{
+					&ast.ImportSpec{
+						Path: &ast.BasicLit{
+							Kind:  token.STRING,
+							Value: `"time"`,
+						},
+					},
+				},
+			},
 			&ast.GenDecl{
 				Tok: token.TYPE,
 				Specs: []ast.Spec{
@@ -32,18 +204,20 @@ func (u Config) file() *ast.File {
 						Name: ast.NewIdent("Config"),
 						Type: &ast.StructType{
 							Fields: &ast.FieldList{
-								List: []*ast.Field{
-									{
-										Names: []*ast.Ident{
-											ast.NewIdent("Address"),
-										},
-										Type: ast.NewIdent("string"),
-										Tag: &ast.BasicLit{
-											Kind:  token.STRING,
-											Value: "`toml:\"address\"`",
-										},
-									},
-								},
+								List: configFields,
+							},
+						},
+					},
+				},
+			},
+			&ast.GenDecl{
+				Tok: token.TYPE,
+				Specs: []ast.Spec{
+					&ast.TypeSpec{
+						Name: ast.NewIdent("CORSConfig"),
+						Type: &ast.StructType{
+							Fields: &ast.FieldList{
+								List: corsFields,
 							},
 						},
 					},
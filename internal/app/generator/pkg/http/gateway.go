@@ -0,0 +1,28 @@
+package http
+
+import (
+	"path"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+type Gateway struct {
+	project *configs.Project
+}
+
+func NewGateway(project *configs.Project) *Gateway {
+	return &Gateway{project: project}
+}
+
+func (g Gateway) Sync() error {
+	file := &tmpl.Template{
+		SourcePath:      "templates/internal/pkg/http/gateway.go.tmpl",
+		DestinationPath: path.Join("internal", "pkg", "http", "gateway.go"),
+		Name:            "grpc-gateway mux",
+	}
+	if err := file.RenderToFile(g.project); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package http
+
+import (
+	"path"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+type Middlewares struct {
+	project *configs.Project
+}
+
+func NewMiddlewares(project *configs.Project) *Middlewares {
+	return &Middlewares{project: project}
+}
+
+func (m Middlewares) Sync() error {
+	files := []*tmpl.Template{
+		{
+			SourcePath:      "templates/internal/pkg/http/middlewares.go.tmpl",
+			DestinationPath: path.Join("internal", "pkg", "http", "middlewares.go"),
+			Name:            "http middlewares",
+		},
+		{
+			SourcePath:      "templates/internal/pkg/http/middlewares_test.go.tmpl",
+			DestinationPath: path.Join("internal", "pkg", "http", "middlewares_test.go"),
+			Name:            "http middlewares tests",
+		},
+	}
+	for _, file := range files {
+		if err := file.RenderToFile(m.project); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -47,6 +47,18 @@ func (u Server) file() *ast.File {
 							Value: "\"github.com/go-chi/chi/v5\"",
 						},
 					},
+					&ast.ImportSpec{
+						Path: &ast.BasicLit{
+							Kind:  token.STRING,
+							Value: "\"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp\"",
+						},
+					},
+					&ast.ImportSpec{
+						Path: &ast.BasicLit{
+							Kind:  token.STRING,
+							Value: "\"go.opentelemetry.io/otel/trace\"",
+						},
+					},
 				},
 			},
 			&ast.GenDecl{
@@ -188,22 +200,7 @@ func (u Server) file() *ast.File {
 									},
 								},
 								Args: []ast.Expr{
-									&ast.CallExpr{
-										Fun: &ast.SelectorExpr{
-											X: &ast.Ident{
-												Name: "otelchi",
-											},
-											Sel: &ast.Ident{
-												Name: "Middleware",
-											},
-										},
-										Args: []ast.Expr{
-											&ast.BasicLit{
-												Kind:  token.STRING,
-												Value: fmt.Sprintf(`"%s"`, u.project.Name),
-											},
-										},
-									},
+									ast.NewIdent("routeMiddleware"),
 								},
 							},
 						},
@@ -219,12 +216,14 @@ func (u Server) file() *ast.File {
 								},
 								Args: []ast.Expr{
 									&ast.CallExpr{
-										Fun: ast.NewIdent("loggerMiddleware"),
+										Fun: ast.NewIdent("middlewares"),
 										Args: []ast.Expr{
+											ast.NewIdent("config"),
 											ast.NewIdent("logger"),
 										},
 									},
 								},
+								Ellipsis: 1,
 							},
 						},
 						&ast.AssignStmt{
@@ -249,8 +248,20 @@ func (u Server) file() *ast.File {
 												},
 											},
 											&ast.KeyValueExpr{
-												Key:   ast.NewIdent("Handler"),
-												Value: ast.NewIdent("router"),
+												Key: ast.NewIdent("Handler"),
+												Value: &ast.CallExpr{
+													Fun: &ast.SelectorExpr{
+														X:   ast.NewIdent("otelhttp"),
+														Sel: ast.NewIdent("NewHandler"),
+													},
+													Args: []ast.Expr{
+														ast.NewIdent("router"),
+														&ast.BasicLit{
+															Kind:  token.STRING,
+															Value: fmt.Sprintf(`"%s"`, u.project.Name),
+														},
+													},
+												},
 											},
 										},
 									},
@@ -454,6 +465,76 @@ func (u Server) file() *ast.File {
 					},
 				},
 			},
+			&ast.FuncDecl{
+				Recv: &ast.FieldList{
+					List: []*ast.Field{
+						{
+							Names: []*ast.Ident{
+								ast.NewIdent("s"),
+							},
+							Type: &ast.StarExpr{
+								X: ast.NewIdent("Server"),
+							},
+						},
+					},
+				},
+				Name: ast.NewIdent("Use"),
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									ast.NewIdent("middlewares"),
+								},
+								Type: &ast.Ellipsis{
+									Elt: &ast.FuncType{
+										Params: &ast.FieldList{
+											List: []*ast.Field{
+												{
+													Type: &ast.SelectorExpr{
+														X:   ast.NewIdent("http"),
+														Sel: ast.NewIdent("Handler"),
+													},
+												},
+											},
+										},
+										Results: &ast.FieldList{
+											List: []*ast.Field{
+												{
+													Type: &ast.SelectorExpr{
+														X:   ast.NewIdent("http"),
+														Sel: ast.NewIdent("Handler"),
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ExprStmt{
+							X: &ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X: &ast.SelectorExpr{
+										X:   ast.NewIdent("s"),
+										Sel: ast.NewIdent("router"),
+									},
+									Sel: ast.NewIdent("Use"),
+								},
+								Args: []ast.Expr{
+									ast.NewIdent("middlewares"),
+								},
+								Ellipsis: 1,
+							},
+						},
+					},
+				},
+			},
+			u.routeMiddleware(),
 			&ast.FuncDecl{
 				Name: &ast.Ident{
 					Name: "loggerMiddleware",
@@ -941,6 +1022,177 @@ func (u Server) file() *ast.File {
 	}
 }
 
+// routeMiddleware renames the otelhttp span after the matched chi route once the request is routed.
+func (u Server) routeMiddleware() *ast.FuncDecl {
+	return &ast.FuncDecl{
+		Name: ast.NewIdent("routeMiddleware"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Names: []*ast.Ident{
+							ast.NewIdent("next"),
+						},
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("http"),
+							Sel: ast.NewIdent("Handler"),
+						},
+					},
+				},
+			},
+			Results: &ast.FieldList{
+				List: []*ast.Field{
+					{
+						Type: &ast.SelectorExpr{
+							X:   ast.NewIdent("http"),
+							Sel: ast.NewIdent("Handler"),
+						},
+					},
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{
+					Results: []ast.Expr{
+						&ast.CallExpr{
+							Fun: &ast.SelectorExpr{
+								X:   ast.NewIdent("http"),
+								Sel: ast.NewIdent("HandlerFunc"),
+							},
+							Args: []ast.Expr{
+								&ast.FuncLit{
+									Type: &ast.FuncType{
+										Params: &ast.FieldList{
+											List: []*ast.Field{
+												{
+													Names: []*ast.Ident{
+														ast.NewIdent("w"),
+													},
+													Type: &ast.SelectorExpr{
+														X:   ast.NewIdent("http"),
+														Sel: ast.NewIdent("ResponseWriter"),
+													},
+												},
+												{
+													Names: []*ast.Ident{
+														ast.NewIdent("r"),
+													},
+													Type: &ast.StarExpr{
+														X: &ast.SelectorExpr{
+															X:   ast.NewIdent("http"),
+															Sel: ast.NewIdent("Request"),
+														},
+													},
+												},
+											},
+										},
+									},
+									Body: &ast.BlockStmt{
+										List: []ast.Stmt{
+											&ast.ExprStmt{
+												X: &ast.CallExpr{
+													Fun: &ast.SelectorExpr{
+														X:   ast.NewIdent("next"),
+														Sel: ast.NewIdent("ServeHTTP"),
+													},
+													Args: []ast.Expr{
+														ast.NewIdent("w"),
+														ast.NewIdent("r"),
+													},
+												},
+											},
+											&ast.IfStmt{
+												Init: &ast.AssignStmt{
+													Lhs: []ast.Expr{
+														ast.NewIdent("pattern"),
+													},
+													Tok: token.DEFINE,
+													Rhs: []ast.Expr{
+														&ast.CallExpr{
+															Fun: &ast.SelectorExpr{
+																X: &ast.CallExpr{
+																	Fun: &ast.SelectorExpr{
+																		X:   ast.NewIdent("chi"),
+																		Sel: ast.NewIdent("RouteContext"),
+																	},
+																	Args: []ast.Expr{
+																		&ast.CallExpr{
+																			Fun: &ast.SelectorExpr{
+																				X:   ast.NewIdent("r"),
+																				Sel: ast.NewIdent("Context"),
+																			},
+																		},
+																	},
+																},
+																Sel: ast.NewIdent("RoutePattern"),
+															},
+														},
+													},
+												},
+												Cond: &ast.BinaryExpr{
+													X:  ast.NewIdent("pattern"),
+													Op: token.NEQ,
+													Y: &ast.BasicLit{
+														Kind:  token.STRING,
+														Value: `""`,
+													},
+												},
+												Body: &ast.BlockStmt{
+													List: []ast.Stmt{
+														&ast.ExprStmt{
+															X: &ast.CallExpr{
+																Fun: &ast.SelectorExpr{
+																	X: &ast.CallExpr{
+																		Fun: &ast.SelectorExpr{
+																			X:   ast.NewIdent("trace"),
+																			Sel: ast.NewIdent("SpanFromContext"),
+																		},
+																		Args: []ast.Expr{
+																			&ast.CallExpr{
+																				Fun: &ast.SelectorExpr{
+																					X:   ast.NewIdent("r"),
+																					Sel: ast.NewIdent("Context"),
+																				},
+																			},
+																		},
+																	},
+																	Sel: ast.NewIdent("SetName"),
+																},
+																Args: []ast.Expr{
+																	&ast.BinaryExpr{
+																		X: &ast.BinaryExpr{
+																			X: &ast.SelectorExpr{
+																				X:   ast.NewIdent("r"),
+																				Sel: ast.NewIdent("Method"),
+																			},
+																			Op: token.ADD,
+																			Y: &ast.BasicLit{
+																				Kind:  token.STRING,
+																				Value: `" "`,
+																			},
+																		},
+																		Op: token.ADD,
+																		Y:  ast.NewIdent("pattern"),
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func (u Server) Sync() error {
 	fileset := token.NewFileSet()
 	filename := path.Join("internal", "pkg", "http", "server.go")
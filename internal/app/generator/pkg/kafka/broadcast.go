@@ -0,0 +1,463 @@
+package kafka
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/astfile"
+)
+
+// addBroadcasts - handlers without a group, the ones of NewBroadcastHandler, read every partition of their topic from
+// the newest offset with a sarama.Consumer. Every instance needs every message, a consumer group per instance would
+// be left behind in Kafka with its offsets on every restart.
+func (u ConsumerGenerator) addBroadcasts(file *ast.File) {
+	if typeSpec, ok := astfile.FindType(file, "Handler"); ok {
+		structType := typeSpec.Type.(*ast.StructType)
+		structType.Fields.List = append(structType.Fields.List, field("partitions", &ast.ArrayType{
+			Elt: selector("sarama", "PartitionConsumer"),
+		}))
+	}
+	if constructor, ok := astfile.FindFunc(file, "NewHandler"); ok {
+		if lit := findCompositeLit(constructor.Body, "Handler"); lit != nil {
+			lit.Elts = append(lit.Elts, &ast.KeyValueExpr{Key: ast.NewIdent("partitions"), Value: ast.NewIdent("nil")})
+		}
+	}
+	if typeSpec, ok := astfile.FindType(file, "Consumer"); ok {
+		structType := typeSpec.Type.(*ast.StructType)
+		structType.Fields.List = append(
+			structType.Fields.List,
+			field("consumer", selector("sarama", "Consumer")),
+			field("broadcasts", &ast.ArrayType{Elt: ast.NewIdent("Handler")}),
+		)
+	}
+	if constructor, ok := astfile.FindFunc(file, "NewConsumer"); ok {
+		if lit := findCompositeLit(constructor.Body, "Consumer"); lit != nil {
+			lit.Elts = append(
+				lit.Elts,
+				&ast.KeyValueExpr{Key: ast.NewIdent("consumer"), Value: ast.NewIdent("nil")},
+				&ast.KeyValueExpr{Key: ast.NewIdent("broadcasts"), Value: ast.NewIdent("nil")},
+			)
+		}
+	}
+	if addHandler, ok := astfile.FindFunc(file, "AddHandler"); ok {
+		addHandler.Body.List = append([]ast.Stmt{
+			&ast.IfStmt{
+				Cond: &ast.BinaryExpr{
+					X:  selector("handler", "GroupID"),
+					Op: token.EQL,
+					Y:  &ast.BasicLit{Kind: token.STRING, Value: `""`},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.AssignStmt{
+							Lhs: []ast.Expr{selector("c", "broadcasts")},
+							Tok: token.ASSIGN,
+							Rhs: []ast.Expr{
+								&ast.CallExpr{
+									Fun:  ast.NewIdent("append"),
+									Args: []ast.Expr{selector("c", "broadcasts"), ast.NewIdent("handler")},
+								},
+							},
+						},
+						&ast.ReturnStmt{},
+					},
+				},
+			},
+		}, addHandler.Body.List...)
+	}
+	if start, ok := astfile.FindFunc(file, "Start"); ok {
+		// the partitions are consumed before any goroutine starts, so a failure leaves nothing running
+		for i, stmt := range start.Body.List {
+			if assign, ok := stmt.(*ast.AssignStmt); ok && len(assign.Lhs) == 2 {
+				if ident, ok := assign.Lhs[0].(*ast.Ident); ok && ident.Name == "errorgroup" {
+					start.Body.List = slices.Insert(
+						start.Body.List,
+						i,
+						returnErr(&ast.CallExpr{Fun: selector("c", "consumePartitions")}),
+					)
+					break
+				}
+			}
+		}
+		last := len(start.Body.List) - 1
+		start.Body.List = append(
+			start.Body.List[:last],
+			&ast.RangeStmt{
+				Key:   ast.NewIdent("_"),
+				Value: ast.NewIdent("handler"),
+				Tok:   token.DEFINE,
+				X:     selector("c", "broadcasts"),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.RangeStmt{
+							Key:   ast.NewIdent("_"),
+							Value: ast.NewIdent("partition"),
+							Tok:   token.DEFINE,
+							X:     selector("handler", "partitions"),
+							Body: &ast.BlockStmt{
+								List: []ast.Stmt{
+									&ast.ExprStmt{
+										X: &ast.CallExpr{
+											Fun: selector("errorgroup", "Go"),
+											Args: []ast.Expr{
+												&ast.FuncLit{
+													Type: &ast.FuncType{
+														Params:  &ast.FieldList{},
+														Results: &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("error")}}},
+													},
+													Body: &ast.BlockStmt{
+														List: []ast.Stmt{
+															&ast.ExprStmt{
+																X: &ast.CallExpr{
+																	Fun: selector("c", "consumePartition"),
+																	Args: []ast.Expr{
+																		ast.NewIdent("partition"),
+																		selector("handler", "HandlerFunc"),
+																	},
+																},
+															},
+															&ast.ReturnStmt{Results: []ast.Expr{ast.NewIdent("nil")}},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			start.Body.List[last],
+		)
+	}
+	if stop, ok := astfile.FindFunc(file, "Stop"); ok {
+		last := len(stop.Body.List) - 1
+		stop.Body.List = append(
+			stop.Body.List[:last],
+			&ast.RangeStmt{
+				Key:   ast.NewIdent("_"),
+				Value: ast.NewIdent("handler"),
+				Tok:   token.DEFINE,
+				X:     selector("c", "broadcasts"),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.RangeStmt{
+							Key:   ast.NewIdent("_"),
+							Value: ast.NewIdent("partition"),
+							Tok:   token.DEFINE,
+							X:     selector("handler", "partitions"),
+							Body: &ast.BlockStmt{
+								List: []ast.Stmt{
+									returnErr(&ast.CallExpr{Fun: selector("partition", "Close")}),
+								},
+							},
+						},
+					},
+				},
+			},
+			&ast.IfStmt{
+				Cond: notNil("c.consumer"),
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						returnErr(&ast.CallExpr{Fun: selector("c.consumer", "Close")}),
+					},
+				},
+			},
+			stop.Body.List[last],
+		)
+	}
+	file.Decls = append(file.Decls, u.consumePartitions(), u.consumePartition())
+}
+
+// consumePartitions - Consumer.consumePartitions, starts a partition consumer for every partition of the broadcast
+// topics.
+func (u ConsumerGenerator) consumePartitions() *ast.FuncDecl {
+	unexpected := func(message string) ast.Stmt {
+		return &ast.IfStmt{
+			Cond: notNil("err"),
+			Body: &ast.BlockStmt{
+				List: []ast.Stmt{
+					&ast.ReturnStmt{
+						Results: []ast.Expr{
+							&ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X: &ast.CallExpr{
+										Fun: selector("errs", "NewUnexpectedBehaviorError"),
+										Args: []ast.Expr{
+											&ast.BasicLit{Kind: token.STRING, Value: `"` + message + `"`},
+										},
+									},
+									Sel: ast.NewIdent("WithCause"),
+								},
+								Args: []ast.Expr{ast.NewIdent("err")},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	return &ast.FuncDecl{
+		Recv: consumerReceiver(),
+		Name: ast.NewIdent("consumePartitions"),
+		Type: &ast.FuncType{
+			Params:  &ast.FieldList{},
+			Results: &ast.FieldList{List: []*ast.Field{{Type: ast.NewIdent("error")}}},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.IfStmt{
+					Cond: &ast.BinaryExpr{
+						X:  &ast.CallExpr{Fun: ast.NewIdent("len"), Args: []ast.Expr{selector("c", "broadcasts")}},
+						Op: token.EQL,
+						Y:  &ast.BasicLit{Kind: token.INT, Value: "0"},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ReturnStmt{Results: []ast.Expr{ast.NewIdent("nil")}},
+						},
+					},
+				},
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{ast.NewIdent("consumer"), ast.NewIdent("err")},
+					Tok: token.DEFINE,
+					Rhs: []ast.Expr{
+						&ast.CallExpr{
+							Fun:  selector("sarama", "NewConsumerFromClient"),
+							Args: []ast.Expr{selector("c", "client")},
+						},
+					},
+				},
+				unexpected("cant build kafka consumer"),
+				&ast.AssignStmt{
+					Lhs: []ast.Expr{selector("c", "consumer")},
+					Tok: token.ASSIGN,
+					Rhs: []ast.Expr{ast.NewIdent("consumer")},
+				},
+				&ast.RangeStmt{
+					Key:   ast.NewIdent("i"),
+					Value: ast.NewIdent("handler"),
+					Tok:   token.DEFINE,
+					X:     selector("c", "broadcasts"),
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.AssignStmt{
+								Lhs: []ast.Expr{ast.NewIdent("partitions"), ast.NewIdent("err")},
+								Tok: token.DEFINE,
+								Rhs: []ast.Expr{
+									&ast.CallExpr{
+										Fun:  selector("consumer", "Partitions"),
+										Args: []ast.Expr{selector("handler", "Topic")},
+									},
+								},
+							},
+							unexpected("cant get kafka partitions"),
+							&ast.RangeStmt{
+								Key:   ast.NewIdent("_"),
+								Value: ast.NewIdent("partition"),
+								Tok:   token.DEFINE,
+								X:     ast.NewIdent("partitions"),
+								Body: &ast.BlockStmt{
+									List: []ast.Stmt{
+										&ast.AssignStmt{
+											Lhs: []ast.Expr{ast.NewIdent("partitionConsumer"), ast.NewIdent("err")},
+											Tok: token.DEFINE,
+											Rhs: []ast.Expr{
+												&ast.CallExpr{
+													Fun: selector("consumer", "ConsumePartition"),
+													Args: []ast.Expr{
+														selector("handler", "Topic"),
+														ast.NewIdent("partition"),
+														selector("sarama", "OffsetNewest"),
+													},
+												},
+											},
+										},
+										unexpected("cant build kafka partition consumer"),
+										&ast.AssignStmt{
+											Lhs: []ast.Expr{selector("handler", "partitions")},
+											Tok: token.ASSIGN,
+											Rhs: []ast.Expr{
+												&ast.CallExpr{
+													Fun: ast.NewIdent("append"),
+													Args: []ast.Expr{
+														selector("handler", "partitions"),
+														ast.NewIdent("partitionConsumer"),
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+							&ast.AssignStmt{
+								Lhs: []ast.Expr{
+									&ast.IndexExpr{X: selector("c", "broadcasts"), Index: ast.NewIdent("i")},
+								},
+								Tok: token.ASSIGN,
+								Rhs: []ast.Expr{ast.NewIdent("handler")},
+							},
+						},
+					},
+				},
+				&ast.ReturnStmt{Results: []ast.Expr{ast.NewIdent("nil")}},
+			},
+		},
+	}
+}
+
+// consumePartition - Consumer.consumePartition, passes the messages of a broadcast partition to the handler until the
+// partition is closed. There are no offsets to commit, a failed message is logged and skipped.
+func (u ConsumerGenerator) consumePartition() *ast.FuncDecl {
+	logField := func(constructor, key string, value ast.Expr) ast.Expr {
+		return &ast.CallExpr{
+			Fun:  selector("log", constructor),
+			Args: []ast.Expr{&ast.BasicLit{Kind: token.STRING, Value: `"` + key + `"`}, value},
+		}
+	}
+	bytesString := func(name string) ast.Expr {
+		return &ast.CallExpr{Fun: ast.NewIdent("string"), Args: []ast.Expr{selector("msg", name)}}
+	}
+	return &ast.FuncDecl{
+		Recv: consumerReceiver(),
+		Name: ast.NewIdent("consumePartition"),
+		Type: &ast.FuncType{
+			Params: &ast.FieldList{
+				List: []*ast.Field{
+					field("partition", selector("sarama", "PartitionConsumer")),
+					field("handlerFunc", ast.NewIdent("HandlerFunc")),
+				},
+			},
+		},
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.RangeStmt{
+					Key: ast.NewIdent("msg"),
+					Tok: token.DEFINE,
+					X:   &ast.CallExpr{Fun: selector("partition", "Messages")},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.AssignStmt{
+								Lhs: []ast.Expr{ast.NewIdent("ctx")},
+								Tok: token.DEFINE,
+								Rhs: []ast.Expr{
+									&ast.CallExpr{
+										Fun: ast.NewIdent("extractContext"),
+										Args: []ast.Expr{
+											&ast.CallExpr{Fun: selector("context", "Background")},
+											ast.NewIdent("msg"),
+										},
+									},
+								},
+							},
+							&ast.AssignStmt{
+								Lhs: []ast.Expr{ast.NewIdent("logger")},
+								Tok: token.DEFINE,
+								Rhs: []ast.Expr{
+									&ast.CallExpr{
+										Fun:  selector("c.logger", "WithContext"),
+										Args: []ast.Expr{ast.NewIdent("ctx")},
+									},
+								},
+							},
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: selector("logger", "Info"),
+									Args: []ast.Expr{
+										&ast.BasicLit{Kind: token.STRING, Value: `"received message"`},
+										logField("String", "topic", selector("msg", "Topic")),
+										logField("Int32", "partition", selector("msg", "Partition")),
+										logField("Int64", "offset", selector("msg", "Offset")),
+										logField("String", "key", bytesString("Key")),
+										logField("String", "value", bytesString("Value")),
+									},
+								},
+							},
+							&ast.IfStmt{
+								Init: &ast.AssignStmt{
+									Lhs: []ast.Expr{ast.NewIdent("err")},
+									Tok: token.DEFINE,
+									Rhs: []ast.Expr{
+										&ast.CallExpr{
+											Fun:  ast.NewIdent("handlerFunc"),
+											Args: []ast.Expr{ast.NewIdent("ctx"), ast.NewIdent("msg")},
+										},
+									},
+								},
+								Cond: notNil("err"),
+								Body: &ast.BlockStmt{
+									List: []ast.Stmt{
+										&ast.ExprStmt{
+											X: &ast.CallExpr{
+												Fun: selector("logger", "Error"),
+												Args: []ast.Expr{
+													&ast.BasicLit{Kind: token.STRING, Value: `"handled message error"`},
+													&ast.CallExpr{
+														Fun:  selector("log", "Error"),
+														Args: []ast.Expr{ast.NewIdent("err")},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func consumerReceiver() *ast.FieldList {
+	return &ast.FieldList{
+		List: []*ast.Field{
+			field("c", &ast.StarExpr{X: ast.NewIdent("Consumer")}),
+		},
+	}
+}
+
+func field(name string, typ ast.Expr) *ast.Field {
+	return &ast.Field{
+		Names: []*ast.Ident{
+			ast.NewIdent(name),
+		},
+		Type: typ,
+	}
+}
+
+func selector(x, sel string) *ast.SelectorExpr {
+	return &ast.SelectorExpr{
+		X:   ast.NewIdent(x),
+		Sel: ast.NewIdent(sel),
+	}
+}
+
+func notNil(name string) ast.Expr {
+	return &ast.BinaryExpr{
+		X:  ast.NewIdent(name),
+		Op: token.NEQ,
+		Y:  ast.NewIdent("nil"),
+	}
+}
+
+// returnErr - if err := call; err != nil { return err }.
+func returnErr(call ast.Expr) ast.Stmt {
+	return &ast.IfStmt{
+		Init: &ast.AssignStmt{
+			Lhs: []ast.Expr{ast.NewIdent("err")},
+			Tok: token.DEFINE,
+			Rhs: []ast.Expr{call},
+		},
+		Cond: notNil("err"),
+		Body: &ast.BlockStmt{
+			List: []ast.Stmt{
+				&ast.ReturnStmt{Results: []ast.Expr{ast.NewIdent("err")}},
+			},
+		},
+	}
+}
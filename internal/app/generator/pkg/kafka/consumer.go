@@ -300,6 +300,84 @@ func (u ConsumerGenerator) file() *ast.File {
 					},
 				},
 			},
+			&ast.FuncDecl{
+				Doc: &ast.CommentGroup{
+					List: []*ast.Comment{
+						{
+							Text: "// NewBroadcastHandler returns a handler that gets every message of the topic on every instance. It reads the",
+						},
+						{
+							Text: "// partitions from the newest offset without a consumer group, so restarts leave no groups behind in Kafka and an",
+						},
+						{
+							Text: "// instance does not get the messages sent while it was down.",
+						},
+					},
+				},
+				Name: &ast.Ident{
+					Name: "NewBroadcastHandler",
+				},
+				Type: &ast.FuncType{
+					Params: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "topic",
+									},
+								},
+								Type: &ast.Ident{
+									Name: "string",
+								},
+							},
+							{
+								Names: []*ast.Ident{
+									{
+										Name: "handlerFunc",
+									},
+								},
+								Type: &ast.Ident{
+									Name: "HandlerFunc",
+								},
+							},
+						},
+					},
+					Results: &ast.FieldList{
+						List: []*ast.Field{
+							{
+								Type: &ast.Ident{
+									Name: "Handler",
+								},
+							},
+						},
+					},
+				},
+				Body: &ast.BlockStmt{
+					List: []ast.Stmt{
+						&ast.ReturnStmt{
+							Results: []ast.Expr{
+								&ast.CallExpr{
+									Fun: &ast.Ident{
+										Name: "NewHandler",
+									},
+									Args: []ast.Expr{
+										&ast.Ident{
+											Name: "topic",
+										},
+										&ast.BasicLit{
+											Kind:  token.STRING,
+											Value: "\"\"",
+										},
+										&ast.Ident{
+											Name: "handlerFunc",
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
 			&ast.GenDecl{
 				Tok: token.TYPE,
 				Specs: []ast.Spec{
@@ -1687,12 +1765,22 @@ func (u ConsumerGenerator) file() *ast.File {
 										Tok: token.DEFINE,
 										Rhs: []ast.Expr{
 											&ast.CallExpr{
-												Fun: &ast.SelectorExpr{
-													X: &ast.Ident{
-														Name: "context",
+												Fun: &ast.Ident{
+													Name: "extractContext",
+												},
+												Args: []ast.Expr{
+													&ast.CallExpr{
+														Fun: &ast.SelectorExpr{
+															X: &ast.Ident{
+																Name: "context",
+															},
+															Sel: &ast.Ident{
+																Name: "Background",
+															},
+														},
 													},
-													Sel: &ast.Ident{
-														Name: "Background",
+													&ast.Ident{
+														Name: "msg",
 													},
 												},
 											},
@@ -1899,15 +1987,8 @@ func (u ConsumerGenerator) file() *ast.File {
 														},
 													},
 													Args: []ast.Expr{
-														&ast.CallExpr{
-															Fun: &ast.SelectorExpr{
-																X: &ast.Ident{
-																	Name: "context",
-																},
-																Sel: &ast.Ident{
-																	Name: "Background",
-																},
-															},
+														&ast.Ident{
+															Name: "ctx",
 														},
 														&ast.Ident{
 															Name: "msg",
@@ -2013,6 +2094,10 @@ func (u ConsumerGenerator) Sync() error {
 	file, err := parser.ParseFile(fileset, filename, nil, parser.ParseComments)
 	if err != nil {
 		file = u.file()
+		u.addBroadcasts(file)
+		if u.project.PrometheusEnabled() {
+			u.addMetrics(file)
+		}
 	}
 	buff := &bytes.Buffer{}
 	if err := printer.Fprint(buff, fileset, file); err != nil {
@@ -0,0 +1,134 @@
+package kafka
+
+import (
+	"go/ast"
+	"path"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/astfile"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+type MetricsGenerator struct {
+	project *configs.Project
+}
+
+func NewMetricsGenerator(project *configs.Project) *MetricsGenerator {
+	return &MetricsGenerator{project: project}
+}
+
+func (u MetricsGenerator) Sync() error {
+	file := &tmpl.Template{
+		SourcePath:      "templates/internal/pkg/kafka/metrics.go.tmpl",
+		DestinationPath: path.Join("internal", "pkg", "kafka", "metrics.go"),
+		Name:            "kafka metrics",
+	}
+	if err := file.RenderToFile(u.project); err != nil {
+		return err
+	}
+	return nil
+}
+
+func metricsField() *ast.Field {
+	return &ast.Field{
+		Names: []*ast.Ident{
+			ast.NewIdent("metrics"),
+		},
+		Type: &ast.StarExpr{
+			X: ast.NewIdent("Metrics"),
+		},
+	}
+}
+
+func findCompositeLit(node ast.Node, typeName string) *ast.CompositeLit {
+	var result *ast.CompositeLit
+	ast.Inspect(node, func(node ast.Node) bool {
+		lit, ok := node.(*ast.CompositeLit)
+		if !ok || result != nil {
+			return result == nil
+		}
+		if ident, ok := lit.Type.(*ast.Ident); ok && ident.Name == typeName {
+			result = lit
+			return false
+		}
+		return true
+	})
+	return result
+}
+
+func (u ConsumerGenerator) addMetrics(file *ast.File) {
+	if typeSpec, ok := astfile.FindType(file, "Consumer"); ok {
+		structType := typeSpec.Type.(*ast.StructType)
+		structType.Fields.List = append(structType.Fields.List, metricsField())
+	}
+	if constructor, ok := astfile.FindFunc(file, "NewConsumer"); ok {
+		constructor.Type.Params.List = append(constructor.Type.Params.List, metricsField())
+		if lit := findCompositeLit(constructor.Body, "Consumer"); lit != nil {
+			lit.Elts = append(lit.Elts, &ast.KeyValueExpr{
+				Key:   ast.NewIdent("metrics"),
+				Value: ast.NewIdent("metrics"),
+			})
+		}
+	}
+	start, ok := astfile.FindFunc(file, "Start")
+	if !ok {
+		return
+	}
+	ast.Inspect(start.Body, func(node ast.Node) bool {
+		assign, ok := node.(*ast.AssignStmt)
+		if !ok || len(assign.Rhs) != 1 {
+			return true
+		}
+		call, ok := assign.Rhs[0].(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		if ident, ok := call.Fun.(*ast.Ident); !ok || ident.Name != "NewGroupHandler" {
+			return true
+		}
+		assign.Rhs[0] = &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X: &ast.SelectorExpr{
+					X:   ast.NewIdent("c"),
+					Sel: ast.NewIdent("metrics"),
+				},
+				Sel: ast.NewIdent("GroupHandler"),
+			},
+			Args: []ast.Expr{
+				&ast.SelectorExpr{
+					X:   ast.NewIdent("handler"),
+					Sel: ast.NewIdent("GroupID"),
+				},
+				call,
+			},
+		}
+		return false
+	})
+}
+
+func (u ProducerGenerator) addMetrics(file *ast.File) {
+	constructor, ok := astfile.FindFunc(file, "NewProducer")
+	if !ok {
+		return
+	}
+	constructor.Type.Params.List = append(constructor.Type.Params.List, metricsField())
+	lit := findCompositeLit(constructor.Body, "Producer")
+	if lit == nil {
+		return
+	}
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if ident, ok := kv.Key.(*ast.Ident); ok && ident.Name == "producer" {
+			kv.Value = &ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("metrics"),
+					Sel: ast.NewIdent("SyncProducer"),
+				},
+				Args: []ast.Expr{kv.Value},
+			}
+		}
+	}
+}
@@ -501,7 +501,7 @@ func (u ProducerGenerator) file() *ast.File {
 							{
 								Names: []*ast.Ident{
 									{
-										Name: "_",
+										Name: "ctx",
 									},
 								},
 								Type: &ast.SelectorExpr{
@@ -627,6 +627,21 @@ func (u ProducerGenerator) file() *ast.File {
 								},
 							},
 						},
+						&ast.ExprStmt{
+							X: &ast.CallExpr{
+								Fun: &ast.Ident{
+									Name: "injectContext",
+								},
+								Args: []ast.Expr{
+									&ast.Ident{
+										Name: "ctx",
+									},
+									&ast.Ident{
+										Name: "msg",
+									},
+								},
+							},
+						},
 						&ast.AssignStmt{
 							Lhs: []ast.Expr{
 								&ast.Ident{
@@ -822,6 +837,9 @@ func (u ProducerGenerator) Sync() error {
 	file, err := parser.ParseFile(fileset, filename, nil, parser.ParseComments)
 	if err != nil {
 		file = u.file()
+		if u.project.PrometheusEnabled() {
+			u.addMetrics(file)
+		}
 	}
 	buff := &bytes.Buffer{}
 	if err := printer.Fprint(buff, fileset, file); err != nil {
@@ -0,0 +1,28 @@
+package kafka
+
+import (
+	"path"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+type PropagationGenerator struct {
+	project *configs.Project
+}
+
+func NewPropagationGenerator(project *configs.Project) *PropagationGenerator {
+	return &PropagationGenerator{project: project}
+}
+
+func (u PropagationGenerator) Sync() error {
+	file := &tmpl.Template{
+		SourcePath:      "templates/internal/pkg/kafka/propagation.go.tmpl",
+		DestinationPath: path.Join("internal", "pkg", "kafka", "propagation.go"),
+		Name:            "kafka trace propagation",
+	}
+	if err := file.RenderToFile(u.project); err != nil {
+		return err
+	}
+	return nil
+}
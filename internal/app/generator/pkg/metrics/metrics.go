@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"path"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+var destinationPath = "."
+
+type Generator struct {
+	project *configs.Project
+}
+
+func NewGenerator(project *configs.Project) *Generator {
+	return &Generator{project: project}
+}
+
+func (g *Generator) Sync() error {
+	files := []*tmpl.Template{
+		{
+			SourcePath: "templates/internal/pkg/metrics/metrics.go.tmpl",
+			DestinationPath: path.Join(
+				destinationPath,
+				"internal",
+				"pkg",
+				"metrics",
+				"metrics.go",
+			),
+			Name: "prometheus registry and admin server",
+		},
+	}
+	if g.project.HTTPEnabled {
+		files = append(files, &tmpl.Template{
+			SourcePath:      "templates/internal/pkg/metrics/http.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "metrics", "http.go"),
+			Name:            "http metrics middleware",
+		})
+	}
+	if g.project.GRPCEnabled {
+		files = append(files, &tmpl.Template{
+			SourcePath:      "templates/internal/pkg/metrics/grpc.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "metrics", "grpc.go"),
+			Name:            "grpc metrics interceptors",
+		})
+	}
+	for _, file := range files {
+		if err := file.RenderToFile(g.project); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package mongo
+
+import (
+	"path"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+var destinationPath = "."
+
+type Generator struct {
+	project *configs.Project
+}
+
+func NewGenerator(project *configs.Project) *Generator {
+	return &Generator{project: project}
+}
+
+func (g *Generator) Sync() error {
+	files := []*tmpl.Template{
+		{
+			SourcePath:      "templates/internal/pkg/mongo/mongo.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "mongo", "mongo.go"),
+			Name:            "mongo",
+		},
+	}
+	for _, file := range files {
+		if err := file.RenderToFile(g.project); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package mysql
+
+import (
+	"path"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+var destinationPath = "."
+
+type Generator struct {
+	project *configs.Project
+}
+
+func NewGenerator(project *configs.Project) *Generator {
+	return &Generator{project: project}
+}
+
+func (c *Generator) Sync() error {
+	files := []*tmpl.Template{
+		{
+			SourcePath:      "templates/internal/pkg/mysql/mysql.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "mysql", "mysql.go"),
+			Name:            "mysql",
+		},
+		{
+			SourcePath:      "templates/internal/pkg/mysql/array.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "mysql", "array.go"),
+			Name:            "mysql array",
+		},
+		{
+			SourcePath:      "templates/internal/pkg/mysql/search.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "mysql", "search.go"),
+			Name:            "search",
+		},
+		{
+			SourcePath:      "templates/internal/pkg/mysql/testing.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "mysql", "testing.go"),
+			Name:            "mysql testing",
+		},
+		{
+			SourcePath: "templates/internal/pkg/mysql/migrations/init.sql.tmpl",
+			DestinationPath: path.Join(
+				destinationPath,
+				"internal",
+				"pkg",
+				"mysql",
+				"migrations",
+				"000001_init.up.sql",
+			),
+			Name: "mysql init migration",
+		},
+	}
+	for _, file := range files {
+		if err := file.RenderToFile(c.project); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -2,17 +2,26 @@ package pkg
 
 import (
 	"github.com/mikalai-mitsin/creathor/internal/app/generator"
+	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/broadcast"
+	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/cache"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/clock"
 	cfg "github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/configs"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/containers"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/dtx"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/errs"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/grpc"
+	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/health"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/http"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/kafka"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/log"
+	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/metrics"
+	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/mongo"
+	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/mysql"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/pointer"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/postgres"
+	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/ratelimit"
+	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/sqlite"
+	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/tracing"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/uptrace"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg/uuid"
 	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
@@ -26,6 +35,18 @@ func NewGenerator(project *configs.Project) *Generator {
 	return &Generator{project: project}
 }
 
+// database returns the generator of the connection package of the selected database.
+func (g *Generator) database() generator.Generator {
+	switch g.project.Database {
+	case "mysql":
+		return mysql.NewGenerator(g.project)
+	case "sqlite":
+		return sqlite.NewGenerator(g.project)
+	default:
+		return postgres.NewGenerator(g.project)
+	}
+}
+
 func (g *Generator) Sync() error {
 	generators := []generator.Generator{
 		clock.NewGenerator(g.project),
@@ -34,9 +55,11 @@ func (g *Generator) Sync() error {
 		errs.NewGenerator(g.project),
 		log.NewGenerator(g.project),
 		pointer.NewGenerator(g.project),
-		postgres.NewGenerator(g.project),
+		g.database(),
 		uuid.NewGenerator(g.project),
 		dtx.NewGenerator(g.project),
+		tracing.NewGenerator(g.project),
+		health.NewGenerator(g.project),
 	}
 	if g.project.KafkaEnabled {
 		generators = append(
@@ -44,22 +67,50 @@ func (g *Generator) Sync() error {
 			kafka.NewConfigGenerator(g.project),
 			kafka.NewConsumerGenerator(g.project),
 			kafka.NewProducerGenerator(g.project),
+			kafka.NewPropagationGenerator(g.project),
 		)
+		if g.project.PrometheusEnabled() {
+			generators = append(generators, kafka.NewMetricsGenerator(g.project))
+		}
 	}
 	if g.project.HTTPEnabled {
-		generators = append(generators, http.NewConfig(g.project), http.NewServer(g.project))
+		generators = append(
+			generators,
+			http.NewConfig(g.project),
+			http.NewMiddlewares(g.project),
+			http.NewServer(g.project),
+		)
+	}
+	if g.project.GatewayEnabled {
+		generators = append(generators, http.NewGateway(g.project))
 	}
 	if g.project.GRPCEnabled {
 		generators = append(
 			generators,
 			grpc.NewConfig(g.project),
 			grpc.NewMiddlewares(g.project),
+			grpc.NewInterceptors(g.project),
 			grpc.NewServer(g.project),
 		)
 	}
+	if g.project.GRPCEnabled && g.project.WatchEnabled {
+		generators = append(generators, broadcast.NewGenerator(g.project))
+	}
 	if g.project.UptraceEnabled {
 		generators = append(generators, uptrace.NewProvider(g.project))
 	}
+	if g.project.PrometheusEnabled() {
+		generators = append(generators, metrics.NewGenerator(g.project))
+	}
+	if g.project.RateLimitEnabled {
+		generators = append(generators, ratelimit.NewGenerator(g.project))
+	}
+	if g.project.CacheEnabled() {
+		generators = append(generators, cache.NewGenerator(g.project))
+	}
+	if g.project.MongoEnabled() {
+		generators = append(generators, mongo.NewGenerator(g.project))
+	}
 	for _, gen := range generators {
 		if err := gen.Sync(); err != nil {
 			return err
@@ -0,0 +1,65 @@
+package ratelimit
+
+import (
+	"path"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+var destinationPath = "."
+
+type Generator struct {
+	project *configs.Project
+}
+
+func NewGenerator(project *configs.Project) *Generator {
+	return &Generator{project: project}
+}
+
+func (g *Generator) Sync() error {
+	files := []*tmpl.Template{
+		{
+			SourcePath: "templates/internal/pkg/ratelimit/ratelimit.go.tmpl",
+			DestinationPath: path.Join(
+				destinationPath,
+				"internal",
+				"pkg",
+				"ratelimit",
+				"ratelimit.go",
+			),
+			Name: "rate limiter",
+		},
+		{
+			SourcePath: "templates/internal/pkg/ratelimit/ratelimit_test.go.tmpl",
+			DestinationPath: path.Join(
+				destinationPath,
+				"internal",
+				"pkg",
+				"ratelimit",
+				"ratelimit_test.go",
+			),
+			Name: "rate limiter tests",
+		},
+	}
+	if g.project.HTTPEnabled {
+		files = append(files, &tmpl.Template{
+			SourcePath:      "templates/internal/pkg/ratelimit/http.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "ratelimit", "http.go"),
+			Name:            "rate limit http middleware",
+		})
+	}
+	if g.project.GRPCEnabled {
+		files = append(files, &tmpl.Template{
+			SourcePath:      "templates/internal/pkg/ratelimit/grpc.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "ratelimit", "grpc.go"),
+			Name:            "rate limit grpc interceptors",
+		})
+	}
+	for _, file := range files {
+		if err := file.RenderToFile(g.project); err != nil {
+			return err
+		}
+	}
+	return nil
+}
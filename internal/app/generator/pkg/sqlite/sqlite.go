@@ -0,0 +1,61 @@
+package sqlite
+
+import (
+	"path"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+var destinationPath = "."
+
+type Generator struct {
+	project *configs.Project
+}
+
+func NewGenerator(project *configs.Project) *Generator {
+	return &Generator{project: project}
+}
+
+func (c *Generator) Sync() error {
+	files := []*tmpl.Template{
+		{
+			SourcePath:      "templates/internal/pkg/sqlite/sqlite.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "sqlite", "sqlite.go"),
+			Name:            "sqlite",
+		},
+		{
+			SourcePath:      "templates/internal/pkg/sqlite/array.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "sqlite", "array.go"),
+			Name:            "sqlite array",
+		},
+		{
+			SourcePath:      "templates/internal/pkg/sqlite/search.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "sqlite", "search.go"),
+			Name:            "search",
+		},
+		{
+			SourcePath:      "templates/internal/pkg/sqlite/testing.go.tmpl",
+			DestinationPath: path.Join(destinationPath, "internal", "pkg", "sqlite", "testing.go"),
+			Name:            "sqlite testing",
+		},
+		{
+			SourcePath: "templates/internal/pkg/sqlite/migrations/init.sql.tmpl",
+			DestinationPath: path.Join(
+				destinationPath,
+				"internal",
+				"pkg",
+				"sqlite",
+				"migrations",
+				"000001_init.up.sql",
+			),
+			Name: "sqlite init migration",
+		},
+	}
+	for _, file := range files {
+		if err := file.RenderToFile(c.project); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package tracing
+
+import (
+	"path"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
+)
+
+var destinationPath = "."
+
+type Generator struct {
+	project *configs.Project
+}
+
+func NewGenerator(project *configs.Project) *Generator {
+	return &Generator{project: project}
+}
+
+func (g *Generator) Sync() error {
+	files := []*tmpl.Template{
+		{
+			SourcePath: "templates/internal/pkg/tracing/span.go.tmpl",
+			DestinationPath: path.Join(
+				destinationPath,
+				"internal",
+				"pkg",
+				"tracing",
+				"span.go",
+			),
+			Name: "tracing spans",
+		},
+	}
+	if g.project.OTLPEnabled() {
+		files = append(
+			files,
+			&tmpl.Template{
+				SourcePath:      "templates/internal/pkg/tracing/tracing.go.tmpl",
+				DestinationPath: path.Join(destinationPath, "internal", "pkg", "tracing", "tracing.go"),
+				Name:            "otlp tracing provider",
+			},
+			&tmpl.Template{
+				SourcePath:      "templates/internal/pkg/tracing/tracing_test.go.tmpl",
+				DestinationPath: path.Join(destinationPath, "internal", "pkg", "tracing", "tracing_test.go"),
+				Name:            "otlp tracing provider tests",
+			},
+		)
+	}
+	for _, file := range files {
+		if err := file.RenderToFile(g.project); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package plugin
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/astfile"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/errs"
+)
+
+func (g *Generator) patch(patch Patch) error {
+	if err := g.checkPath(patch.Path); err != nil {
+		return err
+	}
+	src, err := os.ReadFile(patch.Path)
+	if err != nil {
+		e := errs.NewPluginError(g.config.Name, err.Error())
+		e.AddParam("path", patch.Path)
+		return e
+	}
+	fileset := token.NewFileSet()
+	file, err := parser.ParseFile(fileset, patch.Path, src, parser.ParseComments)
+	if err != nil {
+		e := errs.NewPluginError(g.config.Name, err.Error())
+		e.AddParam("path", patch.Path)
+		return e
+	}
+	source := "package " + file.Name.Name + "\n\n" + patch.Content
+	snippetFileset := token.NewFileSet()
+	snippet, err := parser.ParseFile(snippetFileset, "", source, parser.ParseComments)
+	if err != nil {
+		e := errs.NewPluginError(g.config.Name, err.Error())
+		e.AddParam("path", patch.Path)
+		return e
+	}
+	text := func(from, to token.Pos) string {
+		return source[snippetFileset.Position(from).Offset:snippetFileset.Position(to).Offset]
+	}
+	var imports []string
+	var decls []string
+	for _, decl := range snippet.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			for _, spec := range gen.Specs {
+				if !astfile.Imported(file, spec.(*ast.ImportSpec).Path.Value) {
+					imports = append(imports, text(spec.Pos(), spec.End()))
+				}
+			}
+			continue
+		}
+		if declared(file, decl) {
+			continue
+		}
+		start := decl.Pos()
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+		case *ast.GenDecl:
+			if d.Doc != nil {
+				start = d.Doc.Pos()
+			}
+		}
+		decls = append(decls, text(start, decl.End()))
+	}
+	if len(imports) == 0 && len(decls) == 0 {
+		return nil
+	}
+	buff := &bytes.Buffer{}
+	buff.Write(astfile.AddImports(fileset, file, src, imports))
+	for _, decl := range decls {
+		buff.WriteString("\n\n")
+		buff.WriteString(decl)
+	}
+	formatted, err := format.Source(buff.Bytes())
+	if err != nil {
+		e := errs.NewPluginError(g.config.Name, err.Error())
+		e.AddParam("path", patch.Path)
+		return e
+	}
+	if err := os.WriteFile(patch.Path, formatted, 0777); err != nil {
+		return err
+	}
+	return nil
+}
+
+// declared reports whether file already has every name declared by decl.
+func declared(file *ast.File, decl ast.Decl) bool {
+	switch d := decl.(type) {
+	case *ast.FuncDecl:
+		if d.Recv == nil {
+			_, exists := astfile.FindFunc(file, d.Name.Name)
+			return exists
+		}
+		return methodExists(file, astfile.ReceiverName(d), d.Name.Name)
+	case *ast.GenDecl:
+		for _, spec := range d.Specs {
+			switch s := spec.(type) {
+			case *ast.TypeSpec:
+				if !astfile.TypeExists(file, s.Name.Name) {
+					return false
+				}
+			case *ast.ValueSpec:
+				for _, name := range s.Names {
+					if d.Tok == token.CONST && !astfile.ConstExists(file, name.Name) ||
+						d.Tok == token.VAR && !astfile.VarExists(file, name.Name) {
+						return false
+					}
+				}
+			}
+		}
+		return true
+	}
+	return false
+}
+
+func methodExists(file *ast.File, receiver, name string) bool {
+	for _, decl := range file.Decls {
+		if function, ok := decl.(*ast.FuncDecl); ok && function.Recv != nil &&
+			function.Name.Name == name && astfile.ReceiverName(function) == receiver {
+			return true
+		}
+	}
+	return false
+}
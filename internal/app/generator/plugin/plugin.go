@@ -0,0 +1,124 @@
+package plugin
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/errs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/manifest"
+)
+
+// Response is read from the plugin stdout as JSON. A non-empty Error aborts the generation.
+type Response struct {
+	Error   string  `json:"error"`
+	Files   []File  `json:"files"`
+	Patches []Patch `json:"patches"`
+}
+
+// File is written to Path unless it already exists, like the files of the built-in templates.
+type File struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+// Patch adds the declarations of Content to the existing Go file at Path. Content is Go source without the
+// package clause, it may start with imports. Declarations that the file already has are kept untouched.
+type Patch struct {
+	Path    string `json:"path"`
+	Content string `json:"content"`
+}
+
+type Generator struct {
+	project *configs.Project
+	config  configs.PluginConfig
+	version string
+}
+
+func NewGenerator(project *configs.Project, config configs.PluginConfig, version string) *Generator {
+	return &Generator{project: project, config: config, version: version}
+}
+
+func (g *Generator) Sync() error {
+	response, err := g.run()
+	if err != nil {
+		return err
+	}
+	for _, file := range response.Files {
+		if err := g.write(file); err != nil {
+			return err
+		}
+	}
+	for _, patch := range response.Patches {
+		if err := g.patch(patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (g *Generator) run() (*Response, error) {
+	request, err := json.Marshal(NewRequest(g.project, g.config, g.version))
+	if err != nil {
+		return nil, errs.NewUnexpectedBehaviorError(err.Error())
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(g.config.Command, g.config.Args...)
+	cmd.Stdin = bytes.NewReader(request)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	fmt.Println(strings.Join(cmd.Args, " "))
+	if err := cmd.Run(); err != nil {
+		details := strings.TrimSpace(stderr.String())
+		if details == "" {
+			details = err.Error()
+		}
+		return nil, errs.NewPluginError(g.config.Name, details)
+	}
+	response := &Response{}
+	if err := json.Unmarshal(stdout.Bytes(), response); err != nil {
+		return nil, errs.NewPluginError(g.config.Name, fmt.Sprintf("bad response: %s", err))
+	}
+	if response.Error != "" {
+		return nil, errs.NewPluginError(g.config.Name, response.Error)
+	}
+	return response, nil
+}
+
+func (g *Generator) write(file File) error {
+	if err := g.checkPath(file.Path); err != nil {
+		return err
+	}
+	if _, err := os.Stat(file.Path); err == nil {
+		fmt.Printf("%s already exists.\n", file.Path)
+		return nil
+	}
+	if err := os.MkdirAll(path.Dir(file.Path), 0777); err != nil {
+		return err
+	}
+	if err := os.WriteFile(file.Path, []byte(file.Content), 0666); err != nil {
+		if errors.Is(err, os.ErrPermission) {
+			return errs.NewPermissionError(file.Path)
+		}
+		return errs.NewUnexpectedBehaviorError(err.Error())
+	}
+	manifest.Generated(file.Path)
+	return nil
+}
+
+// checkPath rejects paths that are absolute or leave the project directory.
+func (g *Generator) checkPath(filePath string) error {
+	if !filepath.IsLocal(filePath) {
+		e := errs.NewPluginError(g.config.Name, "path is outside of the project")
+		e.AddParam("path", filePath)
+		return e
+	}
+	return nil
+}
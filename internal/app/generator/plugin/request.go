@@ -0,0 +1,142 @@
+package plugin
+
+import (
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+)
+
+// Request is written to the plugin stdin as JSON. It carries the resolved project with the names the built-in
+// generators derive from it, so plugins do not have to reimplement the naming rules.
+type Request struct {
+	Version string   `json:"version"`
+	Plugin  string   `json:"plugin"`
+	Args    []string `json:"args"`
+	Project Project  `json:"project"`
+}
+
+type Project struct {
+	Name         string `json:"name"`
+	Module       string `json:"module"`
+	GoVersion    string `json:"go_version"`
+	ProtoPackage string `json:"proto_package"`
+	Database     string `json:"database"`
+	Driver       string `json:"driver"`
+	Metrics      string `json:"metrics"`
+	Tracing      string `json:"tracing"`
+	GRPC         bool   `json:"grpc"`
+	HTTP         bool   `json:"http"`
+	Gateway      bool   `json:"gateway"`
+	Kafka        bool   `json:"kafka"`
+	TypeScript   bool   `json:"typescript"`
+	Apps         []App  `json:"apps"`
+}
+
+type App struct {
+	Name     string   `json:"name"`
+	AppName  string   `json:"app_name"`
+	AppAlias string   `json:"app_alias"`
+	Entities []Entity `json:"entities"`
+}
+
+type Entity struct {
+	Name               string  `json:"name"`
+	EntityName         string  `json:"entity_name"`
+	Variable           string  `json:"variable"`
+	ListVariable       string  `json:"list_variable"`
+	SnakeName          string  `json:"snake_name"`
+	DirName            string  `json:"dir_name"`
+	FileName           string  `json:"file_name"`
+	TableName          string  `json:"table_name"`
+	Repository         string  `json:"repository"`
+	RepositoryDir      string  `json:"repository_dir"`
+	CreateTypeName     string  `json:"create_type_name"`
+	UpdateTypeName     string  `json:"update_type_name"`
+	FilterTypeName     string  `json:"filter_type_name"`
+	ServiceTypeName    string  `json:"service_type_name"`
+	UseCaseTypeName    string  `json:"use_case_type_name"`
+	RepositoryTypeName string  `json:"repository_type_name"`
+	HTTPPath           string  `json:"http_path"`
+	Cache              bool    `json:"cache"`
+	Params             []Param `json:"params"`
+}
+
+type Param struct {
+	Name      string `json:"name"`
+	FieldName string `json:"field_name"`
+	Column    string `json:"column"`
+	Type      string `json:"type"`
+	Search    bool   `json:"search"`
+	Unique    bool   `json:"unique"`
+}
+
+// NewRequest builds the request from the project, entities have to be linked to their apps before.
+func NewRequest(project *configs.Project, plugin configs.PluginConfig, version string) *Request {
+	request := &Request{
+		Version: version,
+		Plugin:  plugin.Name,
+		Args:    plugin.Args,
+		Project: Project{
+			Name:         project.Name,
+			Module:       project.Module,
+			GoVersion:    project.GoVersion,
+			ProtoPackage: project.ProtoPackage(),
+			Database:     project.Database,
+			Driver:       project.Driver,
+			Metrics:      project.Metrics,
+			Tracing:      project.Tracing,
+			GRPC:         project.GRPCEnabled,
+			HTTP:         project.HTTPEnabled,
+			Gateway:      project.GatewayEnabled,
+			Kafka:        project.KafkaEnabled,
+			TypeScript:   project.TypeScriptEnabled,
+			Apps:         []App{},
+		},
+	}
+	for _, appConfig := range project.Apps {
+		app := App{
+			Name:     appConfig.Name,
+			AppName:  appConfig.AppName(),
+			AppAlias: appConfig.AppAlias(),
+			Entities: []Entity{},
+		}
+		for _, entityConfig := range appConfig.Entities {
+			app.Entities = append(app.Entities, newEntity(entityConfig))
+		}
+		request.Project.Apps = append(request.Project.Apps, app)
+	}
+	return request
+}
+
+func newEntity(entityConfig configs.EntityConfig) Entity {
+	entity := Entity{
+		Name:               entityConfig.Name,
+		EntityName:         entityConfig.EntityName(),
+		Variable:           entityConfig.Variable(),
+		ListVariable:       entityConfig.ListVariable(),
+		SnakeName:          entityConfig.SnakeName(),
+		DirName:            entityConfig.DirName(),
+		FileName:           entityConfig.FileName(),
+		TableName:          entityConfig.TableName(),
+		Repository:         entityConfig.Repository,
+		RepositoryDir:      entityConfig.RepositoryDir(),
+		CreateTypeName:     entityConfig.CreateTypeName(),
+		UpdateTypeName:     entityConfig.UpdateTypeName(),
+		FilterTypeName:     entityConfig.FilterTypeName(),
+		ServiceTypeName:    entityConfig.GetServiceTypeName(),
+		UseCaseTypeName:    entityConfig.GetUseCaseTypeName(),
+		RepositoryTypeName: entityConfig.GetRepositoryTypeName(),
+		HTTPPath:           entityConfig.GetHTTPPath(),
+		Cache:              entityConfig.CacheEnabled,
+		Params:             []Param{},
+	}
+	for _, param := range entityConfig.Params {
+		entity.Params = append(entity.Params, Param{
+			Name:      param.Name,
+			FieldName: param.GetName(),
+			Column:    param.Tag(),
+			Type:      param.Type,
+			Search:    param.Search,
+			Unique:    param.Unique,
+		})
+	}
+	return entity
+}
@@ -0,0 +1,375 @@
+package importer
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jinzhu/inflection"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/ddl"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/errs"
+	"gopkg.in/yaml.v3"
+)
+
+var migrationPattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Report - what an import added to the project.
+type Report struct {
+	App        string
+	Entities   []string
+	Migrations []string
+	// Version is the number of the last recorded migration, the existing database has the tables up to it.
+	Version  int
+	Warnings []string
+}
+
+func (r *Report) String() string {
+	var lines []string
+	if len(r.Entities) > 0 {
+		lines = append(lines, fmt.Sprintf("entities added to app %s:", r.App))
+		for _, entity := range r.Entities {
+			lines = append(lines, "  "+entity)
+		}
+	}
+	if len(r.Migrations) > 0 {
+		lines = append(lines, "migrations recorded, creathor does not create these tables again:")
+		for _, migration := range r.Migrations {
+			lines = append(lines, "  "+migration)
+		}
+		lines = append(lines, fmt.Sprintf(
+			"mark them as applied on the existing database:\n  migrate -path %s -database \"$DATABASE_URL\" force %d",
+			path.Dir(r.Migrations[0]),
+			r.Version,
+		))
+	}
+	if len(r.Warnings) > 0 {
+		lines = append(lines, "warnings:")
+		for _, warning := range r.Warnings {
+			lines = append(lines, "  "+strings.ReplaceAll(warning, "\n", "\n    "))
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (r *Report) warn(format string, args ...any) {
+	r.Warnings = append(r.Warnings, fmt.Sprintf(format, args...))
+}
+
+type Importer struct {
+	directory  string
+	configPath string
+	app        string
+}
+
+// NewImporter returns an importer adding the entities to the app named app of the config, the first app of the
+// config or an app named after the project when app is empty.
+func NewImporter(directory, configPath, app string) *Importer {
+	if directory == "" {
+		directory = "."
+	}
+	return &Importer{directory: directory, configPath: configPath, app: app}
+}
+
+// ImportSQL adds an entity for every table created by the PostgreSQL DDL files that the config does not have yet and
+// records the statements of the table as a migration, so that the generated repositories use the existing tables.
+func (i *Importer) ImportSQL(files []string) (*Report, error) {
+	project, err := configs.NewProject(i.configPath)
+	if err != nil {
+		return nil, err
+	}
+	if project.Database != "postgres" {
+		return nil, errs.NewImportError(fmt.Sprintf(
+			"import sql reads PostgreSQL tables, the config uses the %s database",
+			project.Database,
+		))
+	}
+	report := &Report{}
+	schema := &ddl.Schema{}
+	for _, file := range files {
+		if strings.HasSuffix(file, ".down.sql") {
+			report.warn("%s skipped, down migrations drop the tables", file)
+			continue
+		}
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		if err := schema.Read(string(data)); err != nil {
+			return nil, errs.NewImportError(fmt.Sprintf("%s:%s", file, strings.TrimPrefix(err.Error(), "line ")))
+		}
+	}
+	if len(schema.Tables) == 0 {
+		return nil, errs.NewImportError("no CREATE TABLE statements in " + strings.Join(files, ", "))
+	}
+	config, err := i.readConfig()
+	if err != nil {
+		return nil, err
+	}
+	app := i.appNode(config, project, report)
+	existing := map[string]bool{}
+	for _, projectApp := range project.Apps {
+		for _, entity := range projectApp.Entities {
+			existing[entity.TableName()] = true
+		}
+	}
+	var imported []*ddl.Table
+	for _, table := range ordered(schema.Tables) {
+		entity := &configs.EntityConfig{
+			Name:        inflection.Singular(table.Name),
+			Module:      project.Module,
+			ProjectName: project.Name,
+		}
+		if existing[entity.TableName()] {
+			report.warn("%s skipped, the config has entity %s already", table.Name, entity.Name)
+			continue
+		}
+		if err := entity.Validate(); err != nil {
+			report.warn("%s skipped, entity %s: %s", table.Name, entity.Name, err)
+			continue
+		}
+		entity.Params = params(table, report)
+		appendEntity(app, entity, table)
+		report.Entities = append(report.Entities, summary(entity, table))
+		checkTable(entity, table, report)
+		existing[entity.TableName()] = true
+		imported = append(imported, table)
+	}
+	if len(imported) == 0 {
+		return report, nil
+	}
+	if err := i.writeConfig(config); err != nil {
+		return nil, err
+	}
+	if err := i.recordMigrations(imported, report); err != nil {
+		return nil, err
+	}
+	if others := statementKinds(schema.Others); len(others) > 0 {
+		report.warn(
+			"not recorded, add them to a migration when the tables need them: %s",
+			strings.Join(others, ", "),
+		)
+	}
+	return report, nil
+}
+
+func (i *Importer) readConfig() (*yaml.Node, error) {
+	data, err := os.ReadFile(i.configPath)
+	if err != nil {
+		return nil, err
+	}
+	config := &yaml.Node{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, err
+	}
+	if len(config.Content) == 0 || config.Content[0].Kind != yaml.MappingNode {
+		return nil, errs.NewImportError(i.configPath + " is not a mapping")
+	}
+	return config, nil
+}
+
+// writeConfig writes the config and reads it back, the previous config is restored when the new one is invalid.
+func (i *Importer) writeConfig(config *yaml.Node) error {
+	previous, err := os.ReadFile(i.configPath)
+	if err != nil {
+		return err
+	}
+	var data bytes.Buffer
+	encoder := yaml.NewEncoder(&data)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(config); err != nil {
+		return err
+	}
+	if err := encoder.Close(); err != nil {
+		return err
+	}
+	if err := os.WriteFile(i.configPath, data.Bytes(), 0666); err != nil {
+		return err
+	}
+	if _, err := configs.NewProject(i.configPath); err != nil {
+		return errors.Join(err, os.WriteFile(i.configPath, previous, 0666))
+	}
+	return nil
+}
+
+// appNode returns the entities of the app the tables are imported to, the app is added when the config does not
+// have it.
+func (i *Importer) appNode(config *yaml.Node, project *configs.Project, report *Report) *yaml.Node {
+	name := i.app
+	switch {
+	case name != "":
+	case len(project.Apps) > 0:
+		name = project.Apps[0].Name
+	default:
+		name = project.Name
+	}
+	report.App = name
+	apps := value(config.Content[0], "apps", yaml.SequenceNode)
+	for _, app := range apps.Content {
+		if app.Kind != yaml.MappingNode {
+			continue
+		}
+		appName := lookup(app, "name")
+		if appName != nil && (&configs.AppConfig{Name: appName.Value}).AppName() ==
+			(&configs.AppConfig{Name: name}).AppName() {
+			return value(app, "entities", yaml.SequenceNode)
+		}
+	}
+	app := &yaml.Node{Kind: yaml.MappingNode}
+	app.Content = append(app.Content, scalar("name"), scalar(name))
+	apps.Content = append(apps.Content, app)
+	return value(app, "entities", yaml.SequenceNode)
+}
+
+// lookup returns the value of key in mapping, or nil.
+func lookup(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// value returns the value of key in mapping, an empty value of kind is added when the mapping does not have it.
+func value(mapping *yaml.Node, key string, kind yaml.Kind) *yaml.Node {
+	if node := lookup(mapping, key); node != nil {
+		if node.Kind != kind && node.Tag == "!!null" {
+			// a key without a value, like "entities:", is filled in place
+			*node = yaml.Node{Kind: kind}
+		}
+		return node
+	}
+	node := &yaml.Node{Kind: kind}
+	mapping.Content = append(mapping.Content, scalar(key), node)
+	return node
+}
+
+func scalar(value string) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: value}
+}
+
+func enabled() *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"}
+}
+
+func appendEntity(entities *yaml.Node, entity *configs.EntityConfig, table *ddl.Table) {
+	params := &yaml.Node{Kind: yaml.SequenceNode}
+	for _, param := range entity.Params {
+		name := scalar(param.Name)
+		if foreignKey := foreignKey(table, param.Name); foreignKey != nil {
+			name.LineComment = "# references " + reference(foreignKey)
+		}
+		node := &yaml.Node{Kind: yaml.MappingNode}
+		node.Content = append(node.Content, scalar("name"), name, scalar("type"), scalar(param.Type))
+		if param.Search {
+			node.Content = append(node.Content, scalar("search"), enabled())
+		}
+		if param.Unique {
+			node.Content = append(node.Content, scalar("unique"), enabled())
+		}
+		params.Content = append(params.Content, node)
+	}
+	node := &yaml.Node{Kind: yaml.MappingNode, HeadComment: "# imported from table " + table.Name}
+	node.Content = append(node.Content, scalar("name"), scalar(entity.Name))
+	if len(params.Content) > 0 {
+		node.Content = append(node.Content, scalar("params"), params)
+	}
+	entities.Content = append(entities.Content, node)
+}
+
+// recordMigrations writes the statements of every table as a migration numbered after the last one, 000001 is
+// kept for the init migration of a project that is not generated yet.
+func (i *Importer) recordMigrations(tables []*ddl.Table, report *Report) error {
+	directory := path.Join("internal", "pkg", "postgres", "migrations")
+	if err := os.MkdirAll(filepath.Join(i.directory, directory), 0777); err != nil {
+		return err
+	}
+	entries, err := os.ReadDir(filepath.Join(i.directory, directory))
+	if err != nil {
+		return err
+	}
+	last := 1
+	for _, entry := range entries {
+		if match := migrationPattern.FindStringSubmatch(entry.Name()); match != nil {
+			number, _ := strconv.Atoi(match[1])
+			last = max(last, number)
+		}
+	}
+	for _, table := range tables {
+		last++
+		up := path.Join(directory, fmt.Sprintf("%06d_%s.up.sql", last, table.Name))
+		down := path.Join(directory, fmt.Sprintf("%06d_%s.down.sql", last, table.Name))
+		name := table.Name
+		if table.Schema != "" {
+			name = table.Schema + "." + name
+		}
+		files := map[string]string{
+			up:   strings.Join(table.Statements, ";\n\n") + ";\n",
+			down: fmt.Sprintf("DROP TABLE IF EXISTS %s;\n", name),
+		}
+		for _, file := range []string{up, down} {
+			if err := os.WriteFile(filepath.Join(i.directory, file), []byte(files[file]), 0666); err != nil {
+				return err
+			}
+		}
+		report.Migrations = append(report.Migrations, up)
+	}
+	report.Version = last
+	return nil
+}
+
+// ordered returns the tables with the referenced ones first, so that the migrations create them in order.
+func ordered(tables []*ddl.Table) []*ddl.Table {
+	result := make([]*ddl.Table, 0, len(tables))
+	visited := map[string]bool{}
+	var visit func(table *ddl.Table)
+	visit = func(table *ddl.Table) {
+		if visited[table.Name] {
+			return
+		}
+		visited[table.Name] = true
+		for _, foreignKey := range table.ForeignKeys {
+			for _, referenced := range tables {
+				if referenced.Name == foreignKey.Table {
+					visit(referenced)
+				}
+			}
+		}
+		result = append(result, table)
+	}
+	for _, table := range tables {
+		visit(table)
+	}
+	return result
+}
+
+// statementKinds returns the kinds of the statements, like CREATE EXTENSION or SET, without repeats.
+func statementKinds(statements []string) []string {
+	var kinds []string
+	for _, statement := range statements {
+		words := strings.FieldsFunc(strings.ToUpper(statement), func(r rune) bool {
+			return r != '_' && (r < 'A' || r > 'Z')
+		})
+		words = slices.DeleteFunc(words, func(word string) bool {
+			return word == "OR" || word == "REPLACE"
+		})
+		switch {
+		case len(words) > 1 && slices.Contains([]string{"CREATE", "ALTER", "DROP", "COMMENT"}, words[0]):
+			words = words[:2]
+		case len(words) > 0:
+			words = words[:1]
+		}
+		if kind := strings.Join(words, " "); kind != "" && !slices.Contains(kinds, kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
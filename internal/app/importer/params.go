@@ -0,0 +1,276 @@
+package importer
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/ddl"
+)
+
+// sqlTypes map the PostgreSQL names and aliases of the types to the names Param.SQLType returns.
+var sqlTypes = map[string]string{
+	"int":                         "int",
+	"integer":                     "int",
+	"int4":                        "int",
+	"serial":                      "int",
+	"serial4":                     "int",
+	"smallint":                    "int",
+	"int2":                        "int",
+	"smallserial":                 "int",
+	"serial2":                     "int",
+	"bigint":                      "bigint",
+	"int8":                        "bigint",
+	"bigserial":                   "bigint",
+	"serial8":                     "bigint",
+	"float":                       "float",
+	"real":                        "float",
+	"float4":                      "float",
+	"double precision":            "double precision",
+	"float8":                      "double precision",
+	"text":                        "text",
+	"citext":                      "text",
+	"varchar":                     "varchar",
+	"character varying":           "varchar",
+	"char":                        "varchar",
+	"character":                   "varchar",
+	"bpchar":                      "varchar",
+	"uuid":                        "uuid",
+	"timestamp":                   "timestamp",
+	"timestamp without time zone": "timestamp",
+	"timestamp with time zone":    "timestamp",
+	"timestamptz":                 "timestamp",
+	"date":                        "date",
+	"time":                        "time",
+	"time without time zone":      "time",
+	"time with time zone":         "time",
+	"timetz":                      "time",
+	"boolean":                     "boolean",
+	"bool":                        "boolean",
+	"interval":                    "interval",
+}
+
+var (
+	typeModifiers = regexp.MustCompile(`\s*\([^)]*\)`)
+	arrayBounds   = regexp.MustCompile(`(\s*\[\d*\])+$|\s+array$`)
+)
+
+// sqlType returns the column type the way Param.SQLType names it, int[] for integer[] and varchar for
+// character varying(255), or an empty string for the types it does not return.
+func sqlType(column *ddl.Column) string {
+	name := strings.TrimPrefix(typeModifiers.ReplaceAllString(column.Type, ""), "pg_catalog.")
+	array := arrayBounds.MatchString(name)
+	name = arrayBounds.ReplaceAllString(name, "")
+	typ, ok := sqlTypes[name]
+	if !ok {
+		return ""
+	}
+	switch {
+	case array && typ == "text":
+		// string slices are varchar[], the text of the elements is the same type
+		return "varchar[]"
+	case array:
+		return typ + "[]"
+	}
+	return typ
+}
+
+// paramType returns the param type that Param.SQLType turns into the type of the column. The SQL type of strings
+// and times depends on the name of the param, so the names giving varchar, date and time are tried after the name of
+// the column.
+func paramType(column *ddl.Column) (string, bool) {
+	typ := sqlType(column)
+	if typ == "" {
+		return "", false
+	}
+	for _, name := range []string{column.Name, "name", "date", "time", "value"} {
+		for _, paramType := range configs.ParamTypes {
+			if strings.Contains(paramType, "GroupID") {
+				// group ids are strings of the generated code, a column can not tell them apart
+				continue
+			}
+			if (&configs.Param{Name: name, Type: paramType}).SQLType() == typ {
+				return paramType, true
+			}
+		}
+	}
+	return "", false
+}
+
+// params returns a param for every column of the table besides the ones every entity has, the columns without a
+// param type are reported.
+func params(table *ddl.Table, report *Report) []*configs.Param {
+	search := searchColumns(table)
+	unique := uniqueColumns(table)
+	var params []*configs.Param
+	for _, column := range table.Columns {
+		if slices.Contains([]string{"id", "created_at", "updated_at"}, column.Name) {
+			continue
+		}
+		if column.Generated != nil {
+			// the tsvector of the full-text index is generated from the search params
+			if !strings.HasPrefix(column.Type, "tsvector") {
+				report.warn("%s.%s skipped, it is generated as %s", table.Name, column.Name, column.Generated.Text)
+			}
+			continue
+		}
+		typ, ok := paramType(column)
+		if !ok {
+			report.warn("%s.%s skipped, %s has no param type, add it by hand", table.Name, column.Name, column.Type)
+			continue
+		}
+		param := &configs.Param{Name: column.Name, Type: typ, Search: search[column.Name], Unique: unique[column.Name]}
+		if param.Search && param.SliceType() != "string" {
+			param.Search = false
+		}
+		if err := param.Validate(); err != nil {
+			report.warn("%s.%s skipped, %s", table.Name, column.Name, err)
+			continue
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// searchColumns returns the columns of the full-text indexes, the ones indexing to_tsvector of the columns and the
+// ones indexing a column generated as to_tsvector of the columns.
+func searchColumns(table *ddl.Table) map[string]bool {
+	columns := map[string]bool{}
+	for _, index := range table.Indexes {
+		for _, expression := range index.Expressions {
+			if slices.Contains(expression.Functions, "to_tsvector") {
+				for _, name := range expression.Columns {
+					columns[name] = true
+				}
+				continue
+			}
+			for _, name := range expression.Columns {
+				column := table.Column(name)
+				if column != nil && column.Generated != nil &&
+					slices.Contains(column.Generated.Functions, "to_tsvector") {
+					for _, name := range column.Generated.Columns {
+						columns[name] = true
+					}
+				}
+			}
+		}
+	}
+	return columns
+}
+
+// uniqueColumns returns the columns that are unique on their own, by a constraint or by a unique index of the
+// column.
+func uniqueColumns(table *ddl.Table) map[string]bool {
+	columns := map[string]bool{}
+	for _, unique := range append(slices.Clone(table.Unique), table.PrimaryKey) {
+		if len(unique) == 1 {
+			columns[unique[0]] = true
+		}
+	}
+	for _, index := range table.Indexes {
+		if !index.Unique || len(index.Expressions) != 1 || len(index.Expressions[0].Functions) > 0 {
+			continue
+		}
+		var indexed []string
+		for _, name := range index.Expressions[0].Columns {
+			if table.Column(name) != nil {
+				indexed = append(indexed, name)
+			}
+		}
+		if len(indexed) == 1 {
+			columns[indexed[0]] = true
+		}
+	}
+	return columns
+}
+
+// foreignKey returns the foreign key of the column, the config has no relations, they are kept as params
+// commented with the referenced table.
+func foreignKey(table *ddl.Table, column string) *ddl.ForeignKey {
+	for _, foreignKey := range table.ForeignKeys {
+		if len(foreignKey.Columns) == 1 && foreignKey.Columns[0] == column {
+			return foreignKey
+		}
+	}
+	return nil
+}
+
+func reference(foreignKey *ddl.ForeignKey) string {
+	if len(foreignKey.References) == 0 {
+		return foreignKey.Table
+	}
+	return fmt.Sprintf("%s(%s)", foreignKey.Table, strings.Join(foreignKey.References, ", "))
+}
+
+// summary describes the entity imported from the table in one line.
+func summary(entity *configs.EntityConfig, table *ddl.Table) string {
+	parts := []string{fmt.Sprintf("%d params", len(entity.Params))}
+	var search, unique, relations []string
+	for _, param := range entity.Params {
+		if param.Search {
+			search = append(search, param.Name)
+		}
+		if param.Unique {
+			unique = append(unique, param.Name)
+		}
+		if foreignKey := foreignKey(table, param.Name); foreignKey != nil {
+			relations = append(relations, param.Name+" -> "+reference(foreignKey))
+		}
+	}
+	for _, part := range []struct {
+		name  string
+		names []string
+	}{
+		{name: "search", names: search},
+		{name: "unique", names: unique},
+		{name: "relations", names: relations},
+	} {
+		if len(part.names) > 0 {
+			parts = append(parts, part.name+" "+strings.Join(part.names, ", "))
+		}
+	}
+	return fmt.Sprintf("%s from %s: %s", entity.Name, table.Name, strings.Join(parts, "; "))
+}
+
+// checkTable reports what the generated code expects from the table and the table does not have.
+func checkTable(entity *configs.EntityConfig, table *ddl.Table, report *Report) {
+	if entity.TableName() != table.Name {
+		report.warn(
+			"%s: entity %s uses table %s, rename the entity or the table",
+			table.Name,
+			entity.Name,
+			entity.TableName(),
+		)
+	}
+	if table.Schema != "" && table.Schema != "public" {
+		report.warn("%s: the generated repositories use the public schema, the table is in %s", table.Name, table.Schema)
+	}
+	expected := []struct {
+		name string
+		typ  string
+	}{
+		{name: "id", typ: "uuid"},
+		{name: "created_at", typ: "timestamp"},
+		{name: "updated_at", typ: "timestamp"},
+	}
+	for _, column := range expected {
+		existing := table.Column(column.name)
+		switch {
+		case existing == nil:
+			report.warn("%s: no %s column, the generated code reads %s %s", table.Name, column.name, column.name, column.typ)
+		case sqlType(existing) != column.typ:
+			report.warn("%s: %s is %s, the generated code reads %s", table.Name, column.name, existing.Type, column.typ)
+		}
+	}
+	for _, foreignKey := range table.ForeignKeys {
+		if len(foreignKey.Columns) > 1 {
+			report.warn(
+				"%s: foreign key (%s) has more than one column, the params are not related",
+				table.Name,
+				strings.Join(foreignKey.Columns, ", "),
+			)
+		}
+	}
+}
@@ -127,3 +127,22 @@ func VarExists(file ast.Node, name string) bool {
 	})
 	return constExists
 }
+
+// ReceiverName returns the type name of the method receiver without pointer and type parameters.
+func ReceiverName(function *ast.FuncDecl) string {
+	expr := function.Recv.List[0].Type
+	for {
+		switch e := expr.(type) {
+		case *ast.StarExpr:
+			expr = e.X
+		case *ast.IndexExpr:
+			expr = e.X
+		case *ast.IndexListExpr:
+			expr = e.X
+		case *ast.Ident:
+			return e.Name
+		default:
+			return ""
+		}
+	}
+}
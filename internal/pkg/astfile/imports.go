@@ -0,0 +1,134 @@
+package astfile
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+)
+
+// Imported reports whether file imports importPath, given as a quoted literal.
+func Imported(file *ast.File, importPath string) bool {
+	for _, spec := range file.Imports {
+		if spec.Path.Value == importPath {
+			return true
+		}
+	}
+	return false
+}
+
+// AddImports returns src with the import specs added to its first import declaration, or to a new one after the
+// package clause when the file imports nothing. The source is edited as text, so its comments stay in place.
+func AddImports(fileset *token.FileSet, file *ast.File, src []byte, imports []string) []byte {
+	if len(imports) == 0 {
+		return src
+	}
+	offset := func(pos token.Pos) int {
+		return fileset.Position(pos).Offset
+	}
+	specs := strings.Join(imports, "\n")
+	for _, decl := range file.Decls {
+		if gen, ok := decl.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			if gen.Lparen.IsValid() {
+				end := offset(gen.Rparen)
+				if src[end-1] != '\n' {
+					specs = "\n" + specs
+				}
+				return slices.Concat(src[:end], []byte(specs+"\n"), src[end:])
+			}
+			spec := string(src[offset(gen.Specs[0].Pos()):offset(gen.End())])
+			return slices.Concat(
+				src[:offset(gen.Pos())],
+				[]byte("import (\n"+spec+"\n"+specs+"\n)"),
+				src[offset(gen.End()):],
+			)
+		}
+	}
+	return slices.Concat(
+		src[:offset(file.Name.End())],
+		[]byte("\n\nimport (\n"+specs+"\n)"),
+		src[offset(file.Name.End()):],
+	)
+}
+
+// ImportName returns the name the file refers to an imported package by: the alias of spec, or the last element of
+// the path without a major version suffix, like goimports assumes.
+func ImportName(spec *ast.ImportSpec) string {
+	if spec.Name != nil {
+		return spec.Name.Name
+	}
+	importPath := strings.Trim(spec.Path.Value, `"`)
+	elements := strings.Split(importPath, "/")
+	name := elements[len(elements)-1]
+	if len(elements) > 1 && len(name) > 1 && name[0] == 'v' && strings.Trim(name[1:], "0123456789") == "" {
+		name = elements[len(elements)-2]
+	}
+	name, _, _ = strings.Cut(name, ".")
+	name = strings.TrimPrefix(name, "go-")
+	name = strings.TrimSuffix(name, "-go")
+	return strings.ReplaceAll(name, "-", "")
+}
+
+// UsedNames returns the identifiers that file uses as the left side of selectors, package names among them.
+func UsedNames(file ast.Node) map[string]bool {
+	names := map[string]bool{}
+	ast.Inspect(file, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				names[ident.Name] = true
+			}
+		}
+		return true
+	})
+	return names
+}
+
+// RemoveImports returns src without the import specs of paths, given as quoted literals. Import declarations left
+// without specs are removed.
+func RemoveImports(fileset *token.FileSet, file *ast.File, src []byte, paths []string) []byte {
+	offset := func(pos token.Pos) int {
+		return fileset.Position(pos).Offset
+	}
+	// lines returns the offsets of the whole lines between from and to, with the trailing newline
+	lines := func(from, to token.Pos) (int, int) {
+		start, end := offset(from), offset(to)
+		for start > 0 && src[start-1] != '\n' {
+			start--
+		}
+		for end < len(src) && src[end] != '\n' {
+			end++
+		}
+		if end < len(src) {
+			end++
+		}
+		return start, end
+	}
+	type cut struct{ start, end int }
+	var cuts []cut
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.IMPORT {
+			continue
+		}
+		var removed []ast.Spec
+		for _, spec := range gen.Specs {
+			if slices.Contains(paths, spec.(*ast.ImportSpec).Path.Value) {
+				removed = append(removed, spec)
+			}
+		}
+		if len(removed) == len(gen.Specs) {
+			start, end := lines(gen.Pos(), gen.End())
+			cuts = append(cuts, cut{start, end})
+			continue
+		}
+		for _, spec := range removed {
+			start, end := lines(spec.Pos(), spec.End())
+			cuts = append(cuts, cut{start, end})
+		}
+	}
+	result := slices.Clone(src)
+	for i := len(cuts) - 1; i >= 0; i-- {
+		result = slices.Concat(result[:cuts[i].start], result[cuts[i].end:])
+	}
+	return result
+}
@@ -0,0 +1,103 @@
+package astfile
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+)
+
+// TraceFunc starts a span named spanName at the top of function and ends it when the function returns.
+// The error result is named err so the deferred tracing.End records the error that was actually returned.
+func TraceFunc(function *ast.FuncDecl, spanName string) {
+	errResult := errorResult(function)
+	if errResult != nil {
+		for _, field := range function.Type.Results.List {
+			field.Names = []*ast.Ident{ast.NewIdent("_")}
+		}
+		errResult.Names = []*ast.Ident{ast.NewIdent("err")}
+		for _, stmt := range function.Body.List {
+			if assign, ok := stmt.(*ast.AssignStmt); ok && assign.Tok == token.DEFINE &&
+				!declaresNew(assign) {
+				assign.Tok = token.ASSIGN
+			}
+		}
+	}
+	var end ast.Stmt = &ast.DeferStmt{
+		Call: &ast.CallExpr{
+			Fun: &ast.SelectorExpr{
+				X:   ast.NewIdent("span"),
+				Sel: ast.NewIdent("End"),
+			},
+		},
+	}
+	if errResult != nil {
+		end = &ast.DeferStmt{
+			Call: &ast.CallExpr{
+				Fun: &ast.FuncLit{
+					Type: &ast.FuncType{
+						Params: &ast.FieldList{},
+					},
+					Body: &ast.BlockStmt{
+						List: []ast.Stmt{
+							&ast.ExprStmt{
+								X: &ast.CallExpr{
+									Fun: &ast.SelectorExpr{
+										X:   ast.NewIdent("tracing"),
+										Sel: ast.NewIdent("End"),
+									},
+									Args: []ast.Expr{
+										ast.NewIdent("span"),
+										ast.NewIdent("err"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+	start := &ast.AssignStmt{
+		Lhs: []ast.Expr{
+			ast.NewIdent("ctx"),
+			ast.NewIdent("span"),
+		},
+		Tok: token.DEFINE,
+		Rhs: []ast.Expr{
+			&ast.CallExpr{
+				Fun: &ast.SelectorExpr{
+					X:   ast.NewIdent("tracing"),
+					Sel: ast.NewIdent("Start"),
+				},
+				Args: []ast.Expr{
+					ast.NewIdent("ctx"),
+					&ast.BasicLit{
+						Kind:  token.STRING,
+						Value: fmt.Sprintf("%q", spanName),
+					},
+				},
+			},
+		},
+	}
+	function.Body.List = append([]ast.Stmt{start, end}, function.Body.List...)
+}
+
+func errorResult(function *ast.FuncDecl) *ast.Field {
+	if function.Type.Results == nil || len(function.Type.Results.List) == 0 {
+		return nil
+	}
+	last := function.Type.Results.List[len(function.Type.Results.List)-1]
+	if ident, ok := last.Type.(*ast.Ident); ok && ident.Name == "error" {
+		return last
+	}
+	return nil
+}
+
+func declaresNew(assign *ast.AssignStmt) bool {
+	for _, expr := range assign.Lhs {
+		if ident, ok := expr.(*ast.Ident); ok && ident.Name != "err" && ident.Name != "_" {
+			return true
+		}
+	}
+	return false
+}
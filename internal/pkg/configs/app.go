@@ -1,19 +1,44 @@
 package configs
 
 import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/iancoleman/strcase"
 )
 
 type AppConfig struct {
-	Name          string         `json:"name"          yaml:"name"`
-	Module        string         `json:"module"        yaml:"module"`
-	ProjectName   string         `json:"project_name"  yaml:"projectName"`
-	ProtoPackage  string         `json:"proto_package" yaml:"protoPackage"`
-	HTTPEnabled   bool           `                     yaml:"http"`
-	GRPCEnabled   bool           `                     yaml:"gRPC"`
-	KafkaEnabled  bool           `                     yaml:"kafka"`
-	Entities      []EntityConfig `json:"entities"      yaml:"entities"`
-	ProjectConfig *Project       `json:"-"             yaml:"-"`
+	Name              string         `json:"name"          yaml:"name"`
+	Module            string         `json:"module"        yaml:"module"`
+	ProjectName       string         `json:"project_name"  yaml:"projectName"`
+	ProtoPackage      string         `json:"proto_package" yaml:"protoPackage"`
+	HTTPEnabled       bool           `                     yaml:"http"`
+	GRPCEnabled       bool           `                     yaml:"gRPC"`
+	KafkaEnabled      bool           `                     yaml:"kafka"`
+	TypeScriptEnabled bool           `                     yaml:"typescript"`
+	WatchEnabled      bool           `                     yaml:"watch"`
+	GatewayEnabled    bool           `                     yaml:"gateway"`
+	Entities          []EntityConfig `json:"entities"      yaml:"entities"`
+	ProjectConfig     *Project       `json:"-"             yaml:"-"`
+}
+
+func (m AppConfig) Validate() error {
+	err := validation.ValidateStruct(
+		&m,
+		validation.Field(&m.Name, validation.Required, validation.By(appName)),
+		validation.Field(&m.Entities),
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
+
+// appName checks that the name makes a Go package name, the directory of the app is named the same.
+func appName(value any) error {
+	app := &AppConfig{Name: value.(string)}
+	if err := goName(app.AppName()); err != nil {
+		return err
+	}
+	return goName(app.AppAlias())
 }
 
 func (m *AppConfig) AppName() string {
@@ -23,3 +48,23 @@ func (m *AppConfig) AppName() string {
 func (m *AppConfig) AppAlias() string {
 	return strcase.ToLowerCamel(m.Name)
 }
+
+// CacheEnabled reports whether any entity of the app has a cached repository.
+func (m *AppConfig) CacheEnabled() bool {
+	for _, entity := range m.Entities {
+		if entity.CacheEnabled {
+			return true
+		}
+	}
+	return false
+}
+
+// MongoEnabled reports whether any entity of the app is stored in MongoDB.
+func (m *AppConfig) MongoEnabled() bool {
+	for _, entity := range m.Entities {
+		if entity.MongoEnabled() {
+			return true
+		}
+	}
+	return false
+}
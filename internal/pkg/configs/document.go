@@ -0,0 +1,312 @@
+package configs
+
+import (
+	"errors"
+	"fmt"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"gopkg.in/yaml.v3"
+)
+
+func init() {
+	// validation errors are keyed like the config, so they can be found in the YAML document
+	validation.ErrorTag = "yaml"
+}
+
+// problem - mistake in the config at a position of the YAML document.
+type problem struct {
+	line    int
+	column  int
+	path    string
+	message string
+}
+
+// document - parsed config with the nodes of every key by its path, like apps[0].entities[1].params[2].type.
+type document struct {
+	name     string
+	root     *yaml.Node
+	nodes    map[string]*yaml.Node
+	problems []problem
+}
+
+func parseDocument(name string, data []byte) (*document, error) {
+	d := &document{name: filepath.Base(name), root: &yaml.Node{}, nodes: map[string]*yaml.Node{}}
+	if err := yaml.Unmarshal(data, d.root); err != nil {
+		if match := syntaxErrorLine.FindStringSubmatch(err.Error()); match != nil {
+			return nil, fmt.Errorf("%s:%s: %s", d.name, match[1], match[2])
+		}
+		return nil, err
+	}
+	if len(d.root.Content) > 0 {
+		d.walk("", d.root.Content[0], reflect.TypeOf(Project{}))
+	}
+	return d, nil
+}
+
+// walk records the nodes of the keys of typ and reports the keys that do not belong to it.
+func (d *document) walk(path string, node *yaml.Node, typ reflect.Type) {
+	d.nodes[path] = node
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch {
+	case typ.Kind() == reflect.Struct && node.Kind == yaml.MappingNode:
+		fields := yamlFields(typ)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			field, ok := fields[key.Value]
+			if !ok {
+				d.add(key, join(path, key.Value), unknownKey(key.Value, fields))
+				continue
+			}
+			d.walk(join(path, key.Value), value, field.Type)
+		}
+	case typ.Kind() == reflect.Slice && node.Kind == yaml.SequenceNode:
+		for i, item := range node.Content {
+			d.walk(fmt.Sprintf("%s[%d]", path, i), item, typ.Elem())
+		}
+	}
+}
+
+// yamlFields returns the fields of typ by their YAML keys, the fields without a yaml tag are not part of the config.
+func yamlFields(typ reflect.Type) map[string]reflect.StructField {
+	fields := map[string]reflect.StructField{}
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+		fields[key] = field
+	}
+	return fields
+}
+
+func unknownKey(key string, fields map[string]reflect.StructField) string {
+	keys := make([]string, 0, len(fields))
+	for known := range fields {
+		keys = append(keys, known)
+	}
+	if suggestion := closest(key, keys); suggestion != "" {
+		return fmt.Sprintf("unknown key %s, did you mean %s?", key, suggestion)
+	}
+	slices.Sort(keys)
+	return fmt.Sprintf("unknown key %s, expected one of %s", key, strings.Join(keys, ", "))
+}
+
+func (d *document) add(node *yaml.Node, path, message string) {
+	d.problems = append(d.problems, problem{line: node.Line, column: node.Column, path: path, message: message})
+}
+
+// report adds the problem at the node of path, or of the closest parent when the key is not in the config.
+func (d *document) report(path, message string) {
+	for key := path; ; key = parent(key) {
+		if node, ok := d.nodes[key]; ok {
+			d.add(node, path, message)
+			return
+		}
+		if key == "" {
+			d.add(&yaml.Node{Line: 1, Column: 1}, path, message)
+			return
+		}
+	}
+}
+
+var (
+	syntaxErrorLine = regexp.MustCompile(`^yaml: line (\d+): (.*)$`)
+	typeErrorLine   = regexp.MustCompile(`^line (\d+): (.*)$`)
+)
+
+// reportDecoding adds the values that do not match the types of their keys, yaml reports them with lines only.
+func (d *document) reportDecoding(err error) error {
+	var typeError *yaml.TypeError
+	if !errors.As(err, &typeError) {
+		return err
+	}
+	for _, message := range typeError.Errors {
+		match := typeErrorLine.FindStringSubmatch(message)
+		if match == nil {
+			d.problems = append(d.problems, problem{message: message})
+			continue
+		}
+		line, _ := strconv.Atoi(match[1])
+		path, node := d.atLine(line)
+		if node == nil {
+			node = &yaml.Node{Line: line}
+		}
+		d.add(node, path, match[2])
+	}
+	return nil
+}
+
+// atLine returns the deepest key with the value starting at line, it is the one yaml failed to decode.
+func (d *document) atLine(line int) (string, *yaml.Node) {
+	var found string
+	var foundNode *yaml.Node
+	for path, node := range d.nodes {
+		if node.Line == line && (foundNode == nil || len(path) > len(found)) {
+			found, foundNode = path, node
+		}
+	}
+	return found, foundNode
+}
+
+// reportValidation adds the errors of the Validate methods at the keys they belong to.
+func (d *document) reportValidation(path string, err error) {
+	var errs validation.Errors
+	if !errors.As(err, &errs) {
+		if err != nil {
+			d.report(path, err.Error())
+		}
+		return
+	}
+	for key, keyErr := range errs {
+		if _, err := strconv.Atoi(key); err == nil {
+			d.reportValidation(fmt.Sprintf("%s[%s]", path, key), keyErr)
+			continue
+		}
+		d.reportValidation(join(path, key), keyErr)
+	}
+}
+
+// err returns the problems sorted by their positions as one error, or nil without problems.
+func (d *document) err() error {
+	if len(d.problems) == 0 {
+		return nil
+	}
+	slices.SortStableFunc(d.problems, func(a, b problem) int {
+		if a.line != b.line {
+			return a.line - b.line
+		}
+		return a.column - b.column
+	})
+	lines := make([]string, 0, len(d.problems))
+	for _, p := range d.problems {
+		position := fmt.Sprintf("%s:%d", d.name, p.line)
+		if p.column > 0 {
+			position += fmt.Sprintf(":%d", p.column)
+		}
+		if p.path != "" {
+			position += ": " + p.path
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", position, p.message))
+	}
+	return errors.New(strings.Join(lines, "\n"))
+}
+
+func join(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+func parent(path string) string {
+	if i := strings.LastIndexAny(path, ".["); i >= 0 {
+		return path[:i]
+	}
+	return ""
+}
+
+// checkNames reports the apps, entities and params named twice, names are compared the way they end up in code.
+func (d *document) checkNames(project *Project) {
+	apps := map[string]int{}
+	for i, app := range project.Apps {
+		path := fmt.Sprintf("apps[%d]", i)
+		if first, ok := apps[app.AppName()]; ok {
+			d.report(path+".name", fmt.Sprintf("app %s is already defined at apps[%d]", app.Name, first))
+		} else {
+			apps[app.AppName()] = i
+		}
+		entities := map[string]int{}
+		for j, entity := range app.Entities {
+			path := fmt.Sprintf("%s.entities[%d]", path, j)
+			if first, ok := entities[entity.TableName()]; ok {
+				d.report(path+".name", fmt.Sprintf("entity %s is already defined at entities[%d]", entity.Name, first))
+			} else {
+				entities[entity.TableName()] = j
+			}
+			params := map[string]int{}
+			for k, param := range entity.Params {
+				path := fmt.Sprintf("%s.params[%d]", path, k)
+				if first, ok := params[param.Tag()]; ok {
+					d.report(path+".name", fmt.Sprintf("param %s is already defined at params[%d]", param.Name, first))
+				} else {
+					params[param.Tag()] = k
+				}
+			}
+		}
+	}
+}
+
+// reservedNames are the params every entity has already.
+var reservedNames = []string{"id", "created_at", "updated_at"}
+
+// sqlKeywords are reserved by PostgreSQL, MySQL or SQLite and can not be used as table or column names unquoted.
+var sqlKeywords = []string{
+	"all", "alter", "and", "any", "as", "asc", "between", "by", "case", "check", "column", "constraint", "create",
+	"cross", "current_date", "current_time", "current_timestamp", "current_user", "default", "delete", "desc",
+	"distinct", "drop", "else", "end", "except", "exists", "false", "fetch", "for", "foreign", "from", "full",
+	"grant", "group", "groups", "having", "in", "index", "inner", "insert", "intersect", "into", "is", "join", "key",
+	"left", "like", "limit", "natural", "not", "null", "offset", "on", "or", "order", "outer", "primary",
+	"references", "right", "row", "rows", "select", "table", "then", "to", "true", "union", "unique", "update",
+	"user", "using", "values", "when", "where", "window", "with",
+}
+
+// goName checks that name makes a Go identifier that is not a keyword.
+func goName(name string) error {
+	if token.IsKeyword(name) {
+		return fmt.Errorf("%s is a Go keyword", name)
+	}
+	if !token.IsIdentifier(name) {
+		return fmt.Errorf("%s is not a valid Go identifier", name)
+	}
+	return nil
+}
+
+// sqlName checks that name is not an SQL keyword.
+func sqlName(name string) error {
+	if slices.Contains(sqlKeywords, strings.ToLower(name)) {
+		return fmt.Errorf("%s is an SQL keyword", name)
+	}
+	return nil
+}
+
+// closest returns the candidate that is at most two edits away from word, or an empty string.
+func closest(word string, candidates []string) string {
+	best, bestDistance := "", 3
+	for _, candidate := range candidates {
+		if distance := editDistance(strings.ToLower(word), strings.ToLower(candidate)); distance < bestDistance {
+			best, bestDistance = candidate, distance
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
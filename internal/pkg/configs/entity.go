@@ -14,25 +14,30 @@ import (
 )
 
 type EntityConfig struct {
-	Name         string   `json:"name"          yaml:"name"`
-	Module       string   `json:"module"        yaml:"module"`
-	ProjectName  string   `json:"project_name"  yaml:"projectName"`
-	ProtoPackage string   `json:"proto_package" yaml:"protoPackage"`
-	Params       []*Param `json:"params"        yaml:"params"`
-	HTTPEnabled  bool     `                     yaml:"http"`
-	GRPCEnabled  bool     `                     yaml:"gRPC"`
-	KafkaEnabled bool     `                     yaml:"kafka"`
-	AppConfig    *AppConfig
-	Entities     []*Entity
-}
-
-func (m *EntityConfig) Validate() error {
+	Name              string   `json:"name"          yaml:"name"`
+	Module            string   `json:"module"        yaml:"module"`
+	ProjectName       string   `json:"project_name"  yaml:"projectName"`
+	ProtoPackage      string   `json:"proto_package" yaml:"protoPackage"`
+	Params            []*Param `json:"params"        yaml:"params"`
+	HTTPEnabled       bool     `                     yaml:"http"`
+	GRPCEnabled       bool     `                     yaml:"gRPC"`
+	KafkaEnabled      bool     `                     yaml:"kafka"`
+	TypeScriptEnabled bool     `                     yaml:"typescript"`
+	WatchEnabled      bool     `                     yaml:"watch"`
+	CacheEnabled      bool     `                     yaml:"cache"`
+	Repository        string   `                     yaml:"repository"`
+	AppConfig         *AppConfig
+	Entities          []*Entity
+}
+
+func (m EntityConfig) Validate() error {
 	err := validation.ValidateStruct(
-		m,
-		validation.Field(&m.Name, validation.Required),
+		&m,
+		validation.Field(&m.Name, validation.Required, validation.By(entityName)),
 		validation.Field(&m.Module, validation.Required),
 		validation.Field(&m.ProjectName, validation.Required),
 		validation.Field(&m.Params),
+		validation.Field(&m.Repository, oneOf(Repositories)),
 	)
 	if err != nil {
 		return err
@@ -40,6 +45,17 @@ func (m *EntityConfig) Validate() error {
 	return nil
 }
 
+func entityName(value any) error {
+	entity := &EntityConfig{Name: value.(string)}
+	if err := goName(entity.Variable()); err != nil {
+		return err
+	}
+	if err := goName(entity.EntityName()); err != nil {
+		return err
+	}
+	return sqlName(entity.TableName())
+}
+
 func (m *EntityConfig) SearchVector() string {
 	var params []string
 	for _, param := range m.Params {
@@ -51,6 +67,17 @@ func (m *EntityConfig) SearchVector() string {
 	return vector
 }
 
+// SearchColumns returns the comma separated columns covered by the full-text index.
+func (m *EntityConfig) SearchColumns() string {
+	var columns []string
+	for _, param := range m.Params {
+		if param.Search {
+			columns = append(columns, param.Tag())
+		}
+	}
+	return strings.Join(columns, ", ")
+}
+
 func (m *EntityConfig) Variable() string {
 	return strcase.ToLowerCamel(m.Name)
 }
@@ -155,6 +182,10 @@ func (m *EntityConfig) SnakeName() string {
 	return strcase.ToSnake(m.Name)
 }
 
+func (m *EntityConfig) SpanName(layer, method string) string {
+	return fmt.Sprintf("%s.%s.%s.%s", m.AppName(), m.SnakeName(), layer, method)
+}
+
 func (m *EntityConfig) FileName() string {
 	return fmt.Sprintf("%s.go", m.SnakeName())
 }
@@ -164,7 +195,7 @@ func (m *EntityConfig) TestFileName() string {
 }
 
 func (m *EntityConfig) MigrationUpFileName() string {
-	last, err := lastMigration()
+	last, err := lastMigration(m.Project().Database)
 	if err != nil {
 		return ""
 	}
@@ -172,7 +203,7 @@ func (m *EntityConfig) MigrationUpFileName() string {
 }
 
 func (m *EntityConfig) MigrationDownFileName() string {
-	last, err := lastMigration()
+	last, err := lastMigration(m.Project().Database)
 	if err != nil {
 		return ""
 	}
@@ -199,6 +230,74 @@ func (m *EntityConfig) EventProducerTypeName() string {
 	return fmt.Sprintf("%sEventProducer", strcase.ToCamel(m.Name))
 }
 
+// EventProducerEnabled reports whether the usecase publishes created, updated
+// and deleted events, either to Kafka or to the in-process broadcaster.
+func (m *EntityConfig) EventProducerEnabled() bool {
+	return m.KafkaEnabled || m.GRPCEnabled && m.WatchEnabled
+}
+
+func (m *EntityConfig) EventBroadcasterConstructorName() string {
+	return fmt.Sprintf("New%s", m.EventBroadcasterTypeName())
+}
+
+func (m *EntityConfig) EventBroadcasterTypeName() string {
+	return fmt.Sprintf("%sEventBroadcaster", strcase.ToCamel(m.Name))
+}
+
+func (m *EntityConfig) GetEventBroadcasterPrivateVariableName() string {
+	return fmt.Sprintf("%sEventBroadcaster", strcase.ToLowerCamel(m.Name))
+}
+
+func (m *EntityConfig) EventBroadcasterInterfaceName() string {
+	return fmt.Sprintf("%sEventBroadcaster", strcase.ToLowerCamel(m.Name))
+}
+
+func (m *EntityConfig) CachedRepositoryConstructorName() string {
+	return fmt.Sprintf("New%s", m.CachedRepositoryTypeName())
+}
+
+func (m *EntityConfig) CachedRepositoryTypeName() string {
+	return fmt.Sprintf("%sCachedRepository", strcase.ToCamel(m.Name))
+}
+
+func (m *EntityConfig) GetCachedRepositoryPrivateVariableName() string {
+	return fmt.Sprintf("%sCachedRepository", strcase.ToLowerCamel(m.Name))
+}
+
+func (m *EntityConfig) CacheInterfaceName() string {
+	return fmt.Sprintf("%sCache", strcase.ToLowerCamel(m.Name))
+}
+
+// CacheKeyPrefix - prefix of the cache keys of the entity, unique across apps.
+func (m *EntityConfig) CacheKeyPrefix() string {
+	return fmt.Sprintf(
+		"%s.%s.%s.",
+		strcase.ToSnake(m.AppConfig.ProjectConfig.Name),
+		strcase.ToSnake(m.AppConfig.Name),
+		strcase.ToSnake(m.Name),
+	)
+}
+
+func (m *EntityConfig) WatcherInterfaceName() string {
+	return fmt.Sprintf("%sWatcher", strcase.ToLowerCamel(m.Name))
+}
+
+func (m *EntityConfig) GetWatcherPrivateVariableName() string {
+	return fmt.Sprintf("%sWatcher", strcase.ToLowerCamel(m.Name))
+}
+
+func (m *EntityConfig) EventTypeName() string {
+	return fmt.Sprintf("%sEvent", strcase.ToCamel(m.Name))
+}
+
+func (m *EntityConfig) GetGRPCEventDecodeName() string {
+	return fmt.Sprintf("decode%sEvent", m.GetMainModel().Name)
+}
+
+func (m *EntityConfig) GetGRPCEventMatchName() string {
+	return fmt.Sprintf("match%sEvent", m.GetMainModel().Name)
+}
+
 func (m *EntityConfig) EventProducerInterfaceName() string {
 	return fmt.Sprintf("%sEventProducer", strcase.ToLowerCamel(m.Name))
 }
@@ -239,6 +338,18 @@ func (m *EntityConfig) KafkaDeletedConsumerGroup() string {
 	)
 }
 
+// KafkaBroadcastEvents returns the events every instance consumes, without a consumer group: all of them feed
+// the Watch streams, the updated and deleted ones drop the cached entity.
+func (m *EntityConfig) KafkaBroadcastEvents() []string {
+	switch {
+	case m.GRPCEnabled && m.WatchEnabled:
+		return []string{"Created", "Updated", "Deleted"}
+	case m.CacheEnabled:
+		return []string{"Updated", "Deleted"}
+	}
+	return nil
+}
+
 func (m *EntityConfig) GetKafkaHandlerPrivateVariableName() string {
 	return fmt.Sprintf("kafka%sHandler", strcase.ToCamel(m.Name))
 }
@@ -289,6 +400,47 @@ func (m *EntityConfig) TableName() string {
 	return strcase.ToSnake(inflection.Plural(m.Name))
 }
 
+// QualifiedTableName returns the table name as it appears in generated queries.
+func (m *EntityConfig) QualifiedTableName() string {
+	if m.Project().Database == "postgres" {
+		return fmt.Sprintf("public.%s", m.TableName())
+	}
+	return m.TableName()
+}
+
+// Placeholder returns the n-th bind parameter of the project database.
+func (m *EntityConfig) Placeholder(n int) string {
+	if m.Project().PlaceholderFormat() == "Dollar" {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// ArrayConstructor returns the function that turns a slice param into a driver value.
+func (m *EntityConfig) ArrayConstructor(param *Param) string {
+	if m.Project().Database == "postgres" {
+		return "pq.Array"
+	}
+	return param.SQLDTOType(m.Project().Database)
+}
+
+// MongoEnabled reports whether the entity is stored in MongoDB instead of the project database.
+func (m *EntityConfig) MongoEnabled() bool {
+	return m.Repository == "mongo"
+}
+
+// RepositoryDir returns the directory of the entity repository under repositories.
+func (m *EntityConfig) RepositoryDir() string {
+	if m.MongoEnabled() {
+		return "mongo"
+	}
+	return m.Project().Database
+}
+
+func (m *EntityConfig) Project() *Project {
+	return m.AppConfig.ProjectConfig
+}
+
 func (m *EntityConfig) SearchEnabled() bool {
 	return slices.ContainsFunc(
 		m.GetMainModel().Params,
@@ -361,6 +513,14 @@ func (m *EntityConfig) GetHTTPPath() string {
 	return strcase.ToSnake(inflection.Plural(m.GetOneVariableName()))
 }
 
+func (m *EntityConfig) GetHTTPMountPath() string {
+	return fmt.Sprintf("/api/v1/%s/%s", m.AppName(), m.GetHTTPPath())
+}
+
+func (m *EntityConfig) TypeScriptFileName() string {
+	return fmt.Sprintf("%s.ts", strcase.ToKebab(m.Name))
+}
+
 func (m *EntityConfig) GetGRPCHandlerPrivateVariableName() string {
 	return fmt.Sprintf("grpc%sHandler", strcase.ToCamel(m.Name))
 }
@@ -381,6 +541,10 @@ func (m *EntityConfig) GetGRPCServiceDescriptionName() string {
 	return fmt.Sprintf("%sService_ServiceDesc", strcase.ToCamel(m.Name))
 }
 
+func (m *EntityConfig) GetGatewayRegisterName() string {
+	return fmt.Sprintf("Register%sServiceHandlerFromEndpoint", strcase.ToCamel(m.Name))
+}
+
 func (m *EntityConfig) GetGRPCCreateDTOEncodeName() string {
 	return fmt.Sprintf("encode%s", m.GetCreateModel().Name)
 }
@@ -532,6 +696,16 @@ func (m *EntityConfig) OrderingConsts() map[string]string {
 	return consts
 }
 
+// OrderingNames returns the names of the ordering consts in the order of the params, ASC before DESC.
+func (m *EntityConfig) OrderingNames() []string {
+	var names []string
+	for _, param := range m.GetMainModel().Params {
+		name := fmt.Sprintf("%s%s", m.OrderingTypeName(), strcase.ToCamel(param.Name))
+		names = append(names, name+"ASC", name+"DESC")
+	}
+	return names
+}
+
 func (m *EntityConfig) OrderingMap() map[string]string {
 	consts := map[string]string{}
 	for _, param := range m.GetMainModel().Params {
@@ -549,8 +723,25 @@ func (m *EntityConfig) OrderingMap() map[string]string {
 	return consts
 }
 
-func lastMigration() (int, error) {
-	dir, err := os.ReadDir(path.Join("internal", "pkg", "postgres", "migrations"))
+// MongoSort is the BSON sort key of an ordering const.
+type MongoSort struct {
+	Field     string
+	Direction int
+}
+
+// MongoOrderingMap is OrderingMap for MongoDB, keyed by the same ordering consts.
+func (m *EntityConfig) MongoOrderingMap() map[string]MongoSort {
+	sorts := map[string]MongoSort{}
+	for _, param := range m.GetMainModel().Params {
+		name := fmt.Sprintf("%s%s", m.OrderingTypeName(), strcase.ToCamel(param.Name))
+		sorts[name+"ASC"] = MongoSort{Field: param.BSONTag(), Direction: 1}
+		sorts[name+"DESC"] = MongoSort{Field: param.BSONTag(), Direction: -1}
+	}
+	return sorts
+}
+
+func lastMigration(database string) (int, error) {
+	dir, err := os.ReadDir(path.Join("internal", "pkg", database, "migrations"))
 	if err != nil {
 		return 0, err
 	}
@@ -2,6 +2,7 @@ package configs
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
@@ -12,23 +13,37 @@ type Param struct {
 	Name   string `json:"name"   yaml:"name"`
 	Type   string `json:"type"   yaml:"type"`
 	Search bool   `json:"search" yaml:"search"`
+	Unique bool   `json:"unique" yaml:"unique"`
+}
+
+// ParamTypes are the types of params the generators support. Floats are not among them, the fakes of the tests do not
+// compile for them.
+var ParamTypes = []string{
+	"bool",
+	"int", "int8", "int16", "int32", "int64",
+	"uint", "uint8", "uint16", "uint32", "uint64",
+	"string",
+	"time.Time",
+	"uuid.UUID",
+	"[]int", "[]int8", "[]int16", "[]int32", "[]int64",
+	"[]uint", "[]uint8", "[]uint16", "[]uint32", "[]uint64",
+	"[]string",
+	"[]time.Time",
+	"GroupID", "entities.GroupID", "*GroupID", "*entities.GroupID",
 }
 
 func (p *Param) Validate() error {
 	err := validation.ValidateStruct(
 		p,
-		validation.Field(&p.Name, validation.Required),
-		validation.Field(&p.Type, validation.Required, validation.In(
-			"int", "int64", "int32", "int16", "int8",
-			"[]int", "[]int64", "[]int32", "[]int16", "[]int8",
-			"uint", "uint64", "uint32", "uint16", "uint8",
-			"[]uint", "[]uint64", "[]uint32", "[]uint16", "[]uint8",
-			"string",
-			"[]string",
-			"time.Time",
-			"[]time.Time",
-			"GroupID", "entities.GroupID", "*GroupID", "*entities.GroupID",
-		)),
+		validation.Field(&p.Name, validation.Required, validation.By(paramName)),
+		validation.Field(&p.Type, validation.Required, validation.By(paramType)),
+		validation.Field(
+			&p.Search,
+			validation.When(
+				p.Search && p.SliceType() != "string",
+				validation.In(false).Error("requires a string or []string type"),
+			),
+		),
 	)
 	if err != nil {
 		return err
@@ -36,6 +51,31 @@ func (p *Param) Validate() error {
 	return nil
 }
 
+func paramName(value any) error {
+	param := &Param{Name: value.(string)}
+	if slices.Contains(reservedNames, param.Tag()) {
+		return fmt.Errorf("%s is generated for every entity", param.Name)
+	}
+	if err := goName(param.GetPrivateName()); err != nil {
+		return err
+	}
+	return sqlName(param.Tag())
+}
+
+func paramType(value any) error {
+	typ := value.(string)
+	if slices.Contains(ParamTypes, typ) {
+		return nil
+	}
+	if strings.HasPrefix((&Param{Type: typ}).SliceType(), "float") {
+		return fmt.Errorf("unsupported type %s, floats are not supported yet", typ)
+	}
+	if suggestion := closest(typ, ParamTypes); suggestion != "" {
+		return fmt.Errorf("unsupported type %s, did you mean %s?", typ, suggestion)
+	}
+	return fmt.Errorf("unsupported type %s, expected one of %s", typ, strings.Join(ParamTypes, ", "))
+}
+
 func (p *Param) IsSlice() bool {
 	return strings.HasPrefix(strings.TrimPrefix(p.Type, "*"), "[]")
 }
@@ -149,6 +189,73 @@ func (p *Param) SQLType() string {
 	}
 }
 
+// MySQLType returns the MySQL column type, slices are stored as JSON documents.
+func (p *Param) MySQLType() string {
+	if p.IsSlice() && p.Type != "[]byte" {
+		return "JSON"
+	}
+	switch p.Type {
+	case "int8", "int16", "int32", "int":
+		return "INT"
+	case "float32":
+		return "FLOAT"
+	case "float64":
+		return "DOUBLE"
+	case "int64", "uint", "uint8", "uint16", "uint32", "uint64", "time.Duration":
+		return "BIGINT"
+	case "[]byte":
+		return "BLOB"
+	case "string":
+		switch p.Name {
+		case "title", "name":
+			return "VARCHAR(255)"
+		default:
+			return "TEXT"
+		}
+	case "uuid", "UUID", "uuid.UUID":
+		return "CHAR(36)"
+	case "GroupID", "entities.GroupID":
+		return "VARCHAR(255)"
+	case "time.Time":
+		if p.Name == "date" {
+			return "DATE"
+		}
+		return "DATETIME(6)"
+	case "bool":
+		return "BOOLEAN"
+	default:
+		return "/* FIXME */"
+	}
+}
+
+// SQLiteType returns the SQLite column type, slices are stored as JSON documents.
+func (p *Param) SQLiteType() string {
+	if p.IsSlice() && p.Type != "[]byte" {
+		return "TEXT"
+	}
+	switch p.Type {
+	case "int8", "int16", "int32", "int", "int64", "time.Duration":
+		return "INTEGER"
+	case "uint", "uint8", "uint16", "uint32", "uint64":
+		return "INTEGER"
+	case "float32", "float64":
+		return "REAL"
+	case "[]byte":
+		return "BLOB"
+	case "string", "uuid", "UUID", "uuid.UUID", "GroupID", "entities.GroupID":
+		return "TEXT"
+	case "time.Time":
+		if p.Name == "date" {
+			return "DATE"
+		}
+		return "DATETIME"
+	case "bool":
+		return "BOOLEAN"
+	default:
+		return "/* FIXME */"
+	}
+}
+
 func (p *Param) GetGRPCWrapper() string {
 	switch p.Type {
 	case "*int", "*int32", "*int8", "*int16":
@@ -283,6 +390,26 @@ func (p *Param) JsonType() string {
 	return p.Type
 }
 
+func (p *Param) TypeScriptType() string {
+	jsonType := strings.TrimPrefix(p.JsonType(), "*")
+	if strings.HasPrefix(jsonType, "[]") {
+		item := &Param{Name: p.Name, Type: strings.TrimPrefix(jsonType, "[]")}
+		return fmt.Sprintf("%s[]", item.TypeScriptType())
+	}
+	switch jsonType {
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64",
+		"float32", "float64", "time.Duration":
+		return "number"
+	case "string", "uuid", "UUID", "uuid.UUID", "GroupID", "entities.GroupID", "time.Time":
+		return "string"
+	case "bool":
+		return "boolean"
+	default:
+		return "unknown"
+	}
+}
+
 func (p *Param) PostgresDTOType() string {
 	switch p.Type {
 	case "int8", "int16", "int32", "int":
@@ -346,6 +473,38 @@ func (p *Param) PostgresDTOSliceType() string {
 	}
 }
 
+// SQLDTOType returns the repository DTO type for databases without native arrays,
+// slices are wrapped into the JSON backed Array of the database package.
+func (p *Param) SQLDTOType(database string) string {
+	if p.Type == "[]byte" {
+		return "[]byte"
+	}
+	if p.IsSlice() {
+		return fmt.Sprintf("%s.Array[%s]", database, p.SliceType())
+	}
+	return p.PostgresDTOType()
+}
+
+// BSONType returns the type of the param in the MongoDB document, IDs are stored as strings.
+func (p *Param) BSONType() string {
+	switch {
+	case p.IsID() && strings.HasPrefix(p.Type, "*"):
+		return "*string"
+	case p.IsID():
+		return "string"
+	default:
+		return p.Type
+	}
+}
+
+// BSONTag returns the document field of the param, the entity ID is the document _id.
+func (p *Param) BSONTag() string {
+	if p.GetName() == "ID" {
+		return "_id"
+	}
+	return p.Tag()
+}
+
 func (p *Param) GRPCGetter() string {
 	return fmt.Sprintf("Get%s", p.GRPCParam())
 }
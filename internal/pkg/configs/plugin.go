@@ -0,0 +1,24 @@
+package configs
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// PluginConfig - external generator, it reads the resolved project from stdin and answers with files to write.
+type PluginConfig struct {
+	Name    string   `yaml:"name"`
+	Command string   `yaml:"command"`
+	Args    []string `yaml:"args"`
+}
+
+func (p PluginConfig) Validate() error {
+	err := validation.ValidateStruct(
+		&p,
+		validation.Field(&p.Name, validation.Required),
+		validation.Field(&p.Command, validation.Required),
+	)
+	if err != nil {
+		return err
+	}
+	return nil
+}
@@ -2,26 +2,59 @@ package configs
 
 import (
 	"fmt"
-	"log"
 	"os"
+	"strings"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/iancoleman/strcase"
-	"gopkg.in/yaml.v3"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/errs"
 )
 
 type Project struct {
-	Name           string      `yaml:"name"`
-	Module         string      `yaml:"module"`
-	GoVersion      string      `yaml:"goVersion"`
-	CI             string      `yaml:"ci"`
-	Apps           []AppConfig `yaml:"apps"`
-	GRPCEnabled    bool        `yaml:"gRPC"`
-	MakeEnabled    bool        `yaml:"make"`
-	TaskEnabled    bool        `yaml:"task"`
-	UptraceEnabled bool        `yaml:"uptrace"`
-	KafkaEnabled   bool        `yaml:"kafka"`
-	HTTPEnabled    bool        `yaml:"http"`
+	Name              string         `yaml:"name"`
+	Module            string         `yaml:"module"`
+	GoVersion         string         `yaml:"goVersion"`
+	CI                string         `yaml:"ci"`
+	Apps              []AppConfig    `yaml:"apps"`
+	GRPCEnabled       bool           `yaml:"gRPC"`
+	MakeEnabled       bool           `yaml:"make"`
+	TaskEnabled       bool           `yaml:"task"`
+	UptraceEnabled    bool           `yaml:"uptrace"`
+	KafkaEnabled      bool           `yaml:"kafka"`
+	HTTPEnabled       bool           `yaml:"http"`
+	TypeScriptEnabled bool           `yaml:"typescript"`
+	WatchEnabled      bool           `yaml:"watch"`
+	GatewayEnabled    bool           `yaml:"gateway"`
+	RateLimitEnabled  bool           `yaml:"rateLimit"`
+	Database          string         `yaml:"database"`
+	Driver            string         `yaml:"driver"`
+	Metrics           string         `yaml:"metrics"`
+	Tracing           string         `yaml:"tracing"`
+	Templates         string         `yaml:"templates"`
+	Plugins           []PluginConfig `yaml:"plugins"`
+}
+
+// Values of the keys that take one of a list, the validation and the JSON Schema use them.
+var (
+	CIs          = []string{"github", "gitlab"}
+	Databases    = []string{"postgres", "mysql", "sqlite"}
+	Drivers      = []string{"pq", "pgx"}
+	Metrics      = []string{"prometheus"}
+	Tracings     = []string{"uptrace", "otlp"}
+	Repositories = []string{"mongo"}
+)
+
+// oneOf checks that the value is one of values.
+func oneOf(values []string) validation.Rule {
+	elements := make([]any, 0, len(values))
+	for _, value := range values {
+		elements = append(elements, value)
+	}
+	message := "must be " + values[0]
+	if len(values) > 1 {
+		message = fmt.Sprintf("must be %s or %s", strings.Join(values[:len(values)-1], ", "), values[len(values)-1])
+	}
+	return validation.In(elements...).Error(message)
 }
 
 func NewProject(configPath string) (*Project, error) {
@@ -36,13 +69,21 @@ func NewProject(configPath string) (*Project, error) {
 		TaskEnabled:    true,
 		UptraceEnabled: false,
 		KafkaEnabled:   false,
+		Database:       "postgres",
 	}
 	file, err := os.ReadFile(configPath)
 	if err != nil {
 		return nil, err
 	}
-	if err := yaml.Unmarshal(file, project); err != nil {
-		log.Fatalf("error: %v", err)
+	document, err := parseDocument(configPath, file)
+	if err != nil {
+		return nil, errs.NewInvalidConfigError(configPath, err.Error())
+	}
+	if err := document.reportDecoding(document.root.Decode(project)); err != nil {
+		return nil, errs.NewInvalidConfigError(configPath, err.Error())
+	}
+	if project.Tracing == "uptrace" {
+		project.UptraceEnabled = true
 	}
 
 	for i, app := range project.Apps {
@@ -52,6 +93,9 @@ func NewProject(configPath string) (*Project, error) {
 		app.GRPCEnabled = project.GRPCEnabled
 		app.HTTPEnabled = project.HTTPEnabled
 		app.KafkaEnabled = project.KafkaEnabled
+		app.TypeScriptEnabled = project.TypeScriptEnabled
+		app.WatchEnabled = project.WatchEnabled
+		app.GatewayEnabled = project.GatewayEnabled
 		for i2, entity := range app.Entities {
 			entity.Module = project.Module
 			entity.ProjectName = project.Name
@@ -59,11 +103,18 @@ func NewProject(configPath string) (*Project, error) {
 			entity.GRPCEnabled = project.GRPCEnabled
 			entity.HTTPEnabled = project.HTTPEnabled
 			entity.KafkaEnabled = project.KafkaEnabled
+			entity.TypeScriptEnabled = project.TypeScriptEnabled
+			entity.WatchEnabled = project.WatchEnabled
 			app.Entities[i2] = entity
 		}
 		app.ProjectConfig = project
 		project.Apps[i] = app
 	}
+	document.reportValidation("", project.Validate())
+	document.checkNames(project)
+	if err := document.err(); err != nil {
+		return nil, errs.NewInvalidConfigError(configPath, err.Error())
+	}
 	return project, nil
 }
 
@@ -73,9 +124,46 @@ func (p *Project) Validate() error {
 		validation.Field(&p.Name, validation.Required),
 		validation.Field(&p.Module, validation.Required),
 		validation.Field(&p.GoVersion, validation.Required),
-		validation.Field(&p.CI),
+		validation.Field(&p.CI, oneOf(CIs)),
 		validation.Field(&p.Apps),
 		validation.Field(&p.GRPCEnabled),
+		validation.Field(
+			&p.Database,
+			validation.Required,
+			oneOf(Databases),
+		),
+		validation.Field(
+			&p.Driver,
+			oneOf(Drivers),
+			validation.When(
+				p.Database != "postgres",
+				validation.In("").Error("requires postgres"),
+			),
+		),
+		validation.Field(&p.Metrics, oneOf(Metrics)),
+		validation.Field(&p.Plugins),
+		validation.Field(
+			&p.Tracing,
+			oneOf(Tracings),
+			validation.When(
+				p.UptraceEnabled,
+				validation.In("", "uptrace").Error("conflicts with uptrace"),
+			),
+		),
+		validation.Field(
+			&p.GatewayEnabled,
+			validation.When(
+				p.GatewayEnabled && (!p.GRPCEnabled || !p.HTTPEnabled),
+				validation.In(false).Error("requires gRPC and http"),
+			),
+		),
+		validation.Field(
+			&p.RateLimitEnabled,
+			validation.When(
+				p.RateLimitEnabled && !p.GRPCEnabled && !p.HTTPEnabled,
+				validation.In(false).Error("requires gRPC or http"),
+			),
+		),
 	)
 	if err != nil {
 		return err
@@ -83,6 +171,16 @@ func (p *Project) Validate() error {
 	return nil
 }
 
+// PrometheusEnabled reports whether the project exports Prometheus metrics.
+func (p *Project) PrometheusEnabled() bool {
+	return p.Metrics == "prometheus"
+}
+
+// OTLPEnabled reports whether the project exports traces to an OTLP collector.
+func (p *Project) OTLPEnabled() bool {
+	return p.Tracing == "otlp"
+}
+
 func (p *Project) ProtoPackage() string {
 	return fmt.Sprintf("%spb", strcase.ToSnake(p.Name))
 }
@@ -109,8 +207,58 @@ func (p *Project) UptraceImportPath() string {
 	return fmt.Sprintf(`"%s/internal/pkg/uptrace"`, p.Module)
 }
 
-func (p *Project) PostgresImportPath() string {
-	return fmt.Sprintf(`"%s/internal/pkg/postgres"`, p.Module)
+func (p *Project) TracingImportPath() string {
+	return fmt.Sprintf(`"%s/internal/pkg/tracing"`, p.Module)
+}
+
+// DatabaseImportPath returns the import path of the connection package of the selected database.
+func (p *Project) DatabaseImportPath() string {
+	return fmt.Sprintf(`"%s/internal/pkg/%s"`, p.Module, p.Database)
+}
+
+// PgxEnabled reports whether the postgres connection runs on a pgx/v5 pool instead of lib/pq.
+func (p *Project) PgxEnabled() bool {
+	return p.Database == "postgres" && p.Driver == "pgx"
+}
+
+// DatabaseSystem returns the OpenTelemetry db.system value of the selected database.
+func (p *Project) DatabaseSystem() string {
+	if p.Database == "postgres" {
+		return "postgresql"
+	}
+	return p.Database
+}
+
+// PlaceholderFormat returns the squirrel placeholder format understood by the database driver.
+func (p *Project) PlaceholderFormat() string {
+	if p.Database == "postgres" {
+		return "Dollar"
+	}
+	return "Question"
+}
+
+// DatabaseErrorFunc returns the errs constructor that maps driver errors to domain errors.
+func (p *Project) DatabaseErrorFunc() string {
+	switch p.Database {
+	case "mysql":
+		return "FromMySQLError"
+	case "sqlite":
+		return "FromSQLiteError"
+	default:
+		return "FromPostgresError"
+	}
+}
+
+// DatabaseMockFunc returns the sqlmock constructor of the database package.
+func (p *Project) DatabaseMockFunc() string {
+	switch p.Database {
+	case "mysql":
+		return "NewMockMySQL"
+	case "sqlite":
+		return "NewMockSQLite"
+	default:
+		return "NewMockPostgreSQL"
+	}
 }
 
 func (p *Project) PointerImportPath() string {
@@ -133,6 +281,50 @@ func (p *Project) ConfigsImportPath() string {
 	return fmt.Sprintf(`"%s/internal/pkg/configs"`, p.Module)
 }
 
+func (p *Project) BroadcastImportPath() string {
+	return fmt.Sprintf(`"%s/internal/pkg/broadcast"`, p.Module)
+}
+
+func (p *Project) MetricsImportPath() string {
+	return fmt.Sprintf(`"%s/internal/pkg/metrics"`, p.Module)
+}
+
+// CacheEnabled reports whether any entity of the project has a cached repository.
+func (p *Project) CacheEnabled() bool {
+	for _, app := range p.Apps {
+		if app.CacheEnabled() {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Project) CacheImportPath() string {
+	return fmt.Sprintf(`"%s/internal/pkg/cache"`, p.Module)
+}
+
+// MongoEnabled reports whether any entity of the project is stored in MongoDB.
+func (p *Project) MongoEnabled() bool {
+	for _, app := range p.Apps {
+		if app.MongoEnabled() {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *Project) MongoImportPath() string {
+	return fmt.Sprintf(`"%s/internal/pkg/mongo"`, p.Module)
+}
+
+func (p *Project) RateLimitImportPath() string {
+	return fmt.Sprintf(`"%s/internal/pkg/ratelimit"`, p.Module)
+}
+
+func (p *Project) HealthImportPath() string {
+	return fmt.Sprintf(`"%s/internal/pkg/health"`, p.Module)
+}
+
 func (p *Project) ClockImportPath() string {
 	return fmt.Sprintf(`"%s/internal/pkg/clock"`, p.Module)
 }
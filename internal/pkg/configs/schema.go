@@ -0,0 +1,158 @@
+package configs
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+)
+
+const schemaID = "https://raw.githubusercontent.com/mikalai-mitsin/creathor/main/docs/creathor.schema.json"
+
+// schemaKey - what the JSON Schema says about a config key beyond its Go type.
+type schemaKey struct {
+	description string
+	enum        []string
+	required    bool
+	// derived keys are set from the project by NewProject, a value in the config is ignored
+	derived bool
+}
+
+// schemaKeys describe the config keys by the Go type and the yaml key of their field.
+var schemaKeys = map[string]schemaKey{
+	"Project.name":       {description: "Name of the service, used for the binary, the chart and the proto package.", required: true},
+	"Project.module":     {description: "Go module path of the generated project.", required: true},
+	"Project.goVersion":  {description: "Go version of go.mod and the Dockerfile, 1.24 by default."},
+	"Project.ci":         {description: "CI pipelines to generate, github by default.", enum: CIs},
+	"Project.apps":       {description: "Apps of the service, every app is a package under internal/app."},
+	"Project.gRPC":       {description: "Generate gRPC handlers and proto files, true by default."},
+	"Project.make":       {description: "Generate a Makefile."},
+	"Project.task":       {description: "Generate a Taskfile, true by default."},
+	"Project.uptrace":    {description: "Trace with the Uptrace client, the same as tracing: uptrace."},
+	"Project.kafka":      {description: "Publish and consume entity events with Kafka."},
+	"Project.http":       {description: "Generate chi HTTP handlers."},
+	"Project.typescript": {description: "Generate TypeScript clients of the HTTP API, requires http."},
+	"Project.watch":      {description: "Add a server-streaming Watch RPC to every service, requires gRPC."},
+	"Project.gateway":    {description: "Serve the gRPC services over HTTP with grpc-gateway, requires gRPC and http."},
+	"Project.rateLimit":  {description: "Limit requests with an in-memory token bucket, requires gRPC or http."},
+	"Project.database":   {description: "SQL dialect of the repositories, postgres by default.", enum: Databases},
+	"Project.driver":     {description: "PostgreSQL driver, pq by default.", enum: Drivers},
+	"Project.metrics":    {description: "Metrics exporter.", enum: Metrics},
+	"Project.tracing":    {description: "Tracing provider.", enum: Tracings},
+	"Project.templates":  {description: "Directory of templates overriding the built-in ones, relative to the config."},
+	"Project.plugins":    {description: "External generators run after the built-in ones."},
+
+	"AppConfig.name":         {description: "Name of the app.", required: true},
+	"AppConfig.entities":     {description: "Entities of the app."},
+	"AppConfig.module":       {derived: true},
+	"AppConfig.projectName":  {derived: true},
+	"AppConfig.protoPackage": {derived: true},
+	"AppConfig.http":         {derived: true},
+	"AppConfig.gRPC":         {derived: true},
+	"AppConfig.kafka":        {derived: true},
+	"AppConfig.typescript":   {derived: true},
+	"AppConfig.watch":        {derived: true},
+	"AppConfig.gateway":      {derived: true},
+
+	"EntityConfig.name":         {description: "Name of the entity in singular, the table is named in plural.", required: true},
+	"EntityConfig.params":       {description: "Params of the entity besides id, created_at and updated_at."},
+	"EntityConfig.cache":        {description: "Put a read-through cache in front of the repository."},
+	"EntityConfig.repository":   {description: "Keep the entity in MongoDB instead of the SQL database.", enum: Repositories},
+	"EntityConfig.module":       {derived: true},
+	"EntityConfig.projectName":  {derived: true},
+	"EntityConfig.protoPackage": {derived: true},
+	"EntityConfig.http":         {derived: true},
+	"EntityConfig.gRPC":         {derived: true},
+	"EntityConfig.kafka":        {derived: true},
+	"EntityConfig.typescript":   {derived: true},
+	"EntityConfig.watch":        {derived: true},
+
+	"Param.name":   {description: "Name of the param, the column is named in snake case.", required: true},
+	"Param.type":   {description: "Go type of the param.", enum: ParamTypes, required: true},
+	"Param.search": {description: "Include the param in the full-text search, requires a string or []string type."},
+	"Param.unique": {description: "Add a unique constraint or index."},
+
+	"PluginConfig.name":    {description: "Name of the plugin in the errors and the manifest.", required: true},
+	"PluginConfig.command": {description: "Command reading the project from stdin and answering with files.", required: true},
+	"PluginConfig.args":    {description: "Arguments of the command."},
+}
+
+// Schema returns the JSON Schema of the config, built from the yaml keys of Project and the types it holds.
+func Schema() ([]byte, error) {
+	schema := map[string]any{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"$id":     schemaID,
+		"title":   "creathor.yaml",
+		"$defs":   map[string]any{},
+	}
+	root := typeSchema(reflect.TypeOf(Project{}), schema["$defs"].(map[string]any))
+	for name, value := range root {
+		schema[name] = value
+	}
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// typeSchema returns the schema of typ, the structs it holds are added to defs and referenced.
+func typeSchema(typ reflect.Type, defs map[string]any) map[string]any {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": typeSchema(typ.Elem(), defs)}
+	case reflect.Struct:
+		if typ.Name() != "Project" {
+			if _, ok := defs[typ.Name()]; !ok {
+				// the placeholder stops the recursion of types referencing each other
+				defs[typ.Name()] = nil
+				defs[typ.Name()] = structSchema(typ, defs)
+			}
+			return map[string]any{"$ref": "#/$defs/" + typ.Name()}
+		}
+		return structSchema(typ, defs)
+	}
+	return map[string]any{}
+}
+
+func structSchema(typ reflect.Type, defs map[string]any) map[string]any {
+	properties := map[string]any{}
+	required := []string{}
+	for name, field := range yamlFields(typ) {
+		property := typeSchema(field.Type, defs)
+		info := schemaKeys[typ.Name()+"."+name]
+		switch {
+		case info.derived:
+			property["description"] = "Set from the project, a value here is ignored."
+			property["deprecated"] = true
+		case info.description != "":
+			property["description"] = info.description
+		}
+		if len(info.enum) > 0 {
+			property["enum"] = info.enum
+		}
+		if info.required {
+			required = append(required, name)
+		}
+		properties[name] = property
+	}
+	schema := map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(required) > 0 {
+		slices.Sort(required)
+		schema["required"] = required
+	}
+	return schema
+}
@@ -0,0 +1,22 @@
+package configs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// The published schema is written by go generate, the test fails when the config types changed without it.
+func TestSchema(t *testing.T) {
+	got, err := Schema()
+	if err != nil {
+		t.Fatalf("Schema() error = %v", err)
+	}
+	want, err := os.ReadFile(filepath.Join("..", "..", "..", "docs", "creathor.schema.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("docs/creathor.schema.json is out of date, run go generate")
+	}
+}
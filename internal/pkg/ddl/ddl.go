@@ -0,0 +1,442 @@
+// Package ddl reads the tables of a PostgreSQL schema from the DDL statements creating it, like a pg_dump or the
+// up migrations, without a database.
+package ddl
+
+import (
+	"strings"
+)
+
+// Schema - tables of a DDL script with their columns, constraints and indexes.
+type Schema struct {
+	Tables []*Table
+	// Others are the statements not attached to a table, like CREATE EXTENSION, CREATE SEQUENCE or SET.
+	Others []string
+}
+
+type Table struct {
+	Schema      string
+	Name        string
+	Columns     []*Column
+	PrimaryKey  []string
+	Unique      [][]string
+	ForeignKeys []*ForeignKey
+	Indexes     []*Index
+	// Statements are the sources of the CREATE TABLE and of the statements altering or indexing the table, in the
+	// order of the script and without the semicolons.
+	Statements []string
+}
+
+type Column struct {
+	Name string
+	// Type is the type as written, lower cased with the spaces normalised: character varying(255), int[].
+	Type      string
+	NotNull   bool
+	Generated *Expression
+}
+
+type ForeignKey struct {
+	Columns    []string
+	Table      string
+	References []string
+}
+
+type Index struct {
+	Name        string
+	Unique      bool
+	Method      string
+	Expressions []*Expression
+}
+
+// Expression - SQL expression with the identifiers it reads and the functions it calls, the identifiers are not
+// resolved and include the operator classes and the ordering keywords of index expressions.
+type Expression struct {
+	Text      string
+	Columns   []string
+	Functions []string
+}
+
+// Table returns the table named name, or nil.
+func (s *Schema) Table(name string) *Table {
+	for _, table := range s.Tables {
+		if table.Name == name {
+			return table
+		}
+	}
+	return nil
+}
+
+// Column returns the column named name, or nil.
+func (t *Table) Column(name string) *Column {
+	for _, column := range t.Columns {
+		if column.Name == name {
+			return column
+		}
+	}
+	return nil
+}
+
+// Parse reads the tables of source into a new schema.
+func Parse(source string) (*Schema, error) {
+	schema := &Schema{}
+	if err := schema.Read(source); err != nil {
+		return nil, err
+	}
+	return schema, nil
+}
+
+// Read adds the tables of source to the schema, the statements of source may alter and index the tables read
+// before. The statements it does not understand are kept in Others, it fails only on unterminated strings and
+// comments.
+func (s *Schema) Read(source string) error {
+	tokens, err := lex(source)
+	if err != nil {
+		return err
+	}
+	for _, statement := range split(tokens, ";") {
+		p := &parser{source: source, tokens: statement}
+		if !p.statement(s) {
+			s.Others = append(s.Others, p.text(statement))
+		}
+	}
+	return nil
+}
+
+// parser - cursor over the tokens of a statement or of a part of it.
+type parser struct {
+	source   string
+	tokens   []token
+	position int
+}
+
+func (p *parser) sub(tokens []token) *parser {
+	return &parser{source: p.source, tokens: tokens}
+}
+
+func (p *parser) done() bool {
+	return p.position >= len(p.tokens)
+}
+
+func (p *parser) peek() token {
+	if p.done() {
+		return token{kind: tokenSymbol}
+	}
+	return p.tokens[p.position]
+}
+
+func (p *parser) next() token {
+	t := p.peek()
+	p.position++
+	return t
+}
+
+// word advances past the current token when it is one of the words.
+func (p *parser) word(words ...string) bool {
+	if p.peek().is(tokenWord, words...) {
+		p.position++
+		return true
+	}
+	return false
+}
+
+func (p *parser) ifNotExists() {
+	if p.peek().is(tokenWord, "if") {
+		p.position += 3
+	}
+}
+
+func (p *parser) ident() (string, bool) {
+	t := p.peek()
+	if t.kind != tokenWord && t.kind != tokenQuoted {
+		return "", false
+	}
+	p.position++
+	return t.value, true
+}
+
+// name reads a name qualified by a schema or not.
+func (p *parser) name() (string, string, bool) {
+	name, ok := p.ident()
+	if !ok {
+		return "", "", false
+	}
+	if !p.peek().is(tokenSymbol, ".") {
+		return "", name, true
+	}
+	p.position++
+	qualified, ok := p.ident()
+	return name, qualified, ok
+}
+
+// group reads the tokens between the parenthesis at the cursor and the matching one.
+func (p *parser) group() ([]token, bool) {
+	if !p.peek().is(tokenSymbol, "(") {
+		return nil, false
+	}
+	depth := 0
+	for i := p.position; i < len(p.tokens); i++ {
+		switch {
+		case p.tokens[i].is(tokenSymbol, "("):
+			depth++
+		case p.tokens[i].is(tokenSymbol, ")"):
+			depth--
+			if depth == 0 {
+				group := p.tokens[p.position+1 : i]
+				p.position = i + 1
+				return group, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (p *parser) text(tokens []token) string {
+	if len(tokens) == 0 {
+		return ""
+	}
+	return p.source[tokens[0].start:tokens[len(tokens)-1].end]
+}
+
+func (p *parser) statement(schema *Schema) bool {
+	switch {
+	case p.word("create"):
+		p.word("global", "local")
+		p.word("temporary", "temp", "unlogged")
+		switch {
+		case p.word("table"):
+			return p.createTable(schema)
+		case p.word("index"):
+			return p.createIndex(schema, false)
+		case p.word("unique") && p.word("index"):
+			return p.createIndex(schema, true)
+		}
+	case p.word("alter") && p.word("table"):
+		return p.alterTable(schema)
+	}
+	return false
+}
+
+func (p *parser) createTable(schema *Schema) bool {
+	p.ifNotExists()
+	schemaName, name, ok := p.name()
+	if !ok {
+		return false
+	}
+	elements, ok := p.group()
+	if !ok {
+		return false
+	}
+	table := &Table{Schema: schemaName, Name: name, Statements: []string{p.text(p.tokens)}}
+	for _, element := range split(elements, ",") {
+		p.sub(element).element(table)
+	}
+	schema.Tables = append(schema.Tables, table)
+	return true
+}
+
+func (p *parser) createIndex(schema *Schema, unique bool) bool {
+	p.word("concurrently")
+	p.ifNotExists()
+	index := &Index{Unique: unique}
+	if !p.peek().is(tokenWord, "on") {
+		_, index.Name, _ = p.name()
+	}
+	if !p.word("on") {
+		return false
+	}
+	p.word("only")
+	_, name, ok := p.name()
+	table := schema.Table(name)
+	if !ok || table == nil {
+		return false
+	}
+	if p.word("using") {
+		index.Method, _ = p.ident()
+	}
+	expressions, ok := p.group()
+	if !ok {
+		return false
+	}
+	for _, expression := range split(expressions, ",") {
+		index.Expressions = append(index.Expressions, p.expression(expression))
+	}
+	table.Indexes = append(table.Indexes, index)
+	table.Statements = append(table.Statements, p.text(p.tokens))
+	return true
+}
+
+// alterTable reads the columns and the constraints added to a table, the other actions are kept as they are.
+func (p *parser) alterTable(schema *Schema) bool {
+	if p.word("if") {
+		p.word("exists")
+	}
+	p.word("only")
+	_, name, ok := p.name()
+	table := schema.Table(name)
+	if !ok || table == nil {
+		return false
+	}
+	for _, action := range split(p.tokens[p.position:], ",") {
+		action := p.sub(action)
+		if !action.word("add") {
+			continue
+		}
+		action.word("column")
+		action.ifNotExists()
+		action.element(table)
+	}
+	table.Statements = append(table.Statements, p.text(p.tokens))
+	return true
+}
+
+// element reads a column or a table constraint.
+func (p *parser) element(table *Table) {
+	if p.word("constraint") {
+		p.ident()
+	}
+	switch {
+	case p.word("primary"):
+		p.word("key")
+		table.PrimaryKey = p.columns()
+	case p.word("unique"):
+		table.Unique = append(table.Unique, p.columns())
+	case p.word("foreign"):
+		p.word("key")
+		columns := p.columns()
+		if p.word("references") {
+			foreignKey := p.references()
+			foreignKey.Columns = columns
+			table.ForeignKeys = append(table.ForeignKeys, foreignKey)
+		}
+	case p.peek().is(tokenWord, "check", "exclude", "like"):
+	default:
+		p.column(table)
+	}
+}
+
+// columnConstraints end the type of a column.
+var columnConstraints = []string{
+	"constraint", "not", "null", "default", "primary", "unique", "references", "check", "generated", "collate",
+}
+
+func (p *parser) column(table *Table) {
+	name, ok := p.ident()
+	if !ok {
+		return
+	}
+	column := &Column{Name: name}
+	start := p.position
+	for !p.done() && !p.peek().is(tokenWord, columnConstraints...) {
+		if _, ok := p.group(); !ok {
+			p.next()
+		}
+	}
+	column.Type = typeName(p.tokens[start:p.position])
+	for !p.done() {
+		switch {
+		case p.word("constraint"):
+			p.ident()
+		case p.word("not"):
+			column.NotNull = p.word("null") || column.NotNull
+		case p.word("primary"):
+			p.word("key")
+			table.PrimaryKey = []string{name}
+		case p.word("unique"):
+			table.Unique = append(table.Unique, []string{name})
+		case p.word("references"):
+			foreignKey := p.references()
+			foreignKey.Columns = []string{name}
+			table.ForeignKeys = append(table.ForeignKeys, foreignKey)
+		case p.word("generated"):
+			p.word("always", "by")
+			p.word("default")
+			if p.word("as") {
+				if expression, ok := p.group(); ok {
+					column.Generated = p.expression(expression)
+				}
+			}
+		default:
+			// the values of DEFAULT and the conditions of CHECK are skipped as a whole, they may contain NOT NULL
+			if _, ok := p.group(); !ok {
+				p.next()
+			}
+		}
+	}
+	table.Columns = append(table.Columns, column)
+}
+
+func (p *parser) references() *ForeignKey {
+	_, name, _ := p.name()
+	return &ForeignKey{Table: name, References: p.columns()}
+}
+
+// columns reads a parenthesised list of columns, the ordering of the index columns is dropped.
+func (p *parser) columns() []string {
+	group, ok := p.group()
+	if !ok {
+		return nil
+	}
+	var columns []string
+	for _, item := range split(group, ",") {
+		if name, ok := p.sub(item).ident(); ok {
+			columns = append(columns, name)
+		}
+	}
+	return columns
+}
+
+func (p *parser) expression(tokens []token) *Expression {
+	expression := &Expression{Text: p.text(tokens)}
+	for i, t := range tokens {
+		if t.kind != tokenWord && t.kind != tokenQuoted {
+			continue
+		}
+		switch {
+		case i+1 < len(tokens) && tokens[i+1].is(tokenSymbol, "("):
+			expression.Functions = append(expression.Functions, t.value)
+		case i > 0 && tokens[i-1].is(tokenSymbol, "::"):
+			// the type of a cast
+		default:
+			expression.Columns = append(expression.Columns, t.value)
+		}
+	}
+	return expression
+}
+
+// typeName joins the tokens of a type the way PostgreSQL prints it: character varying(255), timestamp(6) with time
+// zone, int[].
+func typeName(tokens []token) string {
+	var name strings.Builder
+	for i, t := range tokens {
+		if i > 0 && (t.kind == tokenWord || t.kind == tokenQuoted) {
+			previous := tokens[i-1]
+			if previous.kind == tokenWord || previous.kind == tokenQuoted || previous.is(tokenSymbol, ")", "]") {
+				name.WriteByte(' ')
+			}
+		}
+		name.WriteString(t.value)
+	}
+	return name.String()
+}
+
+// split splits tokens by the separator outside of parenthesis, the empty parts are dropped.
+func split(tokens []token, separator string) [][]token {
+	var parts [][]token
+	depth, start := 0, 0
+	for i, t := range tokens {
+		switch {
+		case t.is(tokenSymbol, "("):
+			depth++
+		case t.is(tokenSymbol, ")"):
+			depth--
+		case depth == 0 && t.is(tokenSymbol, separator):
+			if i > start {
+				parts = append(parts, tokens[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if len(tokens) > start {
+		parts = append(parts, tokens[start:])
+	}
+	return parts
+}
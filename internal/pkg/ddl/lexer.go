@@ -0,0 +1,165 @@
+package ddl
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+type tokenKind uint8
+
+const (
+	tokenWord tokenKind = iota
+	tokenQuoted
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+// token - word, literal or symbol of the source, words are lower cased like PostgreSQL folds unquoted identifiers.
+type token struct {
+	kind  tokenKind
+	value string
+	start int
+	end   int
+}
+
+func (t token) is(kind tokenKind, values ...string) bool {
+	if t.kind != kind {
+		return false
+	}
+	for _, value := range values {
+		if t.value == value {
+			return true
+		}
+	}
+	return len(values) == 0
+}
+
+// lex splits source into tokens, the comments are dropped.
+func lex(source string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(source); {
+		c := source[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f':
+			i++
+		case strings.HasPrefix(source[i:], "--"):
+			end := strings.IndexByte(source[i:], '\n')
+			if end < 0 {
+				end = len(source) - i
+			}
+			i += end
+		case strings.HasPrefix(source[i:], "/*"):
+			end, err := blockComment(source, i)
+			if err != nil {
+				return nil, err
+			}
+			i = end
+		case c == '\'':
+			end, err := quoted(source, i, '\'')
+			if err != nil {
+				return nil, err
+			}
+			tokens = append(tokens, token{kind: tokenString, value: source[i+1 : end-1], start: i, end: end})
+			i = end
+		case c == '"':
+			end, err := quoted(source, i, '"')
+			if err != nil {
+				return nil, err
+			}
+			value := strings.ReplaceAll(source[i+1:end-1], `""`, `"`)
+			tokens = append(tokens, token{kind: tokenQuoted, value: value, start: i, end: end})
+			i = end
+		case c == '$' && dollarTag(source[i:]) != "":
+			tag := dollarTag(source[i:])
+			end := strings.Index(source[i+len(tag):], tag)
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated %s string", line(source, i), tag)
+			}
+			end += i + 2*len(tag)
+			tokens = append(tokens, token{kind: tokenString, value: source[i+len(tag) : end-len(tag)], start: i, end: end})
+			i = end
+		case c >= '0' && c <= '9':
+			end := i
+			for end < len(source) && (isDigit(source[end]) || source[end] == '.') {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: source[i:end], start: i, end: end})
+			i = end
+		case c == '_' || unicode.IsLetter(rune(c)) || c >= 0x80:
+			end := i
+			for end < len(source) && (isWord(source[end]) || source[end] >= 0x80) {
+				end++
+			}
+			tokens = append(tokens, token{kind: tokenWord, value: strings.ToLower(source[i:end]), start: i, end: end})
+			i = end
+		case strings.HasPrefix(source[i:], "::"):
+			tokens = append(tokens, token{kind: tokenSymbol, value: "::", start: i, end: i + 2})
+			i += 2
+		default:
+			tokens = append(tokens, token{kind: tokenSymbol, value: string(c), start: i, end: i + 1})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// blockComment returns the end of the comment starting at start, block comments nest in PostgreSQL.
+func blockComment(source string, start int) (int, error) {
+	depth := 0
+	for i := start; i+1 < len(source); i++ {
+		switch source[i : i+2] {
+		case "/*":
+			depth++
+			i++
+		case "*/":
+			depth--
+			i++
+			if depth == 0 {
+				return i + 1, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("line %d: unterminated comment", line(source, start))
+}
+
+// quoted returns the end of the literal starting at start, the quote is escaped by doubling it.
+func quoted(source string, start int, quote byte) (int, error) {
+	for i := start + 1; i < len(source); i++ {
+		if source[i] != quote {
+			continue
+		}
+		if i+1 < len(source) && source[i+1] == quote {
+			i++
+			continue
+		}
+		return i + 1, nil
+	}
+	return 0, fmt.Errorf("line %d: unterminated %c", line(source, start), quote)
+}
+
+// dollarTag returns the $tag$ opening a dollar-quoted string at the start of source, or an empty string.
+func dollarTag(source string) string {
+	for i := 1; i < len(source); i++ {
+		switch {
+		case source[i] == '$':
+			return source[:i+1]
+		case !isWord(source[i]) || (i == 1 && isDigit(source[i])):
+			return ""
+		}
+	}
+	return ""
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWord(c byte) bool {
+	return c == '_' || c == '$' || isDigit(c) || (c|0x20 >= 'a' && c|0x20 <= 'z')
+}
+
+func line(source string, offset int) int {
+	return strings.Count(source[:offset], "\n") + 1
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"path"
 	"reflect"
+	"strconv"
 )
 
 type ErrorCode uint
@@ -120,3 +121,75 @@ func NewBadTemplateError(details string) *Error {
 		},
 	}
 }
+
+func NewTemplatesDirectoryError(directory string) *Error {
+	return &Error{
+		Code:    ErrorCodeFailedPrecondition,
+		Message: "Templates directory not found.",
+		Params: map[string]string{
+			"path": directory,
+		},
+	}
+}
+
+func NewPluginError(plugin string, details string) *Error {
+	return &Error{
+		Code:    ErrorCodeFailedPrecondition,
+		Message: "Plugin failed.",
+		Params: map[string]string{
+			"plugin":  plugin,
+			"details": details,
+		},
+	}
+}
+
+func NewUpgradeError(details string) *Error {
+	return &Error{
+		Code:    ErrorCodeFailedPrecondition,
+		Message: "Upgrade failed.",
+		Params: map[string]string{
+			"details": details,
+		},
+	}
+}
+
+func NewPostInitError(steps string) *Error {
+	return &Error{
+		Code:    ErrorCodeFailedPrecondition,
+		Message: "Post init steps failed.",
+		Params: map[string]string{
+			"steps": steps,
+		},
+	}
+}
+
+func NewDoctorError(failed int) *Error {
+	return &Error{
+		Code:    ErrorCodeFailedPrecondition,
+		Message: "Doctor checks failed.",
+		Params: map[string]string{
+			"failed": strconv.Itoa(failed),
+		},
+	}
+}
+
+func NewInvalidConfigError(configPath string, details string) *Error {
+	return &Error{
+		Code:    ErrorCodeInvalidArgument,
+		Message: "Invalid config.",
+		Params: map[string]string{
+			"config":  configPath,
+			"details": details,
+		},
+	}
+}
+
+func NewImportError(details string) *Error {
+	return &Error{
+		Code:    ErrorCodeFailedPrecondition,
+		Message: "Import failed.",
+		Params: map[string]string{
+			"details": details,
+		},
+	}
+}
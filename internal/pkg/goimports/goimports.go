@@ -0,0 +1,88 @@
+package goimports
+
+import (
+	"bytes"
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/imports"
+)
+
+// Process formats the Go files among files like goimports does: the source is gofmt-ed, missing imports are added
+// and unused ones removed. Top-level declarations are separated by a blank line, the generators print them without
+// one and a regenerated file gets it. Files that do not parse are left as they are and reported.
+func Process(files []string) error {
+	var errs []error
+	for _, filePath := range files {
+		if !strings.HasSuffix(filePath, ".go") {
+			continue
+		}
+		abs, err := filepath.Abs(filePath)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		src, err := os.ReadFile(abs)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		formatted, err := imports.Process(abs, separate(src), &imports.Options{Comments: true, TabIndent: true, TabWidth: 8})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if bytes.Equal(src, formatted) {
+			continue
+		}
+		if err := os.WriteFile(abs, formatted, 0666); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// separate inserts a blank line before the top-level declarations that follow the previous one on the next line.
+func separate(src []byte) []byte {
+	fileset := token.NewFileSet()
+	file, err := parser.ParseFile(fileset, "", src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return src
+	}
+	var offsets []int
+	for i := 1; i < len(file.Decls); i++ {
+		start := file.Decls[i].Pos()
+		switch decl := file.Decls[i].(type) {
+		case *ast.FuncDecl:
+			if decl.Doc != nil {
+				start = decl.Doc.Pos()
+			}
+		case *ast.GenDecl:
+			if decl.Doc != nil {
+				start = decl.Doc.Pos()
+			}
+		}
+		if fileset.Position(start).Line == fileset.Position(file.Decls[i-1].End()).Line+1 {
+			offsets = append(offsets, fileset.Position(start).Offset)
+		}
+	}
+	if len(offsets) == 0 {
+		return src
+	}
+	buff := &bytes.Buffer{}
+	previous := 0
+	for _, offset := range offsets {
+		// the declaration starts a line, the blank line goes before its indentation
+		lineStart := bytes.LastIndexByte(src[:offset], '\n') + 1
+		buff.Write(src[previous:lineStart])
+		buff.WriteByte('\n')
+		previous = lineStart
+	}
+	buff.Write(src[previous:])
+	return buff.Bytes()
+}
@@ -0,0 +1,250 @@
+package manifest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/astfile"
+)
+
+// declaration - top-level declaration of a Go file. Start and End are byte offsets of its source, it starts at the
+// comments that end right before it, so the doc belongs to it. Tail is the end of the comments that follow it and are
+// not attached to the next declaration, they are kept next to it but are not part of its hash.
+type declaration struct {
+	Key   string
+	Decl  ast.Decl
+	Start int
+	End   int
+	Tail  int
+}
+
+// declarations returns the top-level declarations of file except imports. Keys are the declared names, methods are
+// prefixed with the receiver type, repeated keys such as init or _ get an #n suffix.
+func declarations(fileset *token.FileSet, file *ast.File) []declaration {
+	var decls []declaration
+	seen := map[string]int{}
+	line := func(pos token.Pos) int {
+		return fileset.Position(pos).Line
+	}
+	previous := file.Name.End()
+	comment := 0
+	for _, decl := range file.Decls {
+		limit := previous
+		previous = decl.End()
+		var key string
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			key = d.Name.Name
+			if d.Recv != nil {
+				key = fmt.Sprintf("%s.%s", astfile.ReceiverName(d), d.Name.Name)
+			}
+		case *ast.GenDecl:
+			if d.Tok == token.IMPORT {
+				continue
+			}
+			var names []string
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					names = append(names, s.Name.Name)
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						names = append(names, name.Name)
+					}
+				}
+			}
+			key = strings.Join(names, ",")
+		default:
+			continue
+		}
+		seen[key]++
+		if seen[key] > 1 {
+			key = fmt.Sprintf("%s#%d", key, seen[key])
+		}
+		for comment < len(file.Comments) && file.Comments[comment].End() <= decl.Pos() {
+			comment++
+		}
+		// a comment is attached only if it ends on the line above the declaration or above the attached comment
+		start := decl.Pos()
+		for i := comment - 1; i >= 0; i-- {
+			group := file.Comments[i]
+			if group.Pos() < limit || line(group.End()) < line(start)-1 || line(group.Pos()) == line(limit) {
+				break
+			}
+			start = group.Pos()
+		}
+		if len(decls) > 0 {
+			decls[len(decls)-1].Tail = tail(file, decls[len(decls)-1].End, int(start-file.FileStart))
+		}
+		decls = append(decls, declaration{
+			Key:   key,
+			Decl:  decl,
+			Start: int(start - file.FileStart),
+			End:   int(decl.End() - file.FileStart),
+		})
+	}
+	if len(decls) > 0 {
+		decls[len(decls)-1].Tail = tail(file, decls[len(decls)-1].End, int(file.FileEnd-file.FileStart))
+	}
+	return decls
+}
+
+// tail returns the end of the last comment between the offsets end and next, or end when there is none.
+func tail(file *ast.File, end, next int) int {
+	result := end
+	for _, group := range file.Comments {
+		offset := int(group.Pos() - file.FileStart)
+		if offset >= end && offset < next {
+			result = max(result, int(group.End()-file.FileStart))
+		}
+	}
+	return result
+}
+
+// text returns the source of the declaration with its doc.
+func (d declaration) text(src []byte) string {
+	return string(src[d.Start:d.End])
+}
+
+// trailing returns the comments that follow the declaration as they are written, with the blank lines before them.
+func (d declaration) trailing(src []byte) string {
+	return string(src[d.End:d.Tail])
+}
+
+// header returns the source before the first declaration without the blank lines separating them.
+func header(src []byte, decls []declaration) []byte {
+	if len(decls) == 0 {
+		return bytes.TrimRight(src, "\n")
+	}
+	return bytes.TrimRight(src[:decls[0].Start], "\n")
+}
+
+// footer returns the source after the comments that follow the last declaration.
+func footer(src []byte, decls []declaration) []byte {
+	if len(decls) == 0 {
+		return []byte("\n")
+	}
+	return src[decls[len(decls)-1].Tail:]
+}
+
+// declarationHashes returns the hashes of the top-level declarations of a Go source by their keys.
+func declarationHashes(src []byte) (map[string]string, error) {
+	fileset := token.NewFileSet()
+	file, err := parser.ParseFile(fileset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	hashes := map[string]string{}
+	for _, decl := range declarations(fileset, file) {
+		hashes[decl.Key] = hashDeclaration(file, decl)
+	}
+	return hashes, nil
+}
+
+// hashDeclaration hashes the syntax tree and the comments of decl. Positions are not hashed, so gofmt, goimports or
+// golines do not turn a generated declaration into a hand-modified one.
+func hashDeclaration(file *ast.File, decl declaration) string {
+	buff := &bytes.Buffer{}
+	for _, group := range file.Comments {
+		offset := int(group.Pos() - file.FileStart)
+		if offset >= decl.Start && offset < decl.End {
+			for _, comment := range group.List {
+				buff.WriteString(strings.TrimSpace(comment.Text))
+				buff.WriteByte('\n')
+			}
+		}
+	}
+	buff.Write(canonical(decl.Decl))
+	return hash(buff.Bytes())
+}
+
+// hashFile hashes the content of a generated file. Go sources are hashed like declarations, with the comments before
+// the package clause, such as a license or build constraints, and the sorted imports, so goimports regrouping them
+// does not count as a modification. Other files are hashed byte by byte.
+func hashFile(name string, src []byte) string {
+	if !strings.HasSuffix(name, ".go") {
+		return hash(src)
+	}
+	fileset := token.NewFileSet()
+	file, err := parser.ParseFile(fileset, "", src, parser.ParseComments)
+	if err != nil {
+		return hash(src)
+	}
+	buff := &bytes.Buffer{}
+	for _, group := range file.Comments {
+		if group.End() > file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			buff.WriteString(strings.TrimSpace(comment.Text))
+			buff.WriteByte('\n')
+		}
+	}
+	buff.WriteString(file.Name.Name)
+	buff.WriteByte('\n')
+	imports := make([]string, 0, len(file.Imports))
+	for _, spec := range file.Imports {
+		path := spec.Path.Value
+		if spec.Name != nil {
+			path = spec.Name.Name + " " + path
+		}
+		imports = append(imports, path)
+	}
+	slices.Sort(imports)
+	for _, path := range imports {
+		buff.WriteString(path)
+		buff.WriteByte('\n')
+	}
+	for _, decl := range declarations(fileset, file) {
+		buff.WriteString(hashDeclaration(file, decl))
+		buff.WriteByte('\n')
+	}
+	return hash(buff.Bytes())
+}
+
+func hash(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+var (
+	posType     = reflect.TypeOf(token.NoPos)
+	commentType = reflect.TypeOf(&ast.CommentGroup{})
+)
+
+// canonical prints node without positions and comments. It clears them in place, so node must not be used after.
+func canonical(node ast.Node) []byte {
+	ast.Inspect(node, func(n ast.Node) bool {
+		value := reflect.ValueOf(n)
+		if !value.IsValid() || value.Kind() != reflect.Pointer || value.IsNil() {
+			return true
+		}
+		value = value.Elem()
+		if value.Kind() != reflect.Struct {
+			return true
+		}
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Field(i)
+			switch {
+			case !field.CanSet():
+			case field.Type() == posType:
+				field.SetInt(0)
+			case field.Type() == commentType:
+				field.Set(reflect.Zero(commentType))
+			}
+		}
+		return true
+	})
+	buff := &bytes.Buffer{}
+	_ = printer.Fprint(buff, token.NewFileSet(), node)
+	return buff.Bytes()
+}
@@ -0,0 +1,185 @@
+package manifest
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+const filename = ".creathor/manifest.json"
+
+// Manifest records the files and declarations written by creathor with their hashes. Everything that still matches
+// its hash on the next run is regenerated, hand-modified code is left alone and reported. Version is the creathor
+// version that generated the project, it is updated on Save.
+type Manifest struct {
+	Version string           `json:"version"`
+	Files   map[string]*File `json:"files"`
+
+	version      string
+	directory    string
+	skipped      map[string][]string
+	conflicts    map[string][]string
+	written      map[string]bool
+	removedFiles map[string][]byte
+	removedDecls map[string]map[string]string
+	trailing     map[string]map[string]string
+	order        map[string][]string
+	before       map[string]snapshot
+}
+
+// File - whole file written from a template or a plugin when Declarations is empty, otherwise Go file built by the
+// AST generators where only the listed declarations are generated.
+type File struct {
+	Hash         string            `json:"hash,omitempty"`
+	Declarations map[string]string `json:"declarations,omitempty"`
+}
+
+// generated holds the absolute paths of the files written from templates and plugins in this run.
+var generated = map[string]bool{}
+
+// Generated marks filePath as a whole generated file, templates and plugins call it for every file they write.
+func Generated(filePath string) {
+	if abs, err := filepath.Abs(filePath); err == nil {
+		generated[abs] = true
+	}
+}
+
+// Load reads the manifest of the project in directory, a project without one gets an empty manifest.
+func Load(directory, version string) (*Manifest, error) {
+	if directory == "" {
+		directory = "."
+	}
+	manifest := &Manifest{
+		Files:        map[string]*File{},
+		version:      version,
+		directory:    directory,
+		skipped:      map[string][]string{},
+		conflicts:    map[string][]string{},
+		written:      map[string]bool{},
+		removedFiles: map[string][]byte{},
+		removedDecls: map[string]map[string]string{},
+		trailing:     map[string]map[string]string{},
+		order:        map[string][]string{},
+	}
+	data, err := os.ReadFile(filepath.Join(directory, filename))
+	if errors.Is(err, os.ErrNotExist) {
+		return manifest, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	if manifest.Files == nil {
+		manifest.Files = map[string]*File{}
+	}
+	return manifest, nil
+}
+
+// Save hashes the tracked files as they are on disk and writes the manifest. Hand-modified files and declarations
+// keep their old hashes, so they are reported until they are reverted or removed from the manifest.
+func (m *Manifest) Save() error {
+	m.Version = m.version
+	for name, file := range m.Files {
+		src, err := os.ReadFile(filepath.Join(m.directory, name))
+		if errors.Is(err, os.ErrNotExist) {
+			delete(m.Files, name)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		skipped, isSkipped := m.skipped[name]
+		conflicts, isConflicted := m.conflicts[name]
+		skipped = slices.Concat(skipped, conflicts)
+		isSkipped = isSkipped || isConflicted
+		if file.Declarations == nil {
+			if !isSkipped {
+				file.Hash = hashFile(name, src)
+			}
+			continue
+		}
+		hashes, err := declarationHashes(src)
+		if err != nil {
+			continue
+		}
+		for key := range file.Declarations {
+			current, ok := hashes[key]
+			if !ok {
+				delete(file.Declarations, key)
+				continue
+			}
+			if !slices.Contains(skipped, key) {
+				file.Declarations[key] = current
+			}
+		}
+		if len(file.Declarations) == 0 {
+			delete(m.Files, name)
+		}
+	}
+	data, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Join(m.directory, filepath.Dir(filename)), 0777); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(m.directory, filename), append(data, '\n'), 0666)
+}
+
+// Written returns the paths of the files written in this run, sorted, without the ones left with conflict markers.
+func (m *Manifest) Written() []string {
+	files := make([]string, 0, len(m.written))
+	for name, written := range m.written {
+		if !written {
+			continue
+		}
+		files = append(files, filepath.Join(m.directory, name))
+	}
+	slices.Sort(files)
+	return files
+}
+
+// Tracked returns the paths of the files generated by creathor, sorted.
+func (m *Manifest) Tracked() []string {
+	files := make([]string, 0, len(m.Files))
+	for name := range m.Files {
+		files = append(files, filepath.Join(m.directory, name))
+	}
+	slices.Sort(files)
+	return files
+}
+
+// Report prints the files and declarations that were not regenerated because they were modified by hand, and the
+// ones left with conflict markers by an upgrade.
+func (m *Manifest) Report() {
+	report("modified by hand, not regenerated:", m.skipped)
+	report("conflicts, resolve the markers:", m.conflicts)
+}
+
+func report(title string, files map[string][]string) {
+	if len(files) == 0 {
+		return
+	}
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	fmt.Println(title)
+	for _, name := range names {
+		keys := files[name]
+		if len(keys) == 0 {
+			fmt.Printf("  %s\n", name)
+			continue
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			fmt.Printf("  %s: %s\n", name, key)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+package manifest
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/astfile"
+)
+
+// merged - result of a three-way merge of a file. Kept are the declarations modified by hand that the new version
+// does not change, conflicts are the ones changed on both sides. An empty key stands for the whole file.
+type merged struct {
+	src       []byte
+	kept      []string
+	conflicts []string
+}
+
+// chunk - declaration of a file with its hash, trailing are the comments that follow it.
+type chunk struct {
+	hash     string
+	text     string
+	trailing string
+}
+
+// mergeFile merges theirs, the output of the new version, into yours, the file of the project, using base, the
+// output of the version that generated the project, as the common ancestor. base is nil when that version did not
+// generate the file. Go files are merged declaration by declaration, other files as a whole.
+func mergeFile(name string, base, yours, theirs []byte, label string) merged {
+	if strings.HasSuffix(name, ".go") {
+		if result, err := mergeGo(base, yours, theirs, label); err == nil {
+			return result
+		}
+	}
+	equal := func(a, b []byte) bool {
+		return hashFile(name, a) == hashFile(name, b)
+	}
+	switch {
+	case equal(yours, theirs):
+		return merged{src: yours}
+	case base != nil && equal(yours, base):
+		return merged{src: theirs}
+	case base != nil && equal(theirs, base):
+		return merged{src: yours, kept: []string{""}}
+	}
+	return merged{
+		src:       []byte(conflict(string(yours), string(theirs), label) + "\n"),
+		conflicts: []string{""},
+	}
+}
+
+// mergeGo keeps the order of the declarations in yours, declarations that only the new version has are added to the
+// end. Imports added by the new version are merged, unused ones are left to goimports.
+func mergeGo(base, yours, theirs []byte, label string) (merged, error) {
+	theirsFileset := token.NewFileSet()
+	theirsFile, err := parser.ParseFile(theirsFileset, "", theirs, parser.ParseComments)
+	if err != nil {
+		return merged{}, err
+	}
+	yoursFileset := token.NewFileSet()
+	yoursFile, err := parser.ParseFile(yoursFileset, "", yours, parser.ParseComments)
+	if err != nil {
+		return merged{}, err
+	}
+	var baseFile *ast.File
+	var baseChunks map[string]chunk
+	if base != nil {
+		baseFileset := token.NewFileSet()
+		if baseFile, err = parser.ParseFile(baseFileset, "", base, parser.ParseComments); err == nil {
+			_, baseChunks = chunks(baseFileset, baseFile, base)
+		}
+	}
+	var imports []string
+	for _, spec := range theirsFile.Imports {
+		if astfile.Imported(yoursFile, spec.Path.Value) ||
+			baseChunks != nil && astfile.Imported(baseFile, spec.Path.Value) {
+			continue
+		}
+		start := theirsFileset.Position(spec.Pos()).Offset
+		end := theirsFileset.Position(spec.End()).Offset
+		imports = append(imports, string(theirs[start:end]))
+	}
+	if len(imports) > 0 {
+		yours = astfile.AddImports(yoursFileset, yoursFile, yours, imports)
+		yoursFileset = token.NewFileSet()
+		if yoursFile, err = parser.ParseFile(yoursFileset, "", yours, parser.ParseComments); err != nil {
+			return merged{}, err
+		}
+	}
+	// hashing clears the positions, the offsets of yours are taken first
+	decls := declarations(yoursFileset, yoursFile)
+	theirsKeys, theirsChunks := chunks(theirsFileset, theirsFile, theirs)
+	yoursKeys, yoursChunks := chunks(yoursFileset, yoursFile, yours)
+	result := merged{}
+	changed := len(imports) > 0
+	buff := &bytes.Buffer{}
+	buff.Write(header(yours, decls))
+	write := func(text string) {
+		buff.WriteString("\n\n")
+		buff.WriteString(text)
+	}
+	take := func(text string) {
+		write(text)
+		changed = true
+	}
+	for _, key := range yoursKeys {
+		y := yoursChunks[key]
+		b, inBase := baseChunks[key]
+		t, inTheirs := theirsChunks[key]
+		switch {
+		case !inTheirs && !inBase:
+			write(y.text)
+		case !inTheirs && y.hash == b.hash:
+			// removed by the new version
+			changed = true
+		case !inTheirs:
+			take(conflict(y.text, "", label))
+			result.conflicts = append(result.conflicts, key)
+		case y.hash == t.hash:
+			write(y.text)
+		case inBase && y.hash == b.hash:
+			take(t.text)
+		case inBase && t.hash == b.hash:
+			write(y.text)
+			result.kept = append(result.kept, key)
+		default:
+			take(conflict(y.text, t.text, label))
+			result.conflicts = append(result.conflicts, key)
+		}
+		// comments written after the declaration stay in place whatever happens to it
+		buff.WriteString(y.trailing)
+	}
+	for _, key := range theirsKeys {
+		if _, ok := yoursChunks[key]; ok {
+			continue
+		}
+		t := theirsChunks[key]
+		b, inBase := baseChunks[key]
+		switch {
+		case !inBase:
+			take(t.text)
+		case t.hash == b.hash:
+			// removed by hand
+			result.kept = append(result.kept, key)
+		default:
+			take(conflict("", t.text, label))
+			result.conflicts = append(result.conflicts, key)
+		}
+	}
+	buff.Write(footer(yours, decls))
+	result.src = yours
+	if changed {
+		result.src = buff.Bytes()
+	}
+	return result, nil
+}
+
+// chunks returns the keys of the declarations of file in order with their hashes, sources and trailing comments.
+func chunks(fileset *token.FileSet, file *ast.File, src []byte) ([]string, map[string]chunk) {
+	decls := declarations(fileset, file)
+	keys := make([]string, 0, len(decls))
+	result := make(map[string]chunk, len(decls))
+	for _, decl := range decls {
+		keys = append(keys, decl.Key)
+		result[decl.Key] = chunk{
+			hash:     hashDeclaration(file, decl),
+			text:     decl.text(src),
+			trailing: decl.trailing(src),
+		}
+	}
+	return keys, result
+}
+
+// conflict returns git-style conflict markers around both sides.
+func conflict(yours, theirs, label string) string {
+	buff := &strings.Builder{}
+	buff.WriteString("<<<<<<< yours\n")
+	if yours != "" {
+		buff.WriteString(strings.TrimRight(yours, "\n"))
+		buff.WriteString("\n")
+	}
+	buff.WriteString("=======\n")
+	if theirs != "" {
+		buff.WriteString(strings.TrimRight(theirs, "\n"))
+		buff.WriteString("\n")
+	}
+	buff.WriteString(">>>>>>> ")
+	buff.WriteString(label)
+	return buff.String()
+}
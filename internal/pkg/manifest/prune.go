@@ -0,0 +1,384 @@
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/astfile"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/mockgen"
+)
+
+// Stale - generated file or declaration that the config does not generate anymore, or a declaration that is
+// generated differently now, like the wiring of a removed entity. Key is empty for whole files. Migrations are never
+// removed: a stale one is kept and From is set on the files of the migration created to drop what it created, with the
+// migration the content comes from.
+type Stale struct {
+	Path      string
+	Key       string
+	Rewrite   bool
+	Modified  bool
+	Migration bool
+	From      string
+}
+
+func (s Stale) String() string {
+	action := "remove"
+	if s.Rewrite {
+		action = "rewrite"
+	}
+	name := s.Path
+	if s.Key != "" {
+		name += ": " + s.Key
+	}
+	switch {
+	case s.Modified:
+		action = "keep"
+		name += " (modified by hand)"
+	case s.Migration:
+		action = "keep"
+		name += " (migration)"
+	case s.From != "":
+		action = "create"
+		name += " from " + s.From
+	}
+	return fmt.Sprintf("%-8s %s", action, name)
+}
+
+// Stale compares the project with next, the manifest of the project generated from the current config in its own
+// directory. Modified files and declarations are listed, but Prune keeps them.
+func (m *Manifest) Stale(next *Manifest) ([]Stale, error) {
+	if err := m.detect(); err != nil {
+		return nil, err
+	}
+	var stale []Stale
+	var migrations []string
+	for _, name := range slices.Sorted(maps.Keys(m.Files)) {
+		file := m.Files[name]
+		current, generated := next.Files[name]
+		skipped, modified := m.skipped[name]
+		if migration, ok := migrationName(name); ok {
+			// migrations are numbered by the project, the one of the entity is looked up by its name
+			if !next.generates(path.Dir(name), migration) {
+				migrations = append(migrations, name)
+			}
+			continue
+		}
+		if file.Declarations == nil || generated && current.Declarations == nil {
+			switch {
+			case !generated:
+				stale = append(stale, Stale{Path: name, Modified: modified})
+			case !modified && current.Hash != file.Hash && name != "go.mod":
+				// go mod tidy owns the requirements, it runs after pruning
+				stale = append(stale, Stale{Path: name, Rewrite: true})
+			}
+			continue
+		}
+		if !generated && !modified {
+			// listed once, hand-written declarations of the file are kept
+			stale = append(stale, Stale{Path: name})
+			continue
+		}
+		for _, key := range slices.Sorted(maps.Keys(file.Declarations)) {
+			// a file that does not parse anymore is skipped as a whole
+			keyModified := slices.Contains(skipped, key) || modified && len(skipped) == 0
+			hash, ok := next.Files[name].declaration(key)
+			switch {
+			case !ok:
+				stale = append(stale, Stale{Path: name, Key: key, Modified: keyModified})
+			case !keyModified && hash != file.Declarations[key]:
+				stale = append(stale, Stale{Path: name, Key: key, Rewrite: true})
+			}
+		}
+	}
+	drops, err := m.drops(migrations)
+	if err != nil {
+		return nil, err
+	}
+	return append(stale, drops...), nil
+}
+
+var migrationPattern = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// migrationName returns the name of a SQL migration without the number and the direction, articles for
+// internal/pkg/postgres/migrations/000003_articles.up.sql.
+func migrationName(name string) (string, bool) {
+	if ok, _ := path.Match("internal/pkg/*/migrations/*", name); !ok {
+		return "", false
+	}
+	match := migrationPattern.FindStringSubmatch(path.Base(name))
+	if match == nil {
+		return "", false
+	}
+	return match[2], true
+}
+
+// generates reports whether the manifest has a migration named migration in directory, whatever its number.
+func (m *Manifest) generates(directory, migration string) bool {
+	for name := range m.Files {
+		if current, ok := migrationName(name); ok && current == migration && path.Dir(name) == directory {
+			return true
+		}
+	}
+	return false
+}
+
+// drops keeps the stale migrations and adds a NNNNNN_drop_<name> migration after the last one for every stale
+// migration with both files, its up is the down of the stale one and its down is the up.
+func (m *Manifest) drops(migrations []string) ([]Stale, error) {
+	var stale []Stale
+	for _, name := range migrations {
+		stale = append(stale, Stale{Path: name, Migration: true})
+	}
+	last := map[string]int{}
+	for _, name := range migrations {
+		migration, _ := migrationName(name)
+		directory := path.Dir(name)
+		up := path.Join(directory, strings.TrimSuffix(path.Base(name), ".down.sql")+".up.sql")
+		if !strings.HasSuffix(name, ".down.sql") || !slices.Contains(migrations, up) {
+			continue
+		}
+		if _, ok := last[directory]; !ok {
+			number, err := m.lastMigration(directory)
+			if err != nil {
+				return nil, err
+			}
+			last[directory] = number
+		}
+		last[directory]++
+		drop := path.Join(directory, fmt.Sprintf("%06d_drop_%s", last[directory], migration))
+		stale = append(stale,
+			Stale{Path: drop + ".up.sql", From: name},
+			Stale{Path: drop + ".down.sql", From: up},
+		)
+	}
+	return stale, nil
+}
+
+// lastMigration returns the highest number of the migrations in directory of the project.
+func (m *Manifest) lastMigration(directory string) (int, error) {
+	entries, err := os.ReadDir(filepath.Join(m.directory, directory))
+	if err != nil {
+		return 0, err
+	}
+	var last int
+	for _, entry := range entries {
+		if match := migrationPattern.FindStringSubmatch(entry.Name()); match != nil {
+			number, _ := strconv.Atoi(match[1])
+			last = max(last, number)
+		}
+	}
+	return last, nil
+}
+
+// Prune removes the stale files and declarations and rewrites the stale declarations with the ones generated in
+// next. Imports used only by the removed code are removed as well. Stale migrations stay and are not tracked anymore,
+// the migrations dropping them are written instead.
+func (m *Manifest) Prune(next *Manifest, stale []Stale) error {
+	files := map[string][]Stale{}
+	for _, item := range stale {
+		switch {
+		case item.Migration:
+			// it may have been applied, it stays but is not tracked anymore so that it is dropped only once
+			delete(m.Files, item.Path)
+		case item.From != "":
+			src, err := os.ReadFile(filepath.Join(m.directory, item.From))
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(filepath.Join(m.directory, item.Path), src, 0666); err != nil {
+				return err
+			}
+			m.written[item.Path] = true
+		case !item.Modified:
+			files[item.Path] = append(files[item.Path], item)
+		}
+	}
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		items := files[name]
+		filePath := filepath.Join(m.directory, name)
+		if items[0].Key == "" && m.Files[name].Declarations != nil {
+			items = nil
+			for key := range m.Files[name].Declarations {
+				items = append(items, Stale{Path: name, Key: key})
+			}
+		}
+		if len(items) > 0 && items[0].Key == "" {
+			if !items[0].Rewrite {
+				if err := m.remove(name); err != nil {
+					return err
+				}
+				continue
+			}
+			src, err := os.ReadFile(filepath.Join(next.directory, name))
+			if err != nil {
+				return err
+			}
+			if err := os.WriteFile(filePath, src, 0666); err != nil {
+				return err
+			}
+			m.written[name] = true
+			m.Files[name] = &File{Hash: next.Files[name].Hash}
+			continue
+		}
+		if err := m.pruneDeclarations(next, name, items); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Manifest) pruneDeclarations(next *Manifest, name string, items []Stale) error {
+	filePath := filepath.Join(m.directory, name)
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	fileset := token.NewFileSet()
+	file, err := parser.ParseFile(fileset, name, src, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	var rewrites map[string]chunk
+	if slices.ContainsFunc(items, func(item Stale) bool { return item.Rewrite }) {
+		nextSrc, err := os.ReadFile(filepath.Join(next.directory, name))
+		if err != nil {
+			return err
+		}
+		nextFileset := token.NewFileSet()
+		nextFile, err := parser.ParseFile(nextFileset, name, nextSrc, parser.ParseComments)
+		if err != nil {
+			return err
+		}
+		_, rewrites = chunks(nextFileset, nextFile, nextSrc)
+	}
+	actions := map[string]Stale{}
+	for _, item := range items {
+		actions[item.Key] = item
+	}
+	used := astfile.UsedNames(file)
+	decls := declarations(fileset, file)
+	buff := &bytes.Buffer{}
+	buff.Write(header(src, decls))
+	var left int
+	for _, decl := range decls {
+		item, ok := actions[decl.Key]
+		switch {
+		case !ok:
+			buff.WriteString("\n\n")
+			buff.WriteString(decl.text(src))
+			left++
+		case item.Rewrite:
+			buff.WriteString("\n\n")
+			buff.WriteString(rewrites[decl.Key].text)
+			m.Files[name].Declarations[decl.Key] = next.Files[name].Declarations[decl.Key]
+			left++
+		default:
+			delete(m.Files[name].Declarations, decl.Key)
+		}
+		buff.WriteString(decl.trailing(src))
+	}
+	if left == 0 {
+		return m.remove(name)
+	}
+	buff.Write(footer(src, decls))
+	pruned := buff.Bytes()
+	prunedFileset := token.NewFileSet()
+	prunedFile, err := parser.ParseFile(prunedFileset, name, pruned, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	stillUsed := astfile.UsedNames(prunedFile)
+	var unused []string
+	for _, spec := range prunedFile.Imports {
+		importName := astfile.ImportName(spec)
+		if used[importName] && !stillUsed[importName] {
+			unused = append(unused, spec.Path.Value)
+		}
+	}
+	pruned = astfile.RemoveImports(prunedFileset, prunedFile, pruned, unused)
+	if len(m.Files[name].Declarations) == 0 {
+		delete(m.Files, name)
+	}
+	m.written[name] = true
+	return os.WriteFile(filePath, pruned, 0777)
+}
+
+// remove deletes a generated file with its mocks and the directories it leaves empty.
+func (m *Manifest) remove(name string) error {
+	filePath := filepath.Join(m.directory, name)
+	var mocks []string
+	if strings.HasSuffix(name, ".go") {
+		var err error
+		if mocks, err = mockgen.Mocks(filePath); err != nil {
+			return err
+		}
+	}
+	for _, filePath := range append(mocks, filePath) {
+		if err := os.Remove(filePath); err != nil {
+			return err
+		}
+	}
+	delete(m.Files, name)
+	for directory := filepath.Dir(name); directory != "."; directory = filepath.Dir(directory) {
+		if os.Remove(filepath.Join(m.directory, directory)) != nil {
+			break
+		}
+	}
+	return nil
+}
+
+// declaration returns the hash of a generated declaration of f, f may be nil.
+func (f *File) declaration(key string) (string, bool) {
+	if f == nil {
+		return "", false
+	}
+	hash, ok := f.Declarations[key]
+	return hash, ok
+}
+
+// detect marks the tracked files and declarations that were modified by hand, the ones removed by hand are dropped
+// from the manifest.
+func (m *Manifest) detect() error {
+	for name, file := range m.Files {
+		src, err := os.ReadFile(filepath.Join(m.directory, name))
+		if errors.Is(err, os.ErrNotExist) {
+			delete(m.Files, name)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if file.Declarations == nil {
+			if hashFile(name, src) != file.Hash {
+				m.skipped[name] = []string{}
+			}
+			continue
+		}
+		hashes, err := declarationHashes(src)
+		if err != nil {
+			m.skipped[name] = []string{}
+			continue
+		}
+		for key, tracked := range file.Declarations {
+			current, ok := hashes[key]
+			switch {
+			case !ok:
+				delete(file.Declarations, key)
+			case current != tracked:
+				m.skipped[name] = append(m.skipped[name], key)
+			}
+		}
+	}
+	return nil
+}
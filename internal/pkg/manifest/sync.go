@@ -0,0 +1,250 @@
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+type snapshot struct {
+	hash string
+	keys map[string]bool
+}
+
+// Prepare removes the generated files and declarations that still match their hashes, so the generators write them
+// again. Modified ones are kept and reported.
+func (m *Manifest) Prepare() error {
+	for name, file := range m.Files {
+		filePath := filepath.Join(m.directory, name)
+		src, err := os.ReadFile(filePath)
+		if errors.Is(err, os.ErrNotExist) {
+			delete(m.Files, name)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if file.Declarations == nil {
+			if hashFile(name, src) != file.Hash {
+				m.skipped[name] = []string{}
+				continue
+			}
+			m.removedFiles[name] = src
+			if err := os.Remove(filePath); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := m.prune(name, src, file.Declarations); err != nil {
+			return err
+		}
+	}
+	before, err := m.walk()
+	if err != nil {
+		return err
+	}
+	m.before = before
+	return nil
+}
+
+// prune removes the unmodified declarations of a Go file and remembers the order of all of them.
+func (m *Manifest) prune(name string, src []byte, hashes map[string]string) error {
+	fileset := token.NewFileSet()
+	file, err := parser.ParseFile(fileset, name, src, parser.ParseComments)
+	if err != nil {
+		// the file is broken by hand, nothing can be regenerated safely
+		m.skipped[name] = []string{}
+		return nil
+	}
+	removed := map[string]string{}
+	trailing := map[string]string{}
+	pruned := slices.Clone(src)
+	decls := declarations(fileset, file)
+	order := make([]string, 0, len(decls))
+	for _, decl := range decls {
+		order = append(order, decl.Key)
+	}
+	slices.Reverse(decls)
+	for _, decl := range decls {
+		tracked, ok := hashes[decl.Key]
+		if !ok {
+			continue
+		}
+		if hashDeclaration(file, decl) != tracked {
+			m.skipped[name] = append(m.skipped[name], decl.Key)
+			continue
+		}
+		// the comments after it are cut too and put back after the regenerated declaration
+		removed[decl.Key] = decl.text(src)
+		trailing[decl.Key] = decl.trailing(src)
+		pruned = slices.Concat(pruned[:decl.Start], pruned[decl.Tail:])
+	}
+	if len(removed) == 0 {
+		return nil
+	}
+	m.removedDecls[name] = removed
+	m.trailing[name] = trailing
+	m.order[name] = order
+	return os.WriteFile(filepath.Join(m.directory, name), pruned, 0777)
+}
+
+// Restore moves the regenerated declarations back to their places and puts back the removed files and declarations
+// that were not generated again.
+func (m *Manifest) Restore() error {
+	var errs []error
+	for name, src := range m.removedFiles {
+		filePath := filepath.Join(m.directory, name)
+		if _, err := os.Stat(filePath); errors.Is(err, os.ErrNotExist) {
+			errs = append(errs, os.WriteFile(filePath, src, 0666))
+		}
+	}
+	for name, removed := range m.removedDecls {
+		errs = append(errs, m.reorder(name, removed))
+	}
+	m.removedFiles = map[string][]byte{}
+	m.removedDecls = map[string]map[string]string{}
+	m.trailing = map[string]map[string]string{}
+	return errors.Join(errs...)
+}
+
+// reorder sorts the declarations of a Go file in the order they had before pruning, the generators append the
+// regenerated ones to the end. New declarations stay at the end.
+func (m *Manifest) reorder(name string, removed map[string]string) error {
+	filePath := filepath.Join(m.directory, name)
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return err
+	}
+	fileset := token.NewFileSet()
+	file, err := parser.ParseFile(fileset, name, src, parser.ParseComments)
+	if err != nil {
+		return err
+	}
+	decls := declarations(fileset, file)
+	current := map[string]declaration{}
+	for _, decl := range decls {
+		current[decl.Key] = decl
+	}
+	buff := &bytes.Buffer{}
+	buff.Write(header(src, decls))
+	write := func(text string) {
+		// the gaps left by the pruned declarations are collapsed to a single blank line
+		buff.WriteString("\n\n")
+		buff.WriteString(text)
+	}
+	for _, key := range m.order[name] {
+		if decl, ok := current[key]; ok {
+			write(decl.text(src))
+			buff.WriteString(decl.trailing(src))
+			delete(current, key)
+		} else if text, ok := removed[key]; ok {
+			write(text)
+		}
+		buff.WriteString(m.trailing[name][key])
+	}
+	for _, decl := range decls {
+		if _, ok := current[decl.Key]; ok {
+			write(decl.text(src))
+			buff.WriteString(decl.trailing(src))
+		}
+	}
+	buff.Write(footer(src, decls))
+	return os.WriteFile(filePath, buff.Bytes(), 0777)
+}
+
+// Collect restores the order of the regenerated declarations and adds the files written in this run to the manifest. Whole files come from templates
+// and plugins, in other Go files only the declarations that did not exist before are tracked.
+func (m *Manifest) Collect() error {
+	if err := m.Restore(); err != nil {
+		return err
+	}
+	after, err := m.walk()
+	if err != nil {
+		return err
+	}
+	for name, current := range after {
+		previous, existed := m.before[name]
+		if existed && previous.hash == current.hash {
+			continue
+		}
+		m.written[name] = true
+		abs, err := filepath.Abs(filepath.Join(m.directory, name))
+		if err != nil {
+			return err
+		}
+		if generated[abs] {
+			if _, tracked := m.Files[name]; !tracked {
+				m.Files[name] = &File{}
+			}
+			continue
+		}
+		if current.keys == nil {
+			continue
+		}
+		file, ok := m.Files[name]
+		if !ok || file.Declarations == nil {
+			if ok {
+				// a generated whole file that is patched by the AST generators stays a whole file
+				continue
+			}
+			file = &File{Declarations: map[string]string{}}
+		}
+		for key := range current.keys {
+			if !previous.keys[key] {
+				file.Declarations[key] = ""
+			}
+		}
+		if len(file.Declarations) > 0 {
+			m.Files[name] = file
+		}
+	}
+	return nil
+}
+
+// walk hashes every file of the project, hidden directories, vendor and node_modules are skipped.
+func (m *Manifest) walk() (map[string]snapshot, error) {
+	files := map[string]snapshot{}
+	err := filepath.WalkDir(m.directory, func(filePath string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if filePath != m.directory &&
+				(strings.HasPrefix(entry.Name(), ".") || entry.Name() == "vendor" || entry.Name() == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !entry.Type().IsRegular() {
+			return nil
+		}
+		name, err := filepath.Rel(m.directory, filePath)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		src, err := os.ReadFile(filePath)
+		if err != nil {
+			return err
+		}
+		current := snapshot{hash: hash(src)}
+		if strings.HasSuffix(name, ".go") {
+			fileset := token.NewFileSet()
+			if file, err := parser.ParseFile(fileset, name, src, parser.SkipObjectResolution); err == nil {
+				current.keys = map[string]bool{}
+				for _, decl := range declarations(fileset, file) {
+					current.keys[decl.Key] = true
+				}
+			}
+		}
+		files[name] = current
+		return nil
+	})
+	return files, err
+}
@@ -0,0 +1,88 @@
+package manifest
+
+import (
+	"bytes"
+	"errors"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+)
+
+// Upgrade three-way merges the files tracked by next, the manifest of the project generated by the current version
+// in its own directory, into the project. base is the directory with the output of the version recorded in the
+// manifest. Files removed by hand stay removed, conflicts get git-style markers and are reported.
+func (m *Manifest) Upgrade(base string, next *Manifest) error {
+	if err := m.detect(); err != nil {
+		return err
+	}
+	label := "creathor"
+	if m.version != "" {
+		label += " " + m.version
+	}
+	names := slices.Sorted(maps.Keys(next.Files))
+	for _, name := range names {
+		theirs, err := os.ReadFile(filepath.Join(next.directory, name))
+		if err != nil {
+			return err
+		}
+		ancestor, err := readOptional(filepath.Join(base, name))
+		if err != nil {
+			return err
+		}
+		filePath := filepath.Join(m.directory, name)
+		yours, err := readOptional(filePath)
+		if err != nil {
+			return err
+		}
+		tracked := &File{Hash: next.Files[name].Hash, Declarations: maps.Clone(next.Files[name].Declarations)}
+		if yours == nil {
+			if ancestor != nil {
+				// removed by hand
+				continue
+			}
+			if err := os.MkdirAll(filepath.Dir(filePath), 0777); err != nil {
+				return err
+			}
+			if err := os.WriteFile(filePath, theirs, 0666); err != nil {
+				return err
+			}
+			m.written[name] = true
+			m.Files[name] = tracked
+			continue
+		}
+		result := mergeFile(name, ancestor, yours, theirs, label)
+		if !bytes.Equal(result.src, yours) {
+			if err := os.WriteFile(filePath, result.src, 0666); err != nil {
+				return err
+			}
+			m.written[name] = len(result.conflicts) == 0
+		}
+		// the hashes of the new version are kept for the declarations that were not taken from it, so they are
+		// reported as modified by hand until they match it
+		m.Files[name] = tracked
+		delete(m.skipped, name)
+		mark(m.skipped, name, result.kept)
+		mark(m.conflicts, name, result.conflicts)
+	}
+	return nil
+}
+
+func mark(files map[string][]string, name string, keys []string) {
+	for _, key := range keys {
+		if _, ok := files[name]; !ok {
+			files[name] = []string{}
+		}
+		if key != "" {
+			files[name] = append(files[name], key)
+		}
+	}
+}
+
+func readOptional(filePath string) ([]byte, error) {
+	src, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return src, err
+}
@@ -0,0 +1,517 @@
+package mockgen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/astfile"
+)
+
+// scope - file an interface is declared in. Types of its package are qualified with the import path of qualifier in
+// the mock, it is empty for the package of the mock.
+type scope struct {
+	file      *ast.File
+	pkg       *pkg
+	qualifier string
+}
+
+type iface struct {
+	name    string
+	methods []method
+}
+
+type method struct {
+	name     string
+	params   []param
+	results  []ast.Expr
+	variadic bool
+}
+
+// param - parameter of a method, the types of other packages are selectors of the import path.
+type param struct {
+	name string
+	typ  ast.Expr
+}
+
+// mock collects the interfaces of a source file and the imports their methods need, by import path with the name
+// the source file refers to them by when it does not rename them.
+type mock struct {
+	packageName string
+	imports     map[string]string
+	names       map[string]string
+	resolver    *resolver
+}
+
+func newMock(packageName string, resolver *resolver) *mock {
+	return &mock{packageName: packageName, imports: map[string]string{}, resolver: resolver}
+}
+
+// interfaces returns the interfaces declared in the source file in order.
+func (m *mock) interfaces(source *scope) ([]iface, error) {
+	var interfaces []iface
+	for _, decl := range source.file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			interfaceType, ok := typeSpec.Type.(*ast.InterfaceType)
+			if !ok {
+				continue
+			}
+			if typeSpec.TypeParams != nil {
+				return nil, fmt.Errorf("generic interface %s is not supported", typeSpec.Name.Name)
+			}
+			methods, err := m.methods(interfaceType, source)
+			if err != nil {
+				return nil, err
+			}
+			interfaces = append(interfaces, iface{name: typeSpec.Name.Name, methods: methods})
+		}
+	}
+	return interfaces, nil
+}
+
+// methods returns the methods of interfaceType, embedded interfaces included.
+func (m *mock) methods(interfaceType *ast.InterfaceType, current *scope) ([]method, error) {
+	var methods []method
+	add := func(embedded []method) {
+		for _, candidate := range embedded {
+			if !slices.ContainsFunc(methods, func(existing method) bool { return existing.name == candidate.name }) {
+				methods = append(methods, candidate)
+			}
+		}
+	}
+	for _, field := range interfaceType.Methods.List {
+		switch t := field.Type.(type) {
+		case *ast.FuncType:
+			for _, name := range field.Names {
+				add([]method{m.method(name.Name, t, current)})
+			}
+		case *ast.Ident:
+			if t.Name == "error" {
+				add([]method{{name: "Error", results: []ast.Expr{ast.NewIdent("string")}}})
+				continue
+			}
+			embedded, file, ok := current.pkg.find(t.Name)
+			if !ok {
+				return nil, fmt.Errorf("embedded interface %s is not found", t.Name)
+			}
+			embeddedMethods, err := m.methods(embedded, &scope{file: file, pkg: current.pkg, qualifier: current.qualifier})
+			if err != nil {
+				return nil, err
+			}
+			add(embeddedMethods)
+		case *ast.SelectorExpr:
+			importPath, ok := m.importOf(current.file, t.X.(*ast.Ident).Name)
+			if !ok {
+				return nil, fmt.Errorf("import of %s is not found", t.X.(*ast.Ident).Name)
+			}
+			loaded, err := m.resolver.load(importPath)
+			if err != nil {
+				return nil, err
+			}
+			embedded, file, ok := loaded.find(t.Sel.Name)
+			if !ok {
+				return nil, fmt.Errorf("embedded interface %s.%s is not found", importPath, t.Sel.Name)
+			}
+			embeddedMethods, err := m.methods(embedded, &scope{file: file, pkg: loaded, qualifier: importPath})
+			if err != nil {
+				return nil, err
+			}
+			add(embeddedMethods)
+		default:
+			return nil, fmt.Errorf("embedded %s is not supported", types.ExprString(field.Type))
+		}
+	}
+	return methods, nil
+}
+
+func (m *mock) method(name string, funcType *ast.FuncType, current *scope) method {
+	result := method{name: name}
+	for _, field := range funcType.Params.List {
+		typ := m.qualify(field.Type, current)
+		if _, ok := field.Type.(*ast.Ellipsis); ok {
+			result.variadic = true
+		}
+		if len(field.Names) == 0 {
+			result.params = append(result.params, param{typ: typ})
+		}
+		for _, fieldName := range field.Names {
+			result.params = append(result.params, param{name: fieldName.Name, typ: typ})
+		}
+	}
+	for i := range result.params {
+		if result.params[i].name == "" || result.params[i].name == "_" {
+			result.params[i].name = fmt.Sprintf("arg%d", i)
+		}
+	}
+	if funcType.Results != nil {
+		for _, field := range funcType.Results.List {
+			typ := m.qualify(field.Type, current)
+			for range max(1, len(field.Names)) {
+				result.results = append(result.results, typ)
+			}
+		}
+	}
+	return result
+}
+
+// qualify returns expr with the types of the source package prefixed with the import path of the qualifier and the
+// selectors of imported packages with their import paths, the packages are added to the imports of the mock.
+func (m *mock) qualify(expr ast.Expr, current *scope) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		if current.qualifier != "" && ast.IsExported(e.Name) && current.pkg.declares(e.Name) {
+			m.imports[current.qualifier] = current.pkg.name
+			return &ast.SelectorExpr{X: ast.NewIdent(current.qualifier), Sel: e}
+		}
+		return e
+	case *ast.SelectorExpr:
+		if x, ok := e.X.(*ast.Ident); ok {
+			if importPath, ok := m.importOf(current.file, x.Name); ok {
+				switch _, ok := m.imports[importPath]; {
+				case alias(current.file, importPath) == "":
+					m.imports[importPath] = x.Name
+				case !ok:
+					m.imports[importPath] = ""
+				}
+				return &ast.SelectorExpr{X: ast.NewIdent(importPath), Sel: e.Sel}
+			}
+		}
+		return e
+	case *ast.StarExpr:
+		return &ast.StarExpr{X: m.qualify(e.X, current)}
+	case *ast.ParenExpr:
+		return m.qualify(e.X, current)
+	case *ast.Ellipsis:
+		return &ast.Ellipsis{Elt: m.qualify(e.Elt, current)}
+	case *ast.ArrayType:
+		return &ast.ArrayType{Len: e.Len, Elt: m.qualify(e.Elt, current)}
+	case *ast.MapType:
+		return &ast.MapType{Key: m.qualify(e.Key, current), Value: m.qualify(e.Value, current)}
+	case *ast.ChanType:
+		return &ast.ChanType{Dir: e.Dir, Value: m.qualify(e.Value, current)}
+	case *ast.IndexExpr:
+		return &ast.IndexExpr{X: m.qualify(e.X, current), Index: m.qualify(e.Index, current)}
+	case *ast.IndexListExpr:
+		indices := make([]ast.Expr, 0, len(e.Indices))
+		for _, index := range e.Indices {
+			indices = append(indices, m.qualify(index, current))
+		}
+		return &ast.IndexListExpr{X: m.qualify(e.X, current), Indices: indices}
+	case *ast.FuncType:
+		return &ast.FuncType{Params: m.qualifyFields(e.Params, current), Results: m.qualifyFields(e.Results, current)}
+	}
+	return expr
+}
+
+func (m *mock) qualifyFields(fields *ast.FieldList, current *scope) *ast.FieldList {
+	if fields == nil {
+		return nil
+	}
+	result := &ast.FieldList{}
+	for _, field := range fields.List {
+		result.List = append(result.List, &ast.Field{Names: field.Names, Type: m.qualify(field.Type, current)})
+	}
+	return result
+}
+
+// typeString prints a type the way mockgen does: packages by the names of the mock, func types without the names of
+// their parameters and empty interfaces as any.
+func (m *mock) typeString(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		return e.Name
+	case *ast.SelectorExpr:
+		if name, ok := m.names[e.X.(*ast.Ident).Name]; ok {
+			return name + "." + e.Sel.Name
+		}
+		return types.ExprString(e)
+	case *ast.StarExpr:
+		return "*" + m.typeString(e.X)
+	case *ast.Ellipsis:
+		return "..." + m.typeString(e.Elt)
+	case *ast.ArrayType:
+		if e.Len == nil {
+			return "[]" + m.typeString(e.Elt)
+		}
+		return "[" + types.ExprString(e.Len) + "]" + m.typeString(e.Elt)
+	case *ast.MapType:
+		return "map[" + m.typeString(e.Key) + "]" + m.typeString(e.Value)
+	case *ast.ChanType:
+		switch e.Dir {
+		case ast.RECV:
+			return "<-chan " + m.typeString(e.Value)
+		case ast.SEND:
+			return "chan<- " + m.typeString(e.Value)
+		}
+		return "chan " + m.typeString(e.Value)
+	case *ast.IndexExpr:
+		return m.typeString(e.X) + "[" + m.typeString(e.Index) + "]"
+	case *ast.IndexListExpr:
+		indices := make([]string, 0, len(e.Indices))
+		for _, index := range e.Indices {
+			indices = append(indices, m.typeString(index))
+		}
+		return m.typeString(e.X) + "[" + strings.Join(indices, ", ") + "]"
+	case *ast.InterfaceType:
+		return "any"
+	case *ast.StructType:
+		return "struct{}"
+	case *ast.FuncType:
+		var params, results []string
+		for _, field := range e.Params.List {
+			for range max(1, len(field.Names)) {
+				params = append(params, m.typeString(field.Type))
+			}
+		}
+		if e.Results != nil {
+			for _, field := range e.Results.List {
+				for range max(1, len(field.Names)) {
+					results = append(results, m.typeString(field.Type))
+				}
+			}
+		}
+		switch len(results) {
+		case 0:
+			return "func(" + strings.Join(params, ", ") + ")"
+		case 1:
+			return "func(" + strings.Join(params, ", ") + ") " + results[0]
+		}
+		return "func(" + strings.Join(params, ", ") + ") (" + strings.Join(results, ", ") + ")"
+	}
+	return types.ExprString(expr)
+}
+
+// name allocates the names of the imports like mockgen: the package name, or the last element of the path when the
+// package can not be loaded, with a number when it is taken.
+func (m *mock) name(importPath string, taken map[string]bool) string {
+	base := m.imports[importPath]
+	if loaded, err := m.resolver.load(importPath); err == nil && loaded.name != "" {
+		base = loaded.name
+	}
+	if base == "" {
+		base = sanitize(path.Base(importPath))
+	}
+	name := base
+	for i := 0; taken[name] || token.Lookup(name).IsKeyword() || name == "any"; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	taken[name] = true
+	return name
+}
+
+// render writes the mocks the way mockgen does in source mode, command is the directive that requested them.
+func (m *mock) render(source, command string, interfaces []iface) []byte {
+	imports := maps.Clone(m.imports)
+	imports["go.uber.org/mock/gomock"] = "gomock"
+	if slices.ContainsFunc(interfaces, func(i iface) bool { return len(i.methods) > 0 }) {
+		imports["reflect"] = "reflect"
+	}
+	m.imports = imports
+	m.names = map[string]string{}
+	taken := map[string]bool{}
+	for _, importPath := range slices.Sorted(maps.Keys(imports)) {
+		m.names[importPath] = m.name(importPath, taken)
+	}
+	buff := &strings.Builder{}
+	fmt.Fprintf(buff, "%s\n// Source: %s\n//\n// Generated by this command:\n//\n//\t%s\n//\n\n", header, source, command)
+	fmt.Fprintf(buff, "// Package %[1]s is a generated GoMock package.\npackage %[1]s\n\nimport (\n", m.packageName)
+	for _, importPath := range slices.Sorted(maps.Keys(m.names)) {
+		fmt.Fprintf(buff, "\t%s %q\n", m.names[importPath], importPath)
+	}
+	buff.WriteString(")\n")
+	for _, i := range interfaces {
+		name := "Mock" + i.name
+		recorder := name + "MockRecorder"
+		fmt.Fprintf(buff, `
+// %[1]s is a mock of %[3]s interface.
+type %[1]s struct {
+	ctrl     *gomock.Controller
+	recorder *%[2]s
+	isgomock struct{}
+}
+
+// %[2]s is the mock recorder for %[1]s.
+type %[2]s struct {
+	mock *%[1]s
+}
+
+// New%[1]s creates a new mock instance.
+func New%[1]s(ctrl *gomock.Controller) *%[1]s {
+	mock := &%[1]s{ctrl: ctrl}
+	mock.recorder = &%[2]s{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *%[1]s) EXPECT() *%[2]s {
+	return m.recorder
+}
+`, name, recorder, i.name)
+		methods := slices.SortedFunc(slices.Values(i.methods), func(a, b method) int {
+			return strings.Compare(a.name, b.name)
+		})
+		for _, method := range methods {
+			m.renderMethod(buff, name, recorder, method)
+		}
+	}
+	return []byte(buff.String())
+}
+
+func (m *mock) renderMethod(buff *strings.Builder, name, recorder string, method method) {
+	var names, argTypes, signature []string
+	for _, p := range method.params {
+		names = append(names, p.name)
+		argTypes = append(argTypes, m.typeString(p.typ))
+	}
+	for i, p := range names {
+		// the type is written once for consecutive params of the same type
+		if i+1 < len(argTypes) && argTypes[i] == argTypes[i+1] {
+			signature = append(signature, p)
+			continue
+		}
+		signature = append(signature, p+" "+argTypes[i])
+	}
+	var results []string
+	for _, result := range method.results {
+		results = append(results, m.typeString(result))
+	}
+	returns := strings.Join(results, ", ")
+	if len(results) > 1 {
+		returns = "(" + returns + ")"
+	}
+	if returns != "" {
+		returns = " " + returns
+	}
+	ids := newIdentifiers(names)
+	receiver := ids.allocate("m")
+	fmt.Fprintf(buff, "\n// %s mocks base method.\nfunc (%s *%s) %s(%s)%s {\n\t%s.ctrl.T.Helper()\n",
+		method.name, receiver, name, method.name, strings.Join(signature, ", "), returns, receiver)
+	args := strings.Join(slices.Concat([]string{""}, names), ", ")
+	if method.variadic {
+		varargs, arg := ids.allocate("varargs"), ids.allocate("a")
+		fmt.Fprintf(buff, "\t%[1]s := []any{%[2]s}\n\tfor _, %[3]s := range %[4]s {\n\t\t%[1]s = append(%[1]s, %[3]s)\n\t}\n",
+			varargs, strings.Join(names[:len(names)-1], ", "), arg, names[len(names)-1])
+		args = ", " + varargs + "..."
+	}
+	call := fmt.Sprintf("%[1]s.ctrl.Call(%[1]s, %[2]q%[3]s)", receiver, method.name, args)
+	if len(results) == 0 {
+		fmt.Fprintf(buff, "\t%s\n}\n", call)
+	} else {
+		ret := ids.allocate("ret")
+		fmt.Fprintf(buff, "\t%s := %s\n", ret, call)
+		var rets []string
+		for i, result := range results {
+			id := ids.allocate(fmt.Sprintf("ret%d", i))
+			fmt.Fprintf(buff, "\t%s, _ := %s[%d].(%s)\n", id, ret, i, result)
+			rets = append(rets, id)
+		}
+		fmt.Fprintf(buff, "\treturn %s\n}\n", strings.Join(rets, ", "))
+	}
+	recorderParams := strings.Join(names, ", ")
+	if method.variadic {
+		recorderParams = strings.Join(names[:len(names)-1], ", ")
+	}
+	if recorderParams != "" {
+		recorderParams += " any"
+	}
+	if method.variadic {
+		if recorderParams != "" {
+			recorderParams += ", "
+		}
+		recorderParams += names[len(names)-1] + " ...any"
+	}
+	ids = newIdentifiers(names)
+	receiver = ids.allocate("mr")
+	fmt.Fprintf(buff, "\n// %s indicates an expected call of %s.\nfunc (%s *%s) %s(%s) *gomock.Call {\n\t%s.mock.ctrl.T.Helper()\n",
+		method.name, method.name, receiver, recorder, method.name, recorderParams, receiver)
+	recordArgs := strings.Join(slices.Concat([]string{""}, names), ", ")
+	switch {
+	case method.variadic && len(names) == 1:
+		recordArgs = ", " + names[0] + "..."
+	case method.variadic:
+		varargs := ids.allocate("varargs")
+		fmt.Fprintf(buff, "\t%s := append([]any{%s}, %s...)\n", varargs, strings.Join(names[:len(names)-1], ", "), names[len(names)-1])
+		recordArgs = ", " + varargs + "..."
+	}
+	fmt.Fprintf(buff, "\treturn %[1]s.mock.ctrl.RecordCallWithMethodType(%[1]s.mock, %[2]q, reflect.TypeOf((*%[3]s)(nil).%[2]s)%[4]s)\n}\n",
+		receiver, method.name, name, recordArgs)
+}
+
+// identifiers - names taken in a generated method, the params of the mocked one.
+type identifiers map[string]bool
+
+func newIdentifiers(taken []string) identifiers {
+	ids := identifiers{}
+	for _, name := range taken {
+		ids[name] = true
+	}
+	return ids
+}
+
+// allocate returns want, or want_2, want_3... when it is taken.
+func (ids identifiers) allocate(want string) string {
+	id := want
+	for i := 2; ids[id]; i++ {
+		id = want + "_" + strconv.Itoa(i)
+	}
+	ids[id] = true
+	return id
+}
+
+// sanitize turns the last element of an import path into a package name.
+func sanitize(name string) string {
+	var result strings.Builder
+	for _, r := range name {
+		if unicode.IsLetter(r) || r == '_' || result.Len() > 0 && unicode.IsDigit(r) {
+			result.WriteRune(r)
+			continue
+		}
+		result.WriteRune('_')
+	}
+	if result.String() == "_" {
+		return "x"
+	}
+	return result.String()
+}
+
+// importOf returns the import path that file refers to by name. Packages named unlike the last element of their
+// path are loaded to compare their names.
+func (m *mock) importOf(file *ast.File, name string) (string, bool) {
+	for _, spec := range file.Imports {
+		if astfile.ImportName(spec) == name {
+			return strings.Trim(spec.Path.Value, `"`), true
+		}
+	}
+	for _, spec := range file.Imports {
+		importPath := strings.Trim(spec.Path.Value, `"`)
+		if spec.Name != nil {
+			continue
+		}
+		if loaded, err := m.resolver.load(importPath); err == nil && loaded.name == name {
+			return importPath, true
+		}
+	}
+	return "", false
+}
+
+// alias returns the name of the import of importPath in file when it is renamed, otherwise an empty string.
+func alias(file *ast.File, importPath string) string {
+	for _, spec := range file.Imports {
+		if spec.Name != nil && strings.Trim(spec.Path.Value, `"`) == importPath {
+			return spec.Name.Name
+		}
+	}
+	return ""
+}
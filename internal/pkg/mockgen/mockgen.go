@@ -0,0 +1,190 @@
+package mockgen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/tools/imports"
+)
+
+const (
+	directivePrefix = "//go:generate mockgen "
+	header          = "// Code generated by MockGen. DO NOT EDIT."
+)
+
+// directive - //go:generate mockgen line in source mode, paths are relative to dir, args are the flags as written.
+type directive struct {
+	dir         string
+	source      string
+	destination string
+	pkg         string
+	args        []string
+}
+
+// Generate writes the mocks requested by the //go:generate mockgen directives of sources, the Go files generated by
+// creathor, the way mockgen does in source mode, so the mocks are the same as after go generate. The directives of
+// other files belong to the project and are left for go generate. It returns the written files.
+func Generate(directory string, sources []string) ([]string, error) {
+	if directory == "" {
+		directory = "."
+	}
+	resolver, err := newResolver(directory)
+	if err != nil {
+		return nil, err
+	}
+	var written []string
+	var errs []error
+	for _, source := range sources {
+		if !strings.HasSuffix(source, ".go") {
+			continue
+		}
+		directives, err := scan(source)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, d := range directives {
+			filePath, changed, err := d.generate(resolver)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", filepath.Join(d.dir, d.source), err))
+				continue
+			}
+			if changed {
+				written = append(written, filePath)
+			}
+		}
+	}
+	return written, errors.Join(errs...)
+}
+
+// Mocks returns the mocks generated by mockgen for the directives of the Go file that exist, a pruned file is removed
+// with them.
+func Mocks(filePath string) ([]string, error) {
+	directives, err := scan(filePath)
+	if err != nil {
+		return nil, err
+	}
+	var mocks []string
+	for _, d := range directives {
+		destination := filepath.Join(d.dir, d.destination)
+		src, err := os.ReadFile(destination)
+		if err == nil && bytes.HasPrefix(src, []byte(header)) {
+			mocks = append(mocks, destination)
+		}
+	}
+	return mocks, nil
+}
+
+// scan reads the mockgen directives of a Go file.
+func scan(filePath string) ([]directive, error) {
+	src, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Contains(src, []byte(directivePrefix)) {
+		return nil, nil
+	}
+	var directives []directive
+	for _, line := range strings.Split(string(src), "\n") {
+		args, ok := strings.CutPrefix(strings.TrimSpace(line), directivePrefix)
+		if !ok {
+			continue
+		}
+		d := parseDirective(filepath.Dir(filePath), strings.Fields(args))
+		if d.source == "" || d.destination == "" {
+			fmt.Printf("%s: only mockgen -source with -destination is generated in-process, run go generate\n", filePath)
+			continue
+		}
+		directives = append(directives, d)
+	}
+	return directives, nil
+}
+
+// parseDirective reads the flags of mockgen that define the mock, the others are ignored.
+func parseDirective(dir string, args []string) directive {
+	d := directive{dir: dir, args: args}
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") {
+			continue
+		}
+		name, value, ok := strings.Cut(strings.TrimLeft(args[i], "-"), "=")
+		if !ok && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+			value = args[i+1]
+			i++
+		}
+		switch name {
+		case "source":
+			d.source = value
+		case "destination":
+			d.destination = value
+		case "package":
+			d.pkg = value
+		}
+	}
+	return d
+}
+
+// generate writes the mock of every interface of the source file, changed is false when the mock is up to date.
+func (d directive) generate(resolver *resolver) (string, bool, error) {
+	sourcePath := filepath.Join(d.dir, d.source)
+	destination := filepath.Join(d.dir, d.destination)
+	fileset := token.NewFileSet()
+	file, err := parser.ParseFile(fileset, sourcePath, nil, parser.SkipObjectResolution)
+	if err != nil {
+		return "", false, err
+	}
+	pkg, err := resolver.loadDir(filepath.Dir(sourcePath))
+	if err != nil {
+		return "", false, err
+	}
+	packageName := d.pkg
+	if packageName == "" {
+		packageName = "mock_" + file.Name.Name
+	}
+	source := &scope{file: file, pkg: pkg}
+	mock := newMock(packageName, resolver)
+	if filepath.Dir(destination) != filepath.Dir(sourcePath) || packageName != file.Name.Name {
+		importPath, err := resolver.importPath(filepath.Dir(sourcePath))
+		if err != nil {
+			return "", false, err
+		}
+		source.qualifier = importPath
+	}
+	interfaces, err := mock.interfaces(source)
+	if err != nil {
+		return "", false, err
+	}
+	src := mock.render(d.source, strings.Join(append([]string{"mockgen"}, d.args...), " "), interfaces)
+	abs, err := filepath.Abs(destination)
+	if err != nil {
+		return "", false, err
+	}
+	// mockgen formats its output with goimports, which runs go/format
+	formatted, err := imports.Process(abs, src, nil)
+	if err != nil {
+		return "", false, err
+	}
+	if current, err := os.ReadFile(destination); err == nil && bytes.Equal(current, formatted) {
+		return destination, false, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(destination), 0777); err != nil {
+		return "", false, err
+	}
+	return destination, true, os.WriteFile(destination, formatted, 0666)
+}
+
+// modulePath returns the module path of the go.mod in directory, or an empty string without one.
+func modulePath(directory string) string {
+	data, err := os.ReadFile(filepath.Join(directory, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	return modfile.ModulePath(data)
+}
@@ -0,0 +1,102 @@
+package mockgen
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+// The golden files are the output of mockgen v0.5.0 run with the directive of the source from its directory.
+var goldenMocks = []struct {
+	source      string
+	destination string
+}{
+	{source: "basic/basic.go", destination: "basic/basic_mock.go"},
+	{source: "other/other.go", destination: "other/mocks/other_mock.go"},
+}
+
+func project(t *testing.T) string {
+	t.Helper()
+	directory := t.TempDir()
+	if err := os.CopyFS(directory, os.DirFS(filepath.Join("testdata", "src"))); err != nil {
+		t.Fatal(err)
+	}
+	return directory
+}
+
+func TestGenerate(t *testing.T) {
+	directory := project(t)
+	var sources []string
+	for _, mock := range goldenMocks {
+		sources = append(sources, filepath.Join(directory, mock.source))
+	}
+	written, err := Generate(directory, sources)
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	for _, mock := range goldenMocks {
+		t.Run(mock.source, func(t *testing.T) {
+			destination := filepath.Join(directory, mock.destination)
+			if !slices.Contains(written, destination) {
+				t.Errorf("Generate() written = %v, want %s", written, destination)
+			}
+			got, err := os.ReadFile(destination)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want, err := os.ReadFile(filepath.Join("testdata", "src", mock.destination+".golden"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != string(want) {
+				t.Errorf("Generate() %s differs from mockgen:\n%s", mock.destination, got)
+			}
+		})
+	}
+	t.Run("idempotent", func(t *testing.T) {
+		written, err := Generate(directory, sources)
+		if err != nil {
+			t.Fatalf("Generate() error = %v", err)
+		}
+		if len(written) > 0 {
+			t.Errorf("Generate() written = %v, want nothing", written)
+		}
+	})
+}
+
+func TestGenerate_untracked(t *testing.T) {
+	directory := project(t)
+	written, err := Generate(directory, []string{filepath.Join(directory, "basic", "basic.go")})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	if len(written) != 1 {
+		t.Errorf("Generate() written = %v, want basic_mock.go only", written)
+	}
+	if _, err := os.Stat(filepath.Join(directory, "owned", "owned_mock.go")); !os.IsNotExist(err) {
+		t.Errorf("Generate() wrote the mock of owned.go, which is not a generated file")
+	}
+}
+
+func TestMocks(t *testing.T) {
+	directory := project(t)
+	source := filepath.Join(directory, "basic", "basic.go")
+	mocks, err := Mocks(source)
+	if err != nil {
+		t.Fatalf("Mocks() error = %v", err)
+	}
+	if len(mocks) > 0 {
+		t.Errorf("Mocks() = %v before generating, want nothing", mocks)
+	}
+	if _, err := Generate(directory, []string{source}); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+	mocks, err = Mocks(source)
+	if err != nil {
+		t.Fatalf("Mocks() error = %v", err)
+	}
+	if want := []string{filepath.Join(directory, "basic", "basic_mock.go")}; !slices.Equal(mocks, want) {
+		t.Errorf("Mocks() = %v, want %v", mocks, want)
+	}
+}
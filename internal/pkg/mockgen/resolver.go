@@ -0,0 +1,133 @@
+package mockgen
+
+import (
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// pkg - parsed Go files of a directory without tests.
+type pkg struct {
+	name  string
+	files []*ast.File
+}
+
+// find returns the interface typeName declared in the package with the file declaring it.
+func (p *pkg) find(typeName string) (*ast.InterfaceType, *ast.File, bool) {
+	for _, file := range p.files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if typeSpec.Name.Name != typeName {
+					continue
+				}
+				iface, ok := typeSpec.Type.(*ast.InterfaceType)
+				return iface, file, ok
+			}
+		}
+	}
+	return nil, nil, false
+}
+
+// declares reports whether the package declares the type typeName.
+func (p *pkg) declares(typeName string) bool {
+	for _, file := range p.files {
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				if spec.(*ast.TypeSpec).Name.Name == typeName {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// resolver finds the packages of embedded interfaces. Packages of the module are found by their directories,
+// packages of the standard library in GOROOT, other dependencies are not supported.
+type resolver struct {
+	root     string
+	module   string
+	packages map[string]*pkg
+}
+
+func newResolver(directory string) (*resolver, error) {
+	root, err := filepath.Abs(directory)
+	if err != nil {
+		return nil, err
+	}
+	return &resolver{root: root, module: modulePath(directory), packages: map[string]*pkg{}}, nil
+}
+
+func (r *resolver) load(importPath string) (*pkg, error) {
+	switch {
+	case r.module != "" && (importPath == r.module || strings.HasPrefix(importPath, r.module+"/")):
+		return r.loadDir(filepath.Join(r.root, filepath.FromSlash(strings.TrimPrefix(importPath, r.module))))
+	case !strings.Contains(strings.Split(importPath, "/")[0], "."):
+		return r.loadDir(filepath.Join(build.Default.GOROOT, "src", filepath.FromSlash(importPath)))
+	}
+	return nil, fmt.Errorf("embedded interfaces of %s are not supported, only the module and the standard library", importPath)
+}
+
+func (r *resolver) loadDir(dir string) (*pkg, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	if loaded, ok := r.packages[dir]; ok {
+		return loaded, nil
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	loaded := &pkg{}
+	fileset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(fileset, filepath.Join(dir, name), nil, parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		if loaded.name == "" {
+			loaded.name = file.Name.Name
+		}
+		if file.Name.Name == loaded.name {
+			loaded.files = append(loaded.files, file)
+		}
+	}
+	r.packages[dir] = loaded
+	return loaded, nil
+}
+
+// importPath returns the import path of a directory of the module.
+func (r *resolver) importPath(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	relative, err := filepath.Rel(r.root, dir)
+	if err != nil || r.module == "" || strings.HasPrefix(relative, "..") {
+		return "", fmt.Errorf("%s is not a package of the module", dir)
+	}
+	if relative == "." {
+		return r.module, nil
+	}
+	return r.module + "/" + filepath.ToSlash(relative), nil
+}
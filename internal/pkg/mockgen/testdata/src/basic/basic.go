@@ -0,0 +1,51 @@
+package basic
+
+//go:generate mockgen -source=basic.go -package=basic -destination=basic_mock.go
+
+import (
+	"context"
+	stdjson "encoding/json"
+	"io"
+	"time"
+)
+
+type Entity struct {
+	ID   string
+	Tags []string
+}
+
+// Repository - interface with the shapes of methods the generators produce.
+type Repository interface {
+	Create(ctx context.Context, entity *Entity) error
+	Get(context.Context, string) (*Entity, error)
+	List(ctx context.Context, limit, offset int) ([]*Entity, uint64, error)
+	Delete(_ context.Context, id string)
+}
+
+type Logger interface {
+	Info(msg string, fields ...any)
+	Printf(format string, args ...any)
+	Sync(...string) error
+}
+
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+	Ticks(chan<- time.Time, chan struct{})
+}
+
+type Codec interface {
+	io.Reader
+	error
+	Marshal(v interface{}) ([]byte, error)
+	Decoder(r io.Reader) *stdjson.Decoder
+	Walk(fn func(key string, value any) error, keys map[string][2]int)
+}
+
+type Store interface {
+	Repository
+	Logger
+	Close(m, ret string) (ret0 int, varargs bool)
+}
+
+type empty interface{}
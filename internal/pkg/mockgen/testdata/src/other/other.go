@@ -0,0 +1,20 @@
+package other
+
+//go:generate mockgen -source=other.go -package=mocks -destination=mocks/other_mock.go
+
+import (
+	"context"
+)
+
+type Filter struct {
+	Search *string
+}
+
+type Item struct {
+	Name string
+}
+
+type Finder interface {
+	Find(ctx context.Context, filter Filter) ([]Item, error)
+	First(context.Context, *Filter) (*Item, error)
+}
@@ -0,0 +1,7 @@
+package owned
+
+//go:generate mockgen -source=owned.go -package=owned -destination=owned_mock.go
+
+type Service interface {
+	Run() error
+}
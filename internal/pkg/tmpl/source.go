@@ -0,0 +1,76 @@
+package tmpl
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/mikalai-mitsin/creathor/internal/pkg/errs"
+)
+
+const root = "templates"
+
+// source is the file system templates are parsed from, UseDirectory layers a directory over the embedded one.
+var source fs.FS = content
+
+// overlayFS serves templates/<name> from dir/<name> when it exists there and from the embedded templates otherwise.
+type overlayFS struct {
+	dir  fs.FS
+	base fs.FS
+}
+
+func (o overlayFS) Open(name string) (fs.File, error) {
+	if relative, ok := strings.CutPrefix(name, root+"/"); ok {
+		file, err := o.dir.Open(relative)
+		if err == nil {
+			return file, nil
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, err
+		}
+	}
+	return o.base.Open(name)
+}
+
+// UseDirectory overrides the embedded templates with the ones found in directory by relative path,
+// e.g. <directory>/Dockerfile.tmpl replaces the default Dockerfile. Templates missing there keep the defaults.
+func UseDirectory(directory string) error {
+	info, err := os.Stat(directory)
+	if err != nil || !info.IsDir() {
+		return errs.NewTemplatesDirectoryError(directory)
+	}
+	source = overlayFS{dir: os.DirFS(directory), base: content}
+	return nil
+}
+
+// Export writes the embedded templates to directory, keeping the layout UseDirectory expects.
+// Files that already exist are left untouched.
+func Export(directory string) error {
+	return fs.WalkDir(content, root, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		destination := filepath.Join(directory, filepath.FromSlash(strings.TrimPrefix(name, root+"/")))
+		if _, err := os.Stat(destination); err == nil {
+			fmt.Printf("%s already exists.\n", destination)
+			return nil
+		}
+		data, err := fs.ReadFile(content, name)
+		if err != nil {
+			return err
+		}
+		if err := os.MkdirAll(filepath.Dir(destination), 0777); err != nil {
+			return errs.NewPermissionError(destination)
+		}
+		if err := os.WriteFile(destination, data, 0666); err != nil {
+			return errs.NewPermissionError(destination)
+		}
+		return nil
+	})
+}
@@ -10,6 +10,7 @@ import (
 	"text/template"
 
 	"github.com/mikalai-mitsin/creathor/internal/pkg/errs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/manifest"
 
 	"golang.org/x/text/cases"
 	"golang.org/x/text/language"
@@ -53,7 +54,7 @@ func (t *Template) RenderToFile(data interface{}) error {
 		return nil
 	}
 	a := path.Base(t.SourcePath)
-	tmpl, err := template.New(a).Funcs(funcMap).ParseFS(content, t.SourcePath)
+	tmpl, err := template.New(a).Funcs(funcMap).ParseFS(source, t.SourcePath)
 	if err != nil {
 		e := errs.NewBadTemplateError(err.Error())
 		e.AddParam("template", t.SourcePath)
@@ -72,5 +73,6 @@ func (t *Template) RenderToFile(data interface{}) error {
 	if err := tmpl.Execute(file, data); err != nil {
 		return errs.NewUnexpectedBehaviorError(err.Error())
 	}
+	manifest.Generated(t.DestinationPath)
 	return nil
 }
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
+	"errors"
 
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/layout"
 
@@ -10,24 +12,45 @@ import (
 	"os"
 	"os/exec"
 	"path"
+	"path/filepath"
 	"strings"
 
+	"github.com/mikalai-mitsin/creathor/internal/app/doctor"
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/pkg"
+	"github.com/mikalai-mitsin/creathor/internal/app/importer"
 
 	"github.com/mikalai-mitsin/creathor/internal/app/generator/app"
+	"github.com/mikalai-mitsin/creathor/internal/app/generator/plugin"
 
 	"github.com/iancoleman/strcase"
 	"github.com/mikalai-mitsin/creathor/internal/pkg/configs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/errs"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/goimports"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/manifest"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/mockgen"
+	"github.com/mikalai-mitsin/creathor/internal/pkg/tmpl"
 	"github.com/urfave/cli/v2"
 )
 
+//go:generate go run . schema --output docs/creathor.schema.json
+
 var version string
 
 var (
 	destinationPath = "."
 	configPath      = "./creathor.yaml"
+	templatesPath   = ""
+	previousCommand = ""
+	dryRun          = false
+	assumeYes       = false
+	schemaPath      = ""
+	importApp       = ""
+	skipPostInit    = false
 )
 
+// skipPostInitEnv turns the post init off, upgrade sets it for the version generating the base.
+const skipPostInitEnv = "CREATHOR_SKIP_POST_INIT"
+
 func main() {
 	application := &cli.App{
 		Name:    "Creathor",
@@ -48,8 +71,106 @@ func main() {
 				Required:    false,
 				Value:       configPath,
 			},
+			&cli.StringFlag{
+				Name:        "templates",
+				Usage:       "directory with templates overriding the built-in ones",
+				Destination: &templatesPath,
+				Required:    false,
+			},
+			&cli.BoolFlag{
+				Name:        "skip-post-init",
+				Usage:       "only generate the code, without the formatting, mocks, buf, go mod tidy and docs",
+				EnvVars:     []string{skipPostInitEnv},
+				Destination: &skipPostInit,
+				Required:    false,
+			},
 		},
 		Action: initProject,
+		Commands: []*cli.Command{
+			{
+				Name:  "upgrade",
+				Usage: "merge the output of this version into a project generated by an older one",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "previous",
+						Usage:       "command running the creathor version that generated the project, go run of the version from the manifest by default",
+						Destination: &previousCommand,
+						Required:    false,
+					},
+				},
+				Action: upgradeProject,
+			},
+			{
+				Name:  "prune",
+				Usage: "remove the generated code that the config does not generate anymore",
+				Flags: []cli.Flag{
+					&cli.BoolFlag{
+						Name:        "dry-run",
+						Usage:       "list the files and declarations without removing them",
+						Destination: &dryRun,
+						Required:    false,
+					},
+					&cli.BoolFlag{
+						Name:        "yes",
+						Aliases:     []string{"y"},
+						Usage:       "do not ask for confirmation",
+						Destination: &assumeYes,
+						Required:    false,
+					},
+				},
+				Action: pruneProject,
+			},
+			{
+				Name:   "doctor",
+				Usage:  "check the tools, the config and the wiring of the project",
+				Action: doctorProject,
+			},
+			{
+				Name:  "schema",
+				Usage: "print the JSON Schema of the config",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:        "output",
+						Aliases:     []string{"o"},
+						Usage:       "write the schema to a file instead of stdout",
+						Destination: &schemaPath,
+						Required:    false,
+					},
+				},
+				Action: printSchema,
+			},
+			{
+				Name:  "import",
+				Usage: "add entities to the config from an existing database schema",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "sql",
+						Usage:     "read the tables of PostgreSQL DDL files, like pg_dump --schema-only or up migrations",
+						ArgsUsage: "<file.sql>...",
+						Flags: []cli.Flag{
+							&cli.StringFlag{
+								Name:        "app",
+								Usage:       "app of the entities, the first app of the config by default",
+								Destination: &importApp,
+							},
+						},
+						Action: importSQL,
+					},
+				},
+			},
+			{
+				Name:  "templates",
+				Usage: "manage project templates",
+				Subcommands: []*cli.Command{
+					{
+						Name:      "export",
+						Usage:     "write the built-in templates to a directory for customisation",
+						ArgsUsage: "[directory]",
+						Action:    exportTemplates,
+					},
+				},
+			},
+		},
 	}
 	strcase.ConfigureAcronym("UUID", "uuid")
 	if err := application.Run(os.Args); err != nil {
@@ -62,6 +183,32 @@ func initProject(_ *cli.Context) error {
 	if err != nil {
 		return err
 	}
+	if err := useTemplates(project); err != nil {
+		return err
+	}
+	generation, err := manifest.Load(destinationPath, version)
+	if err != nil {
+		return err
+	}
+	if err := generation.Prepare(); err != nil {
+		return err
+	}
+	if err := generate(project); err != nil {
+		return errors.Join(err, generation.Restore())
+	}
+	if err := generation.Collect(); err != nil {
+		return err
+	}
+	generation.Report()
+	// the manifest is saved even when a post init step fails, the generated code is on disk already
+	postInitErr := postInit(project, generation.Written(), generation.Tracked())
+	if err := generation.Save(); err != nil {
+		return err
+	}
+	return postInitErr
+}
+
+func generate(project *configs.Project) error {
 	layoutGenerator := layout.NewGenerator(project)
 	if err := layoutGenerator.Sync(); err != nil {
 		return err
@@ -85,57 +232,309 @@ func initProject(_ *cli.Context) error {
 			return err
 		}
 	}
-	if err := postInit(project); err != nil {
-		return err
+	for _, pluginConfig := range project.Plugins {
+		pluginGenerator := plugin.NewGenerator(project, pluginConfig, version)
+		if err := pluginGenerator.Sync(); err != nil {
+			return err
+		}
 	}
 	return nil
 }
 
-func postInit(project *configs.Project) error {
-	fmt.Println("post init...")
+// useTemplates layers the --templates directory, or the templates directory of the config relative to the
+// destination, over the built-in templates.
+func useTemplates(project *configs.Project) error {
+	directory, err := templatesDirectory(project)
+	if err != nil || directory == "" {
+		return err
+	}
+	return tmpl.UseDirectory(directory)
+}
+
+// templatesDirectory returns the absolute path of the templates directory, or an empty string when the built-in
+// templates are used.
+func templatesDirectory(project *configs.Project) (string, error) {
+	directory := templatesPath
+	if directory == "" && project.Templates != "" {
+		directory = project.Templates
+		if !path.IsAbs(directory) {
+			directory = path.Join(destinationPath, directory)
+		}
+	}
+	if directory == "" {
+		return "", nil
+	}
+	return filepath.Abs(directory)
+}
+
+// upgradeProject generates the project with the version recorded in the manifest and with this one in temporary
+// directories, and merges the difference into the project declaration by declaration.
+func upgradeProject(_ *cli.Context) error {
+	project, err := configs.NewProject(path.Join(destinationPath, configPath))
+	if err != nil {
+		return err
+	}
+	generation, err := manifest.Load(destinationPath, version)
+	if err != nil {
+		return err
+	}
+	templates, err := templatesDirectory(project)
+	if err != nil {
+		return err
+	}
+	previous := strings.Fields(previousCommand)
+	switch {
+	case len(previous) > 0 && templates != "":
+		// releases older than the manifest do not define --templates, the command is passed as it is
+		fmt.Printf("templates %s are not passed to --previous, add --templates to it if that version has the flag\n", templates)
+	case len(previous) > 0:
+	case generation.Version == "":
+		return errs.NewUpgradeError("the manifest does not record the version that generated the project, use --previous")
+	default:
+		// every version that records itself in the manifest has --templates
+		previous = []string{"go", "run", "github.com/mikalai-mitsin/creathor@v" + strings.TrimPrefix(generation.Version, "v")}
+		if templates != "" {
+			previous = append(previous, "--templates", templates)
+		}
+	}
+	workspace, err := os.MkdirTemp("", "creathor-upgrade-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(workspace)
+	base := filepath.Join(workspace, "base")
+	if err := copyConfig(base); err != nil {
+		return err
+	}
 	var errb bytes.Buffer
-	if project.GRPCEnabled {
-		bufUpdate := exec.Command("buf", "dep", "update")
-		bufUpdate.Dir = path.Join(destinationPath, "api", "proto")
-		bufUpdate.Stderr = &errb
-		fmt.Println(strings.Join(bufUpdate.Args, " "))
-		if err := bufUpdate.Run(); err != nil {
-			fmt.Println(errb.String())
+	previousGenerate := exec.Command(previous[0], previous[1:]...)
+	previousGenerate.Dir = base
+	// the base is only compared with the new output, which has no post init either, it runs once on the merged result
+	previousGenerate.Env = append(os.Environ(), skipPostInitEnv+"=true")
+	previousGenerate.Stderr = &errb
+	fmt.Println(strings.Join(previousGenerate.Args, " "))
+	if err := previousGenerate.Run(); err != nil {
+		details := strings.TrimSpace(errb.String())
+		if details == "" {
+			details = err.Error()
 		}
-		bufGenerate := exec.Command("buf", "generate")
-		bufGenerate.Dir = destinationPath
-		bufGenerate.Stderr = &errb
-		fmt.Println(strings.Join(bufGenerate.Args, " "))
-		if err := bufGenerate.Run(); err != nil {
-			fmt.Println(errb.String())
+		return errs.NewUpgradeError(details)
+	}
+	upgraded, err := generateIn(filepath.Join(workspace, "next"), templates)
+	if err != nil {
+		return err
+	}
+	if err := generation.Upgrade(base, upgraded); err != nil {
+		return err
+	}
+	generation.Report()
+	postInitErr := postInit(project, generation.Written(), generation.Tracked())
+	if err := generation.Save(); err != nil {
+		return err
+	}
+	return postInitErr
+}
+
+// pruneProject generates the project from the config in a temporary directory and removes the generated code that
+// is not generated anymore, after a confirmation.
+func pruneProject(_ *cli.Context) error {
+	project, err := configs.NewProject(path.Join(destinationPath, configPath))
+	if err != nil {
+		return err
+	}
+	generation, err := manifest.Load(destinationPath, version)
+	if err != nil {
+		return err
+	}
+	templates, err := templatesDirectory(project)
+	if err != nil {
+		return err
+	}
+	workspace, err := os.MkdirTemp("", "creathor-prune-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(workspace)
+	next, err := generateIn(workspace, templates)
+	if err != nil {
+		return err
+	}
+	stale, err := generation.Stale(next)
+	if err != nil {
+		return err
+	}
+	if len(stale) == 0 {
+		fmt.Println("nothing to prune")
+		return nil
+	}
+	for _, item := range stale {
+		fmt.Println(item)
+	}
+	if dryRun {
+		return nil
+	}
+	if !assumeYes {
+		fmt.Print("prune? [y/N] ")
+		answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		if !strings.EqualFold(strings.TrimSpace(answer), "y") {
+			return nil
 		}
 	}
-	clean := exec.Command("task", "clean")
-	clean.Dir = destinationPath
-	fmt.Println(strings.Join(clean.Args, " "))
-	if err := clean.Run(); err != nil {
-		fmt.Println(errb.String())
-	}
-	generate := exec.Command("go", "generate", "./...")
-	generate.Dir = destinationPath
-	generate.Stderr = &errb
-	fmt.Println(strings.Join(generate.Args, " "))
-	if err := generate.Run(); err != nil {
-		fmt.Println(errb.String())
-	}
-	tidy := exec.Command("go", "mod", "tidy")
-	tidy.Dir = destinationPath
-	tidy.Stderr = &errb
-	fmt.Println(strings.Join(tidy.Args, " "))
-	if err := tidy.Run(); err != nil {
-		fmt.Println(errb.String())
-	}
-	docs := exec.Command("task", "docs")
-	docs.Dir = destinationPath
-	docs.Stderr = &errb
-	fmt.Println(strings.Join(docs.Args, " "))
-	if err := docs.Run(); err != nil {
-		fmt.Println(errb.String())
+	if err := generation.Prune(next, stale); err != nil {
+		return err
+	}
+	postInitErr := postInit(project, generation.Written(), generation.Tracked())
+	if err := generation.Save(); err != nil {
+		return err
+	}
+	return postInitErr
+}
+
+// copyConfig creates directory with a copy of the config of the project.
+func copyConfig(directory string) error {
+	config, err := os.ReadFile(path.Join(destinationPath, configPath))
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(directory, 0777); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(directory, "creathor.yaml"), config, 0666)
+}
+
+// generateIn generates the project into directory without the post init steps and returns its manifest.
+func generateIn(directory, templates string) (*manifest.Manifest, error) {
+	if err := copyConfig(directory); err != nil {
+		return nil, err
+	}
+	project, err := configs.NewProject(filepath.Join(directory, "creathor.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	if templates != "" {
+		if err := tmpl.UseDirectory(templates); err != nil {
+			return nil, err
+		}
+	}
+	workdir, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chdir(directory); err != nil {
+		return nil, err
+	}
+	defer os.Chdir(workdir)
+	generation, err := manifest.Load(directory, version)
+	if err != nil {
+		return nil, err
+	}
+	if err := generation.Prepare(); err != nil {
+		return nil, err
+	}
+	if err := generate(project); err != nil {
+		return nil, err
+	}
+	if err := generation.Collect(); err != nil {
+		return nil, err
+	}
+	return generation, generation.Save()
+}
+
+// doctorProject prints the result of every check and fails when any of them failed.
+func doctorProject(_ *cli.Context) error {
+	checks := doctor.NewDoctor(destinationPath, path.Join(destinationPath, configPath)).Run()
+	for _, check := range checks {
+		fmt.Println(check)
+	}
+	if failed := doctor.Failed(checks); failed > 0 {
+		return errs.NewDoctorError(failed)
+	}
+	return nil
+}
+
+// importSQL adds the tables of the files to the config and records them as migrations.
+func importSQL(ctx *cli.Context) error {
+	if !ctx.Args().Present() {
+		return errs.NewImportError("pass the SQL files to import")
+	}
+	report, err := importer.NewImporter(destinationPath, path.Join(destinationPath, configPath), importApp).
+		ImportSQL(ctx.Args().Slice())
+	if err != nil {
+		return err
+	}
+	fmt.Println(report)
+	return nil
+}
+
+func printSchema(_ *cli.Context) error {
+	schema, err := configs.Schema()
+	if err != nil {
+		return err
+	}
+	if schemaPath == "" {
+		_, err := os.Stdout.Write(schema)
+		return err
+	}
+	return os.WriteFile(schemaPath, schema, 0666)
+}
+
+func exportTemplates(ctx *cli.Context) error {
+	directory := "templates"
+	if ctx.Args().Present() {
+		directory = ctx.Args().First()
+	}
+	return tmpl.Export(directory)
+}
+
+// postInit finishes the project: the protobuf code, the formatting and imports of the written Go files, the mocks of
+// the generated ones, go.mod and the docs when the project has a Taskfile. Every step runs even when a previous one
+// fails, the failed ones are summarised at the end.
+func postInit(project *configs.Project, written, generated []string) error {
+	if skipPostInit {
+		return nil
+	}
+	fmt.Println("post init...")
+	var failed []string
+	step := func(name string, err error) {
+		if err != nil {
+			failed = append(failed, fmt.Sprintf("%s: %s", name, strings.TrimSpace(err.Error())))
+		}
+	}
+	if project.GRPCEnabled {
+		step("buf dep update", command(path.Join(destinationPath, "api", "proto"), "buf", "dep", "update"))
+		step("buf generate", command(destinationPath, "buf", "generate"))
+	}
+	fmt.Println("format")
+	step("format", goimports.Process(written))
+	fmt.Println("mocks")
+	_, err := mockgen.Generate(destinationPath, generated)
+	step("mocks", err)
+	step("go mod tidy", command(destinationPath, "go", "mod", "tidy"))
+	if project.TaskEnabled {
+		step("task docs", command(destinationPath, "task", "docs"))
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	fmt.Println("post init failed:")
+	for _, item := range failed {
+		fmt.Printf("  %s\n", strings.ReplaceAll(item, "\n", "\n    "))
+	}
+	return errs.NewPostInitError(strings.Join(failed, "\n"))
+}
+
+// command runs an external tool in directory, the error of a failed run holds its stderr.
+func command(directory string, name string, args ...string) error {
+	var errb bytes.Buffer
+	cmd := exec.Command(name, args...)
+	cmd.Dir = directory
+	cmd.Stderr = &errb
+	fmt.Println(strings.Join(cmd.Args, " "))
+	if err := cmd.Run(); err != nil {
+		if details := strings.TrimSpace(errb.String()); details != "" {
+			return errors.New(details)
+		}
+		return err
 	}
 	return nil
 }